@@ -3,6 +3,7 @@ module github.com/stader-labs/stader-node
 go 1.16
 
 require (
+	filippo.io/age v1.0.0
 	github.com/Microsoft/go-winio v0.6.0 // indirect
 	github.com/a8m/envsubst v1.3.0
 	github.com/alessio/shellescape v1.4.1
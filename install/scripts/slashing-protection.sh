@@ -0,0 +1,118 @@
+#!/bin/sh
+# This work is licensed and released under GNU GPL v3 or any other later versions.
+# The full text of the license is below/ found at <http://www.gnu.org/licenses/>
+
+# (c) 2023 Rocket Pool Pty Ltd. Modified under GNU GPL v3. [1.4.7]
+
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU General Public License as published by
+# the Free Software Foundation, either version 3 of the License, or
+# (at your option) any later version.
+
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU General Public License for more details.
+
+# You should have received a copy of the GNU General Public License
+# along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+
+# This script exports or imports the EIP-3076 slashing protection history of the Stader validator client; only edit if you know what you're doing ;)
+# Usage: slashing-protection.sh export|import FILE
+
+ACTION=$1
+FILE=$2
+
+if [ "$ACTION" != "export" ] && [ "$ACTION" != "import" ]; then
+    echo "Usage: slashing-protection.sh export|import FILE"
+    exit 1
+fi
+if [ -z "$FILE" ]; then
+    echo "No slashing protection file was provided."
+    exit 1
+fi
+
+# Set up the network-based flags
+if [ "$NETWORK" = "mainnet" ]; then
+    LH_NETWORK_ARG="--network mainnet"
+    LODESTAR_NETWORK_ARG="--network mainnet"
+    PRYSM_NETWORK="--mainnet"
+    TEKU_NETWORK="mainnet"
+elif [ "$NETWORK" = "prater" ] || [ "$NETWORK" = "devnet" ]; then
+    LH_NETWORK_ARG="--network prater"
+    LODESTAR_NETWORK_ARG="--network goerli"
+    PRYSM_NETWORK="--prater"
+    TEKU_NETWORK="prater"
+elif [ "$NETWORK" = "zhejiang" ]; then
+    LH_NETWORK_ARG="--testnet-dir=/zhejiang"
+    LODESTAR_NETWORK_ARG="--paramsFile=/zhejiang/config.yaml"
+    PRYSM_NETWORK="--chain-config-file=/zhejiang/config.yaml"
+    TEKU_NETWORK="/zhejiang/config.yaml"
+else
+    echo "Unknown network [$NETWORK]"
+    exit 1
+fi
+
+# Lighthouse
+if [ "$CC_CLIENT" = "lighthouse" ]; then
+    exec /usr/local/bin/lighthouse account validator slashing-protection $ACTION $FILE \
+        $LH_NETWORK_ARG \
+        --datadir /validators/lighthouse
+fi
+
+# Lodestar
+if [ "$CC_CLIENT" = "lodestar" ]; then
+    exec /usr/app/node_modules/.bin/lodestar validator slashing-protection $ACTION \
+        $LODESTAR_NETWORK_ARG \
+        --dataDir /validators/lodestar \
+        --beacon-nodes $CC_API_ENDPOINT \
+        --file $FILE
+fi
+
+# Nimbus; the validator client can't do this itself, so the Stader node runs the export with the beacon node image
+if [ "$CC_CLIENT" = "nimbus" ]; then
+    NIMBUS_BN=/home/user/nimbus-eth2/build/nimbus_beacon_node
+    if [ ! -x "$NIMBUS_BN" ]; then
+        echo "The Nimbus slashing protection database can only be managed with nimbus_beacon_node, which is not in this image."
+        exit 1
+    fi
+    exec $NIMBUS_BN slashingdb $ACTION $FILE \
+        --data-dir=/ethclient/nimbus_vc \
+        --validators-dir=/validators/nimbus/validators
+fi
+
+# Prysm; it locks its database while the validator client runs, so the Stader node stops it around an export
+if [ "$CC_CLIENT" = "prysm" ]; then
+    if [ "$ACTION" = "export" ]; then
+        EXPORT_DIR=$(mktemp -d)
+        /app/cmd/validator/validator slashing-protection-history export \
+            --accept-terms-of-use \
+            $PRYSM_NETWORK \
+            --datadir=/validators/prysm-non-hd \
+            --slashing-protection-export-dir=$EXPORT_DIR || exit 1
+        mv $EXPORT_DIR/slashing_protection.json $FILE
+        rm -rf $EXPORT_DIR
+        exit 0
+    fi
+    exec /app/cmd/validator/validator slashing-protection-history import \
+        --accept-terms-of-use \
+        $PRYSM_NETWORK \
+        --datadir=/validators/prysm-non-hd \
+        --slashing-protection-json-file=$FILE
+fi
+
+# Teku
+if [ "$CC_CLIENT" = "teku" ]; then
+    if [ "$ACTION" = "export" ]; then
+        exec /opt/teku/bin/teku slashing-protection export \
+            --data-path=/validators/teku \
+            --to=$FILE
+    fi
+    exec /opt/teku/bin/teku slashing-protection import \
+        --data-path=/validators/teku \
+        --from=$FILE
+fi
+
+echo "Unknown validator client [$CC_CLIENT]"
+exit 1
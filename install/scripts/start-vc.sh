@@ -53,6 +53,15 @@ if [ ! -f "/validators/$FEE_RECIPIENT_FILE" ]; then
     exit 1
 fi
 
+# Import the slashing protection history restored from a backup before the client starts signing
+if [ -f "/validators/slashing-protection.json" ]; then
+    if ! sh /setup/slashing-protection.sh import /validators/slashing-protection.json; then
+        echo "Could not import the restored slashing protection history in /validators/slashing-protection.json, refusing to start."
+        exit 1
+    fi
+    mv /validators/slashing-protection.json /validators/slashing-protection.json.imported
+fi
+
 
 # Lighthouse startup
 if [ "$CC_CLIENT" = "lighthouse" ]; then
@@ -138,6 +147,10 @@ if [ "$CC_CLIENT" = "lodestar" ]; then
         CMD="$CMD --metrics --metrics.address 0.0.0.0 --metrics.port $VC_METRICS_PORT"
     fi
 
+    if [ ! -z "$WEB3SIGNER_URL" ]; then
+        CMD="$CMD --externalSigner.url=$WEB3SIGNER_URL --externalSigner.fetch"
+    fi
+
     exec ${CMD} --graffiti "$GRAFFITI"
 
 fi
@@ -173,6 +186,10 @@ if [ "$CC_CLIENT" = "nimbus" ]; then
         CMD="$CMD --metrics --metrics-address=0.0.0.0 --metrics-port=$VC_METRICS_PORT"
     fi
 
+    if [ ! -z "$WEB3SIGNER_URL" ]; then
+        CMD="$CMD --web3-signer-url=$WEB3SIGNER_URL"
+    fi
+
     # Graffiti breaks if it's in the CMD string instead of here because of spaces
     exec ${CMD} --graffiti="$GRAFFITI"
 
@@ -220,6 +237,10 @@ if [ "$CC_CLIENT" = "prysm" ]; then
         CMD="$CMD --disable-account-metrics"
     fi
 
+    if [ ! -z "$WEB3SIGNER_URL" ]; then
+        CMD="$CMD --validators-external-signer-url=$WEB3SIGNER_URL --validators-external-signer-public-keys=$WEB3SIGNER_URL/api/v1/eth2/publicKeys"
+    fi
+
 
     exec ${CMD} --graffiti "$GRAFFITI"
 
@@ -269,6 +290,10 @@ if [ "$CC_CLIENT" = "teku" ]; then
         CMD="$CMD --doppelganger-detection-enabled"
     fi
 
+    if [ ! -z "$WEB3SIGNER_URL" ]; then
+        CMD="$CMD --validators-external-signer-url=$WEB3SIGNER_URL --validators-external-signer-public-keys=external-signer"
+    fi
+
     exec ${CMD} --validators-graffiti="$GRAFFITI"
 
 fi
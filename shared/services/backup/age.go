@@ -0,0 +1,52 @@
+package backup
+
+import (
+	"errors"
+	"io"
+
+	"filippo.io/age"
+)
+
+// Passphrase encryption in the age v1 format (https://age-encryption.org/v1) with a single scrypt recipient,
+// so backups can also be decrypted with `age -d`
+
+// Config
+const (
+	ScryptWorkFactor    = 18
+	MaxScryptWorkFactor = 22
+)
+
+// The passphrase is wrong, or the file isn't an age file encrypted with a passphrase
+var ErrIncorrectPassphrase = errors.New("incorrect passphrase")
+
+// Encrypt everything written to the returned writer with a passphrase and write it to dst; the writer must be closed
+// to finish the file
+func Encrypt(dst io.Writer, passphrase string) (io.WriteCloser, error) {
+	return encrypt(dst, passphrase, ScryptWorkFactor)
+}
+
+// Encrypt with a passphrase and an scrypt work factor
+func encrypt(dst io.Writer, passphrase string, logN int) (io.WriteCloser, error) {
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	recipient.SetWorkFactor(logN)
+	return age.Encrypt(dst, recipient)
+}
+
+// Decrypt a file encrypted with a passphrase; its integrity is verified as it's read, so a read error means the file
+// was modified or truncated
+func Decrypt(src io.Reader, passphrase string) (io.Reader, error) {
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	identity.SetMaxWorkFactor(MaxScryptWorkFactor)
+	reader, err := age.Decrypt(src, identity)
+	var noMatch *age.NoIdentityMatchError
+	if errors.As(err, &noMatch) {
+		return nil, ErrIncorrectPassphrase
+	}
+	return reader, err
+}
@@ -0,0 +1,335 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Config
+const (
+	BackupFolder     = "backups"
+	ManifestFileName = "manifest.json"
+	ArchiveVersion   = 1
+	ArchiveDirMode   = 0700
+	maxArchiveBytes  = 1 << 30
+)
+
+// Describes the contents of a backup archive
+type Manifest struct {
+	Version     int            `json:"version"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	Network     string         `json:"network"`
+	NodeAddress string         `json:"nodeAddress"`
+	Files       []ManifestFile `json:"files"`
+}
+
+// A file in a backup archive, with the checksum it's verified against on restore
+type ManifestFile struct {
+	Path   string      `json:"path"`
+	Size   int64       `json:"size"`
+	Mode   os.FileMode `json:"mode"`
+	Sha256 string      `json:"sha256"`
+}
+
+// A backup archive being built or read
+type Archive struct {
+	Manifest Manifest
+	files    map[string][]byte
+}
+
+// Create an empty archive
+func NewArchive(network string, nodeAddress string) *Archive {
+	return &Archive{
+		Manifest: Manifest{
+			Version:     ArchiveVersion,
+			CreatedAt:   time.Now().UTC(),
+			Network:     network,
+			NodeAddress: nodeAddress,
+			Files:       []ManifestFile{},
+		},
+		files: map[string][]byte{},
+	}
+}
+
+// Add data to the archive
+func (a *Archive) AddBytes(archivePath string, data []byte, mode os.FileMode) error {
+	archivePath, err := cleanArchivePath(archivePath)
+	if err != nil {
+		return err
+	}
+	if _, exists := a.files[archivePath]; exists {
+		return fmt.Errorf("%s was already added to the backup", archivePath)
+	}
+	checksum := sha256.Sum256(data)
+	a.files[archivePath] = data
+	a.Manifest.Files = append(a.Manifest.Files, ManifestFile{
+		Path:   archivePath,
+		Size:   int64(len(data)),
+		Mode:   mode.Perm(),
+		Sha256: hex.EncodeToString(checksum[:]),
+	})
+	return nil
+}
+
+// Add a file to the archive if it exists, returning whether it did
+func (a *Archive) AddFile(archivePath string, sourcePath string) (bool, error) {
+	info, err := os.Stat(sourcePath)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("error reading %s: %w", sourcePath, err)
+	}
+	if info.IsDir() {
+		return false, fmt.Errorf("%s is a folder", sourcePath)
+	}
+	data, err := ioutil.ReadFile(sourcePath)
+	if err != nil {
+		return false, fmt.Errorf("error reading %s: %w", sourcePath, err)
+	}
+	return true, a.AddBytes(archivePath, data, info.Mode())
+}
+
+// Add the files of a folder and its subfolders to the archive if it exists, skipping files that match the exclude patterns
+func (a *Archive) AddDir(archivePath string, sourceDir string, exclude ...string) (bool, error) {
+	if _, err := os.Stat(sourceDir); os.IsNotExist(err) {
+		return false, nil
+	}
+	err := filepath.Walk(sourceDir, func(sourcePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		for _, pattern := range exclude {
+			if matched, _ := filepath.Match(pattern, info.Name()); matched {
+				return nil
+			}
+		}
+		relativePath, err := filepath.Rel(sourceDir, sourcePath)
+		if err != nil {
+			return err
+		}
+		_, err = a.AddFile(path.Join(archivePath, filepath.ToSlash(relativePath)), sourcePath)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("error reading %s: %w", sourceDir, err)
+	}
+	return true, nil
+}
+
+// Check if the archive has a file
+func (a *Archive) HasFile(archivePath string) bool {
+	_, exists := a.files[archivePath]
+	return exists
+}
+
+// Get a file from the archive
+func (a *Archive) GetFile(archivePath string) ([]byte, bool) {
+	data, exists := a.files[archivePath]
+	return data, exists
+}
+
+// Get the manifest entries under an archive folder
+func (a *Archive) GetFiles(archiveDir string) []ManifestFile {
+	prefix := strings.TrimSuffix(archiveDir, "/") + "/"
+	files := []ManifestFile{}
+	for _, file := range a.Manifest.Files {
+		if strings.HasPrefix(file.Path, prefix) {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// Write a file from the archive to disk, keeping its mode
+func (a *Archive) ExtractFile(archivePath string, targetPath string) error {
+	for _, file := range a.Manifest.Files {
+		if file.Path != archivePath {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(targetPath), ArchiveDirMode); err != nil {
+			return fmt.Errorf("error creating folder for %s: %w", targetPath, err)
+		}
+		if err := ioutil.WriteFile(targetPath, a.files[archivePath], file.Mode); err != nil {
+			return fmt.Errorf("error writing %s: %w", targetPath, err)
+		}
+		return os.Chmod(targetPath, file.Mode)
+	}
+	return fmt.Errorf("%s is not in the backup", archivePath)
+}
+
+// Write the files under an archive folder to a folder on disk
+func (a *Archive) ExtractDir(archiveDir string, targetDir string) error {
+	for _, file := range a.GetFiles(archiveDir) {
+		relativePath := strings.TrimPrefix(file.Path, strings.TrimSuffix(archiveDir, "/")+"/")
+		if err := a.ExtractFile(file.Path, filepath.Join(targetDir, filepath.FromSlash(relativePath))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Serialize the archive as a gzipped tarball, manifest first, and write it to w encrypted with a passphrase
+func (a *Archive) Seal(w io.Writer, passphrase string) error {
+	return a.seal(w, passphrase, ScryptWorkFactor)
+}
+
+func (a *Archive) seal(w io.Writer, passphrase string, logN int) error {
+
+	// Encode the manifest
+	sort.Slice(a.Manifest.Files, func(i, j int) bool {
+		return a.Manifest.Files[i].Path < a.Manifest.Files[j].Path
+	})
+	manifestBytes, err := json.MarshalIndent(a.Manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding backup manifest: %w", err)
+	}
+
+	// Write the tarball through the encryption
+	encryptWriter, err := encrypt(w, passphrase, logN)
+	if err != nil {
+		return fmt.Errorf("error encrypting backup: %w", err)
+	}
+	gzipWriter := gzip.NewWriter(encryptWriter)
+	tarWriter := tar.NewWriter(gzipWriter)
+	writeEntry := func(name string, data []byte, mode os.FileMode) error {
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    int64(mode),
+			Size:    int64(len(data)),
+			ModTime: a.Manifest.CreatedAt,
+		}); err != nil {
+			return err
+		}
+		_, err := tarWriter.Write(data)
+		return err
+	}
+	if err := writeEntry(ManifestFileName, manifestBytes, 0600); err != nil {
+		return fmt.Errorf("error writing backup: %w", err)
+	}
+	for _, file := range a.Manifest.Files {
+		if err := writeEntry(file.Path, a.files[file.Path], file.Mode); err != nil {
+			return fmt.Errorf("error writing backup: %w", err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("error writing backup: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("error writing backup: %w", err)
+	}
+	if err := encryptWriter.Close(); err != nil {
+		return fmt.Errorf("error writing backup: %w", err)
+	}
+	return nil
+
+}
+
+// Decrypt a backup archive from r and verify every file against its manifest
+func OpenArchive(r io.Reader, passphrase string) (*Archive, error) {
+
+	// Read the tarball through the decryption
+	decryptReader, err := Decrypt(r, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting backup: %w", err)
+	}
+	gzipReader, err := gzip.NewReader(decryptReader)
+	if err != nil {
+		return nil, fmt.Errorf("error reading backup: %w", err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	var manifest *Manifest
+	files := map[string][]byte{}
+	var totalBytes int64
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("error reading backup: %w", err)
+		}
+		totalBytes += header.Size
+		if totalBytes > maxArchiveBytes {
+			return nil, errors.New("the backup is too large")
+		}
+		data, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("error reading backup: %w", err)
+		}
+
+		// The manifest comes first
+		if manifest == nil {
+			if header.Name != ManifestFileName {
+				return nil, errors.New("the backup has no manifest")
+			}
+			manifest = &Manifest{}
+			if err := json.Unmarshal(data, manifest); err != nil {
+				return nil, fmt.Errorf("error decoding backup manifest: %w", err)
+			}
+			continue
+		}
+		if _, exists := files[header.Name]; exists {
+			return nil, fmt.Errorf("the backup has %s more than once", header.Name)
+		}
+		files[header.Name] = data
+	}
+
+	// Read to the end so the rest of the file is authenticated too
+	if _, err := io.Copy(ioutil.Discard, gzipReader); err != nil {
+		return nil, fmt.Errorf("error reading backup: %w", err)
+	}
+	if manifest == nil {
+		return nil, errors.New("the backup is empty")
+	}
+	if manifest.Version != ArchiveVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", manifest.Version)
+	}
+
+	// Verify the files
+	for _, file := range manifest.Files {
+		if _, err := cleanArchivePath(file.Path); err != nil {
+			return nil, err
+		}
+		data, exists := files[file.Path]
+		if !exists {
+			return nil, fmt.Errorf("the backup is missing %s", file.Path)
+		}
+		checksum := sha256.Sum256(data)
+		if int64(len(data)) != file.Size || hex.EncodeToString(checksum[:]) != file.Sha256 {
+			return nil, fmt.Errorf("%s in the backup does not match its checksum", file.Path)
+		}
+	}
+	if len(files) != len(manifest.Files) {
+		return nil, errors.New("the backup has files that aren't in its manifest")
+	}
+
+	return &Archive{
+		Manifest: *manifest,
+		files:    files,
+	}, nil
+
+}
+
+// Make sure an archive path is relative and stays inside the archive
+func cleanArchivePath(archivePath string) (string, error) {
+	cleaned := path.Clean(archivePath)
+	if cleaned == "." || cleaned == ManifestFileName || path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("invalid backup path %s", archivePath)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,158 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// A low work factor keeps the tests fast
+const testWorkFactor = 10
+
+// The size of an age payload chunk
+const testChunkSize = 64 * 1024
+
+// Encrypt data with a passphrase at the test work factor
+func testEncrypt(t *testing.T, plaintext []byte, passphrase string) []byte {
+	var ciphertext bytes.Buffer
+	writer, err := encrypt(&ciphertext, passphrase, testWorkFactor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := writer.Write(plaintext); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return ciphertext.Bytes()
+}
+
+// Decrypt data with a passphrase, reading it to the end
+func testDecrypt(ciphertext []byte, passphrase string) ([]byte, error) {
+	reader, err := Decrypt(bytes.NewReader(ciphertext), passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(reader)
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	for _, size := range []int{0, 1, testChunkSize, testChunkSize + 1, 3*testChunkSize + 17} {
+		plaintext := make([]byte, size)
+		if _, err := rand.Read(plaintext); err != nil {
+			t.Fatal(err)
+		}
+		ciphertext := testEncrypt(t, plaintext, "correct horse")
+		decrypted, err := testDecrypt(ciphertext, "correct horse")
+		if err != nil {
+			t.Fatalf("size %d: %v", size, err)
+		}
+		if !bytes.Equal(plaintext, decrypted) {
+			t.Errorf("size %d: decrypted data does not match", size)
+		}
+
+		// A wrong passphrase is rejected
+		if _, err := testDecrypt(ciphertext, "wrong horse"); err != ErrIncorrectPassphrase {
+			t.Errorf("size %d: expected an incorrect passphrase error, got %v", size, err)
+		}
+
+		// So are modified and truncated payloads
+		tampered := append([]byte{}, ciphertext...)
+		tampered[len(tampered)-1] ^= 1
+		if _, err := testDecrypt(tampered, "correct horse"); err == nil {
+			t.Errorf("size %d: expected a modified payload to be rejected", size)
+		}
+		if size > testChunkSize {
+			if _, err := testDecrypt(ciphertext[:len(ciphertext)-100], "correct horse"); err == nil {
+				t.Errorf("size %d: expected a truncated payload to be rejected", size)
+			}
+		}
+	}
+}
+
+func TestDecryptRejectsHighWorkFactor(t *testing.T) {
+	ciphertext := testEncrypt(t, []byte("data"), "passphrase")
+	tampered := bytes.Replace(ciphertext, []byte(" 10\n"), []byte(" 30\n"), 1)
+	if _, err := testDecrypt(tampered, "passphrase"); err == nil {
+		t.Error("expected a work factor above the limit to be rejected")
+	}
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	source := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(source, "validators", "lighthouse"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"wallet":                                "wallet data",
+		"validators/lighthouse/keystore.json":   "keystore data",
+		"validators/lighthouse/slashing.lock":   "lock",
+		"validators/lighthouse/definitions.yml": "definitions",
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(source, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Build and seal an archive
+	archive := NewArchive("prater", "0x01")
+	if added, err := archive.AddFile("wallet", filepath.Join(source, "wallet")); err != nil || !added {
+		t.Fatalf("error adding the wallet: %v", err)
+	}
+	if added, err := archive.AddFile("password", filepath.Join(source, "password")); err != nil || added {
+		t.Fatalf("a missing file was added: %v", err)
+	}
+	if _, err := archive.AddDir("validators/lighthouse", filepath.Join(source, "validators", "lighthouse"), "*.lock"); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.AddBytes("slashing-protection.json", []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.AddBytes("../escape", []byte{}, 0600); err == nil {
+		t.Error("expected a path outside the archive to be rejected")
+	}
+	var sealed bytes.Buffer
+	if err := archive.seal(&sealed, "passphrase", testWorkFactor); err != nil {
+		t.Fatal(err)
+	}
+
+	// Open and extract it
+	opened, err := OpenArchive(bytes.NewReader(sealed.Bytes()), "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opened.Manifest.Network != "prater" || len(opened.Manifest.Files) != 4 {
+		t.Fatalf("unexpected manifest %+v", opened.Manifest)
+	}
+	if opened.HasFile("validators/lighthouse/slashing.lock") {
+		t.Error("an excluded file was added")
+	}
+	target := t.TempDir()
+	if err := opened.ExtractDir("validators", filepath.Join(target, "validators")); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(target, "validators", "lighthouse", "keystore.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "keystore data" {
+		t.Errorf("unexpected extracted data %q", data)
+	}
+	info, err := os.Stat(filepath.Join(target, "validators", "lighthouse", "keystore.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("unexpected extracted mode %v", info.Mode())
+	}
+
+	// A wrong passphrase is rejected
+	if _, err := OpenArchive(bytes.NewReader(sealed.Bytes()), "wrong"); err == nil {
+		t.Error("expected a wrong passphrase to be rejected")
+	}
+}
@@ -22,6 +22,8 @@ package services
 import (
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/stader-labs/stader-node/shared/services/beacon"
@@ -34,12 +36,12 @@ import (
 )
 
 // This is a proxy for multiple Beacon clients, providing natural fallback support if one of them fails.
+// The first client is the primary; the rest are fallbacks in order of preference. Requests are routed to the
+// healthiest ready client.
 type BeaconClientManager struct {
-	primaryBc       beacon.Client
-	fallbackBc      beacon.Client
+	clients         []beacon.Client
+	pool            *clientPool
 	logger          log.ColorLogger
-	primaryReady    bool
-	fallbackReady   bool
 	ignoreSyncCheck bool
 }
 
@@ -57,53 +59,37 @@ func NewBeaconClientManager(cfg *config.StaderConfig) (*BeaconClientManager, err
 
 	// Primary CC
 	var primaryProvider string
-	var selectedCC cfgtypes.ConsensusClient
 	if cfg.IsNativeMode {
 		primaryProvider = cfg.Native.CcHttpUrl.Value.(string)
-		selectedCC = cfg.Native.ConsensusClient.Value.(cfgtypes.ConsensusClient)
 	} else if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
 		primaryProvider = fmt.Sprintf("http://%s:%d", BnContainerName, cfg.ConsensusCommon.ApiPort.Value.(uint16))
-		selectedCC = cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient)
 	} else if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_External {
 		selectedConsensusConfig, err := cfg.GetSelectedConsensusClientConfig()
 		if err != nil {
 			return nil, err
 		}
 		primaryProvider = selectedConsensusConfig.(cfgtypes.ExternalConsensusConfig).GetApiUrl()
-		selectedCC = cfg.ExternalConsensusClient.Value.(cfgtypes.ConsensusClient)
 	} else {
 		return nil, fmt.Errorf("Unknown Consensus client mode '%v'", cfg.ConsensusClientMode.Value)
 	}
 
-	// Fallback CC
-	var fallbackProvider string
-	if cfg.UseFallbackClients.Value == true {
-		if cfg.IsNativeMode {
-			fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
-		} else {
-			switch selectedCC {
-			case cfgtypes.ConsensusClient_Prysm:
-				fallbackProvider = cfg.FallbackPrysm.CcHttpUrl.Value.(string)
-			default:
-				fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
-			}
+	// Fallback CCs
+	bcUrls := []string{primaryProvider}
+	for _, fallbackProvider := range cfg.GetFallbackCcUrls() {
+		if fallbackProvider != primaryProvider {
+			bcUrls = append(bcUrls, fallbackProvider)
 		}
 	}
 
-	var primaryBc beacon.Client
-	var fallbackBc beacon.Client
-
-	primaryBc = client.NewStandardHttpClient(primaryProvider)
-	if fallbackProvider != "" {
-		fallbackBc = client.NewStandardHttpClient(fallbackProvider)
+	clients := make([]beacon.Client, len(bcUrls))
+	for i, bcUrl := range bcUrls {
+		clients[i] = client.NewStandardHttpClient(bcUrl)
 	}
 
 	return &BeaconClientManager{
-		primaryBc:     primaryBc,
-		fallbackBc:    fallbackBc,
-		logger:        log.NewColorLogger(color.FgHiBlue),
-		primaryReady:  true,
-		fallbackReady: fallbackBc != nil,
+		clients: clients,
+		pool:    newClientPool(bcUrls, bcHeadLagPenaltyPerSlot, parseReconnectDelay(cfg.ReconnectDelay.Value)),
+		logger:  log.NewColorLogger(color.FgHiBlue),
 	}, nil
 
 }
@@ -222,6 +208,17 @@ func (m *BeaconClientManager) GetValidatorStatuses(pubkeys []types.ValidatorPubk
 	return result.(map[types.ValidatorPubkey]beacon.ValidatorStatus), nil
 }
 
+// Get the statuses of every validator in any of the given states
+func (m *BeaconClientManager) GetValidatorsByStatus(states []beacon.ValidatorState, opts *beacon.ValidatorStatusOptions) ([]beacon.ValidatorStatus, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorsByStatus(states, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]beacon.ValidatorStatus), nil
+}
+
 // Get a validator's index
 func (m *BeaconClientManager) GetValidatorIndex(pubkey types.ValidatorPubkey) (uint64, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
@@ -311,38 +308,58 @@ func (m *BeaconClientManager) GetCommitteesForEpoch(epoch *uint64) ([]beacon.Com
 func (m *BeaconClientManager) CheckStatus() *api.ClientManagerStatus {
 
 	status := &api.ClientManagerStatus{
-		FallbackEnabled: m.fallbackBc != nil,
+		FallbackEnabled: m.pool.size() > 1,
 	}
 
 	// Ignore the sync check and just use the predefined settings if requested
 	if m.ignoreSyncCheck {
-		status.PrimaryClientStatus.IsWorking = m.primaryReady
-		status.PrimaryClientStatus.IsSynced = m.primaryReady
+		status.PrimaryClientStatus.IsWorking = m.pool.isReady(0)
+		status.PrimaryClientStatus.IsSynced = m.pool.isReady(0)
 		if status.FallbackEnabled {
-			status.FallbackClientStatus.IsWorking = m.fallbackReady
-			status.FallbackClientStatus.IsSynced = m.fallbackReady
+			status.FallbackClientStatus.IsWorking = m.pool.isFallbackReady()
+			status.FallbackClientStatus.IsSynced = m.pool.isFallbackReady()
 		}
+		status.Endpoints = m.pool.getEndpointStatuses()
 		return status
 	}
 
-	// Get the primary BC status
-	status.PrimaryClientStatus = checkBcStatus(m.primaryBc)
+	// Score every client in the pool
+	m.checkHealth()
 
-	// Get the fallback BC status if applicable
+	status.Endpoints = m.pool.getEndpointStatuses()
+	status.PrimaryClientStatus = status.Endpoints[0].Status
 	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkBcStatus(m.fallbackBc)
+		status.FallbackClientStatus = m.pool.getBestFallbackStatus()
 	}
 
-	// Flag the ready clients
-	m.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
-	m.fallbackReady = (status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced)
-
 	return status
 
 }
 
+// Probes every client in the pool concurrently and updates their health scores
+func (m *BeaconClientManager) checkHealth() {
+
+	m.pool.healthCheckMutex.Lock()
+	defer m.pool.healthCheckMutex.Unlock()
+
+	var wg sync.WaitGroup
+	for i, client := range m.clients {
+		wg.Add(1)
+		go func(index int, client beacon.Client) {
+			defer wg.Done()
+			start := time.Now()
+			status, headSlot := checkBcStatus(client)
+			latency := time.Since(start)
+			m.pool.setHealth(index, status, headSlot, latency, status.IsWorking && status.IsSynced)
+		}(i, client)
+	}
+	wg.Wait()
+
+	m.pool.finishHealthCheck()
+}
+
 // Check the client status
-func checkBcStatus(client beacon.Client) api.ClientStatus {
+func checkBcStatus(client beacon.Client) (api.ClientStatus, uint64) {
 
 	status := api.ClientStatus{}
 
@@ -352,7 +369,7 @@ func checkBcStatus(client beacon.Client) api.ClientStatus {
 		status.Error = fmt.Sprintf("Sync progress check failed with [%s]", err.Error())
 		status.IsSynced = false
 		status.IsWorking = false
-		return status
+		return status, 0
 	}
 
 	// Return the sync status
@@ -365,136 +382,113 @@ func checkBcStatus(client beacon.Client) api.ClientStatus {
 		status.IsSynced = false
 		status.SyncProgress = syncStatus.Progress
 	}
-	return status
+	return status, syncStatus.HeadSlot
 
 }
 
-// Attempts to run a function progressively through each client until one succeeds or they all fail.
+// Starts refreshing the health scores if they're stale and gets the clients to try, best first
+func (m *BeaconClientManager) getCandidates() ([]int, error) {
+	if !m.ignoreSyncCheck {
+		m.pool.refreshHealthIfDue(m.checkHealth)
+	}
+	candidates := m.pool.getCandidates()
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no Beacon clients were ready")
+	}
+	return candidates, nil
+}
+
+// Records the outcome of a request, returning true if the client was disconnected and the next one should be tried
+func (m *BeaconClientManager) handleResult(index int, start time.Time, err error) bool {
+	if err != nil && m.isDisconnected(err) {
+		// If it's disconnected, log it and try the next client
+		m.pool.recordResult(index, time.Since(start), true)
+		m.logger.Printlnf("WARNING: %s Beacon client disconnected (%s), trying the next one...", m.getClientName(index), err.Error())
+		return true
+	}
+	m.pool.recordResult(index, time.Since(start), false)
+	if m.pool.setSelection(index) {
+		m.logger.Printlnf("NOTE: switched to the %s Beacon client", m.getClientName(index))
+	}
+	return false
+}
+
+// Attempts to run a function progressively through each client, best first, until one succeeds or they all fail.
 func (m *BeaconClientManager) runFunction0(function bcFunction0) error {
 
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction0(function)
-			}
-			// If it's a different error, just return it
-			return err
-		}
-		// If there's no error, return the result
-		return nil
+	candidates, err := m.getCandidates()
+	if err != nil {
+		return err
 	}
 
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		err := function(m.fallbackBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return fmt.Errorf("all Beacon clients failed")
-			}
-
-			// If it's a different error, just return it
-			return err
+	for _, index := range candidates {
+		start := time.Now()
+		err := function(m.clients[index])
+		if m.handleResult(index, start, err) {
+			continue
 		}
-		// If there's no error, return the result
-		return nil
+		return err
 	}
 
-	return fmt.Errorf("no Beacon clients were ready")
+	return fmt.Errorf("all Beacon clients failed")
 }
 
-// Attempts to run a function progressively through each client until one succeeds or they all fail.
+// Attempts to run a function progressively through each client, best first, until one succeeds or they all fail.
 func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, error) {
 
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		result, err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction1(function)
-			}
-			// If it's a different error, just return it
-			return nil, err
-		}
-		// If there's no error, return the result
-		return result, nil
+	candidates, err := m.getCandidates()
+	if err != nil {
+		return nil, err
 	}
 
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		result, err := function(m.fallbackBc)
+	for _, index := range candidates {
+		start := time.Now()
+		result, err := function(m.clients[index])
+		if m.handleResult(index, start, err) {
+			continue
+		}
 		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return nil, fmt.Errorf("all Beacon clients failed")
-			}
-			// If it's a different error, just return it
 			return nil, err
 		}
-		// If there's no error, return the result
 		return result, nil
 	}
 
-	return nil, fmt.Errorf("no Beacon clients were ready")
+	return nil, fmt.Errorf("all Beacon clients failed")
 
 }
 
-// Attempts to run a function progressively through each client until one succeeds or they all fail.
+// Attempts to run a function progressively through each client, best first, until one succeeds or they all fail.
 func (m *BeaconClientManager) runFunction2(function bcFunction2) (interface{}, interface{}, error) {
 
-	// Check if we can use the primary
-	if m.primaryReady {
-		// Try to run the function on the primary
-		result1, result2, err := function(m.primaryBc)
-		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
-				m.primaryReady = false
-				return m.runFunction2(function)
-			}
-			// If it's a different error, just return it
-			return nil, nil, err
-		}
-		// If there's no error, return the result
-		return result1, result2, nil
+	candidates, err := m.getCandidates()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if m.fallbackReady {
-		// Try to run the function on the fallback
-		result1, result2, err := function(m.fallbackBc)
+	for _, index := range candidates {
+		start := time.Now()
+		result1, result2, err := function(m.clients[index])
+		if m.handleResult(index, start, err) {
+			continue
+		}
 		if err != nil {
-			if m.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
-				m.fallbackReady = false
-				return nil, nil, fmt.Errorf("all Beacon clients failed")
-			}
-			// If it's a different error, just return it
 			return nil, nil, err
 		}
-		// If there's no error, return the result
 		return result1, result2, nil
 	}
 
-	return nil, nil, fmt.Errorf("no Beacon clients were ready")
+	return nil, nil, fmt.Errorf("all Beacon clients failed")
 
 }
 
+// Get a display name for a client in the pool
+func (m *BeaconClientManager) getClientName(index int) string {
+	if index == 0 {
+		return "Primary"
+	}
+	return fmt.Sprintf("Fallback #%d", index)
+}
+
 // Returns true if the error was a connection failure and a backup client is available
 func (m *BeaconClientManager) isDisconnected(err error) bool {
 	return strings.Contains(err.Error(), "dial tcp")
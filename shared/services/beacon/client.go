@@ -33,18 +33,26 @@ type ValidatorStatusOptions struct {
 
 // API response types
 type SyncStatus struct {
-	Syncing  bool
-	Progress float64
+	Syncing      bool
+	Progress     float64
+	HeadSlot     uint64
+	SyncDistance uint64
 }
 type Eth2Config struct {
-	GenesisForkVersion           []byte
-	GenesisValidatorsRoot        []byte
-	GenesisEpoch                 uint64
-	GenesisTime                  uint64
-	SecondsPerSlot               uint64
-	SlotsPerEpoch                uint64
-	SecondsPerEpoch              uint64
-	EpochsPerSyncCommitteePeriod uint64
+	GenesisForkVersion               []byte
+	GenesisValidatorsRoot            []byte
+	GenesisEpoch                     uint64
+	GenesisTime                      uint64
+	SecondsPerSlot                   uint64
+	SlotsPerEpoch                    uint64
+	SecondsPerEpoch                  uint64
+	EpochsPerSyncCommitteePeriod     uint64
+	MaxSeedLookahead                 uint64
+	MinPerEpochChurnLimit            uint64
+	ChurnLimitQuotient               uint64
+	MaxPerEpochActivationChurnLimit  uint64
+	MinValidatorWithdrawabilityDelay uint64
+	MaxWithdrawalsPerPayload         uint64
 }
 type Eth2DepositContract struct {
 	ChainID uint64
@@ -82,6 +90,14 @@ type BeaconBlock struct {
 	Attestations         []AttestationInfo
 	FeeRecipient         common.Address
 	ExecutionBlockNumber uint64
+	Withdrawals          []WithdrawalInfo
+}
+
+type WithdrawalInfo struct {
+	Index          uint64
+	ValidatorIndex uint64
+	Address        common.Address
+	Amount         uint64
 }
 
 type Committee struct {
@@ -140,6 +156,7 @@ type Client interface {
 	GetValidatorStatusByIndex(index string, opts *ValidatorStatusOptions) (ValidatorStatus, error)
 	GetValidatorStatus(pubkey types.ValidatorPubkey, opts *ValidatorStatusOptions) (ValidatorStatus, error)
 	GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *ValidatorStatusOptions) (map[types.ValidatorPubkey]ValidatorStatus, error)
+	GetValidatorsByStatus(states []ValidatorState, opts *ValidatorStatusOptions) ([]ValidatorStatus, error)
 	GetValidatorIndex(pubkey types.ValidatorPubkey) (uint64, error)
 	GetValidatorSyncDuties(indices []uint64, epoch uint64) (map[uint64]bool, error)
 	GetValidatorProposerDuties(indices []uint64, epoch uint64) (map[uint64]uint64, error)
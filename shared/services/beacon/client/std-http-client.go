@@ -102,8 +102,10 @@ func (c *StandardHttpClient) GetSyncStatus() (beacon.SyncStatus, error) {
 
 	// Return response
 	return beacon.SyncStatus{
-		Syncing:  syncStatus.Data.IsSyncing,
-		Progress: progress,
+		Syncing:      syncStatus.Data.IsSyncing,
+		Progress:     progress,
+		HeadSlot:     uint64(syncStatus.Data.HeadSlot),
+		SyncDistance: uint64(syncStatus.Data.SyncDistance),
 	}, nil
 
 }
@@ -137,14 +139,20 @@ func (c *StandardHttpClient) GetEth2Config() (beacon.Eth2Config, error) {
 
 	// Return response
 	return beacon.Eth2Config{
-		GenesisForkVersion:           genesis.Data.GenesisForkVersion,
-		GenesisValidatorsRoot:        genesis.Data.GenesisValidatorsRoot,
-		GenesisEpoch:                 0,
-		GenesisTime:                  uint64(genesis.Data.GenesisTime),
-		SecondsPerSlot:               uint64(eth2Config.Data.SecondsPerSlot),
-		SlotsPerEpoch:                uint64(eth2Config.Data.SlotsPerEpoch),
-		SecondsPerEpoch:              uint64(eth2Config.Data.SecondsPerSlot * eth2Config.Data.SlotsPerEpoch),
-		EpochsPerSyncCommitteePeriod: uint64(eth2Config.Data.EpochsPerSyncCommitteePeriod),
+		GenesisForkVersion:               genesis.Data.GenesisForkVersion,
+		GenesisValidatorsRoot:            genesis.Data.GenesisValidatorsRoot,
+		GenesisEpoch:                     0,
+		GenesisTime:                      uint64(genesis.Data.GenesisTime),
+		SecondsPerSlot:                   uint64(eth2Config.Data.SecondsPerSlot),
+		SlotsPerEpoch:                    uint64(eth2Config.Data.SlotsPerEpoch),
+		SecondsPerEpoch:                  uint64(eth2Config.Data.SecondsPerSlot * eth2Config.Data.SlotsPerEpoch),
+		EpochsPerSyncCommitteePeriod:     uint64(eth2Config.Data.EpochsPerSyncCommitteePeriod),
+		MaxSeedLookahead:                 uint64(eth2Config.Data.MaxSeedLookahead),
+		MinPerEpochChurnLimit:            uint64(eth2Config.Data.MinPerEpochChurnLimit),
+		ChurnLimitQuotient:               uint64(eth2Config.Data.ChurnLimitQuotient),
+		MaxPerEpochActivationChurnLimit:  uint64(eth2Config.Data.MaxPerEpochActivationChurnLimit),
+		MinValidatorWithdrawabilityDelay: uint64(eth2Config.Data.MinValidatorWithdrawabilityDelay),
+		MaxWithdrawalsPerPayload:         uint64(eth2Config.Data.MaxWithdrawalsPerPayload),
 	}, nil
 
 }
@@ -316,6 +324,48 @@ func (c *StandardHttpClient) GetValidatorStatuses(pubkeys []types.ValidatorPubke
 
 }
 
+// Get the statuses of every validator in any of the given states
+func (c *StandardHttpClient) GetValidatorsByStatus(states []beacon.ValidatorState, opts *beacon.ValidatorStatusOptions) ([]beacon.ValidatorStatus, error) {
+
+	// Get state ID
+	stateId, err := c.getStateId(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get validators
+	stateStrings := make([]string, len(states))
+	for i, state := range states {
+		stateStrings[i] = string(state)
+	}
+	validators, err := c.getValidatorsByQuery(stateId, fmt.Sprintf("?status=%s", strings.Join(stateStrings, ",")))
+	if err != nil {
+		return nil, err
+	}
+
+	// Build validator statuses
+	statuses := make([]beacon.ValidatorStatus, len(validators.Data))
+	for i, validator := range validators.Data {
+		statuses[i] = beacon.ValidatorStatus{
+			Pubkey:                     types.BytesToValidatorPubkey(validator.Validator.Pubkey),
+			Index:                      uint64(validator.Index),
+			WithdrawalCredentials:      common.BytesToHash(validator.Validator.WithdrawalCredentials),
+			Balance:                    uint64(validator.Balance),
+			EffectiveBalance:           uint64(validator.Validator.EffectiveBalance),
+			Status:                     beacon.ValidatorState(validator.Status),
+			Slashed:                    validator.Validator.Slashed,
+			ActivationEligibilityEpoch: uint64(validator.Validator.ActivationEligibilityEpoch),
+			ActivationEpoch:            uint64(validator.Validator.ActivationEpoch),
+			ExitEpoch:                  uint64(validator.Validator.ExitEpoch),
+			WithdrawableEpoch:          uint64(validator.Validator.WithdrawableEpoch),
+			Exists:                     true,
+		}
+	}
+
+	return statuses, nil
+
+}
+
 // Get whether validators have sync duties to perform at given epoch
 func (c *StandardHttpClient) GetValidatorSyncDuties(indices []uint64, epoch uint64) (map[uint64]bool, error) {
 
@@ -507,6 +557,14 @@ func (c *StandardHttpClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock,
 		beaconBlock.HasExecutionPayload = true
 		beaconBlock.FeeRecipient = common.BytesToAddress(block.Data.Message.Body.ExecutionPayload.FeeRecipient)
 		beaconBlock.ExecutionBlockNumber = uint64(block.Data.Message.Body.ExecutionPayload.BlockNumber)
+		for _, withdrawal := range block.Data.Message.Body.ExecutionPayload.Withdrawals {
+			beaconBlock.Withdrawals = append(beaconBlock.Withdrawals, beacon.WithdrawalInfo{
+				Index:          uint64(withdrawal.Index),
+				ValidatorIndex: uint64(withdrawal.ValidatorIndex),
+				Address:        common.BytesToAddress(withdrawal.Address),
+				Amount:         uint64(withdrawal.Amount),
+			})
+		}
 	}
 
 	// Add attestation info
@@ -651,6 +709,11 @@ func (c *StandardHttpClient) getValidators(stateId string, pubkeys []string) (Va
 	if len(pubkeys) > 0 {
 		query = fmt.Sprintf("?id=%s", strings.Join(pubkeys, ","))
 	}
+	return c.getValidatorsByQuery(stateId, query)
+}
+
+// Get validators matching a query
+func (c *StandardHttpClient) getValidatorsByQuery(stateId string, query string) (ValidatorsResponse, error) {
 	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestValidatorsPath, stateId) + query)
 	if err != nil {
 		return ValidatorsResponse{}, fmt.Errorf("Could not get validators: %w", err)
@@ -665,29 +728,35 @@ func (c *StandardHttpClient) getValidators(stateId string, pubkeys []string) (Va
 	return validators, nil
 }
 
-// Get validators by pubkeys and status options
-func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts *beacon.ValidatorStatusOptions) (ValidatorsResponse, error) {
-
-	// Get state ID
-	var stateId string
+// Get the state ID for a set of status options
+func (c *StandardHttpClient) getStateId(opts *beacon.ValidatorStatusOptions) (string, error) {
 	if opts == nil {
-		stateId = "head"
+		return "head", nil
 	} else if opts.Slot != nil {
-		stateId = strconv.FormatInt(int64(*opts.Slot), 10)
+		return strconv.FormatInt(int64(*opts.Slot), 10), nil
 	} else if opts.Epoch != nil {
 
 		// Get eth2 config
 		eth2Config, err := c.getEth2Config()
 		if err != nil {
-			return ValidatorsResponse{}, err
+			return "", err
 		}
 
 		// Get slot nuimber
 		slot := *opts.Epoch * uint64(eth2Config.Data.SlotsPerEpoch)
-		stateId = strconv.FormatInt(int64(slot), 10)
+		return strconv.FormatInt(int64(slot), 10), nil
 
-	} else {
-		return ValidatorsResponse{}, fmt.Errorf("must specify a slot or epoch when calling getValidatorsByOpts")
+	}
+	return "", fmt.Errorf("must specify a slot or epoch when calling getValidatorsByOpts")
+}
+
+// Get validators by pubkeys and status options
+func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts *beacon.ValidatorStatusOptions) (ValidatorsResponse, error) {
+
+	// Get state ID
+	stateId, err := c.getStateId(opts)
+	if err != nil {
+		return ValidatorsResponse{}, err
 	}
 	count := len(pubkeysOrIndices)
 	data := make([]Validator, count)
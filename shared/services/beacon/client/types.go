@@ -48,9 +48,15 @@ type SyncStatusResponse struct {
 }
 type Eth2ConfigResponse struct {
 	Data struct {
-		SecondsPerSlot               uinteger `json:"SECONDS_PER_SLOT"`
-		SlotsPerEpoch                uinteger `json:"SLOTS_PER_EPOCH"`
-		EpochsPerSyncCommitteePeriod uinteger `json:"EPOCHS_PER_SYNC_COMMITTEE_PERIOD"`
+		SecondsPerSlot                   uinteger `json:"SECONDS_PER_SLOT"`
+		SlotsPerEpoch                    uinteger `json:"SLOTS_PER_EPOCH"`
+		EpochsPerSyncCommitteePeriod     uinteger `json:"EPOCHS_PER_SYNC_COMMITTEE_PERIOD"`
+		MaxSeedLookahead                 uinteger `json:"MAX_SEED_LOOKAHEAD"`
+		MinPerEpochChurnLimit            uinteger `json:"MIN_PER_EPOCH_CHURN_LIMIT"`
+		ChurnLimitQuotient               uinteger `json:"CHURN_LIMIT_QUOTIENT"`
+		MaxPerEpochActivationChurnLimit  uinteger `json:"MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT"`
+		MinValidatorWithdrawabilityDelay uinteger `json:"MIN_VALIDATOR_WITHDRAWABILITY_DELAY"`
+		MaxWithdrawalsPerPayload         uinteger `json:"MAX_WITHDRAWALS_PER_PAYLOAD"`
 	} `json:"data"`
 }
 type Eth2DepositContractResponse struct {
@@ -102,13 +108,20 @@ type BeaconBlockResponse struct {
 				} `json:"eth1_data"`
 				Attestations     []Attestation `json:"attestations"`
 				ExecutionPayload *struct {
-					FeeRecipient byteArray `json:"fee_recipient"`
-					BlockNumber  uinteger  `json:"block_number"`
+					FeeRecipient byteArray    `json:"fee_recipient"`
+					BlockNumber  uinteger     `json:"block_number"`
+					Withdrawals  []Withdrawal `json:"withdrawals"`
 				} `json:"execution_payload"`
 			} `json:"body"`
 		} `json:"message"`
 	} `json:"data"`
 }
+type Withdrawal struct {
+	Index          uinteger  `json:"index"`
+	ValidatorIndex uinteger  `json:"validator_index"`
+	Address        byteArray `json:"address"`
+	Amount         uinteger  `json:"amount"`
+}
 type ValidatorsResponse struct {
 	Data []Validator `json:"data"`
 }
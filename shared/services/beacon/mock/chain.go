@@ -0,0 +1,310 @@
+package mock
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// The epoch used by the Beacon chain for "never"
+const FarFutureEpoch uint64 = math.MaxUint64
+
+// Network settings served by the fake Beacon node
+type Config struct {
+	GenesisForkVersion               []byte
+	GenesisValidatorsRoot            []byte
+	SecondsPerSlot                   uint64
+	SlotsPerEpoch                    uint64
+	EpochsPerSyncCommitteePeriod     uint64
+	MaxSeedLookahead                 uint64
+	MinPerEpochChurnLimit            uint64
+	ChurnLimitQuotient               uint64
+	MaxPerEpochActivationChurnLimit  uint64
+	MinValidatorWithdrawabilityDelay uint64
+	MaxWithdrawalsPerPayload         uint64
+	DepositChainID                   uint64
+	DepositContract                  common.Address
+}
+
+// A validator in the fake Beacon chain state
+type Validator struct {
+	Pubkey                     types.ValidatorPubkey
+	Index                      uint64
+	WithdrawalCredentials      common.Hash
+	Balance                    uint64
+	EffectiveBalance           uint64
+	Status                     beacon.ValidatorState
+	Slashed                    bool
+	ActivationEligibilityEpoch uint64
+	ActivationEpoch            uint64
+	ExitEpoch                  uint64
+	WithdrawableEpoch          uint64
+}
+
+// A block in the fake Beacon chain
+type Block struct {
+	Slot                 uint64
+	ProposerIndex        uint64
+	FeeRecipient         common.Address
+	ExecutionBlockNumber uint64
+	HasExecutionPayload  bool
+	Withdrawals          []beacon.WithdrawalInfo
+}
+
+// A voluntary exit that was submitted to the fake Beacon node
+type VoluntaryExit struct {
+	ValidatorIndex uint64
+	Epoch          uint64
+	Signature      types.ValidatorSignature
+}
+
+// Scriptable in-memory Beacon chain state
+type Chain struct {
+	config         Config
+	genesisTime    uint64
+	headSlot       uint64
+	syncing        bool
+	syncDistance   uint64
+	finalizedEpoch uint64
+	justifiedEpoch uint64
+	validators     []*Validator
+	blocks         map[uint64]Block
+	proposerDuties map[uint64][]uint64
+	syncCommittees map[uint64][]uint64
+	committees     map[uint64][]beacon.Committee
+	exits          []VoluntaryExit
+	failures       map[string]*failure
+	lock           sync.Mutex
+}
+
+// A scripted failure for requests to a path
+type failure struct {
+	statusCode int
+	remaining  int
+}
+
+// Get the default network settings, modeled after mainnet
+func DefaultConfig() Config {
+	return Config{
+		GenesisForkVersion:               []byte{0x00, 0x00, 0x00, 0x00},
+		GenesisValidatorsRoot:            common.HexToHash("0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95").Bytes(),
+		SecondsPerSlot:                   12,
+		SlotsPerEpoch:                    32,
+		EpochsPerSyncCommitteePeriod:     256,
+		MaxSeedLookahead:                 4,
+		MinPerEpochChurnLimit:            4,
+		ChurnLimitQuotient:               65536,
+		MaxPerEpochActivationChurnLimit:  8,
+		MinValidatorWithdrawabilityDelay: 256,
+		MaxWithdrawalsPerPayload:         16,
+		DepositChainID:                   1,
+		DepositContract:                  common.HexToAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa"),
+	}
+}
+
+// Creates a new chain whose head is at the given slot
+func newChain(config Config, headSlot uint64) *Chain {
+	chain := &Chain{
+		config:         config,
+		blocks:         map[uint64]Block{},
+		proposerDuties: map[uint64][]uint64{},
+		syncCommittees: map[uint64][]uint64{},
+		committees:     map[uint64][]beacon.Committee{},
+		failures:       map[string]*failure{},
+	}
+	chain.setHeadSlot(headSlot)
+	return chain
+}
+
+// Moves the head to the given slot. The genesis time is shifted so that the wall clock agrees with the head, since
+// clients derive the current epoch from the genesis time.
+func (c *Chain) SetHeadSlot(slot uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.setHeadSlot(slot)
+}
+
+func (c *Chain) setHeadSlot(slot uint64) {
+	c.headSlot = slot
+	c.genesisTime = uint64(time.Now().Unix()) - slot*c.config.SecondsPerSlot
+	epoch := slot / c.config.SlotsPerEpoch
+	if epoch >= 2 {
+		c.finalizedEpoch = epoch - 2
+		c.justifiedEpoch = epoch - 1
+	}
+}
+
+// Get the current head slot
+func (c *Chain) HeadSlot() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.headSlot
+}
+
+// Get the current head epoch
+func (c *Chain) HeadEpoch() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.headSlot / c.config.SlotsPerEpoch
+}
+
+// Advances the head by the given number of epochs
+func (c *Chain) AdvanceEpochs(epochs uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.setHeadSlot(c.headSlot + epochs*c.config.SlotsPerEpoch)
+}
+
+// Set whether the node reports that it's syncing, and how far behind it is
+func (c *Chain) SetSyncing(syncing bool, syncDistance uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.syncing = syncing
+	c.syncDistance = syncDistance
+}
+
+// Adds a validator to the state and returns its index. Unset epochs that haven't happened yet for the validator's
+// status default to the far future epoch.
+func (c *Chain) AddValidator(validator Validator) uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	validator.Index = uint64(len(c.validators))
+	if validator.Status == "" {
+		validator.Status = beacon.ValidatorState_PendingInitialized
+	}
+	if validator.Status == beacon.ValidatorState_PendingInitialized && validator.ActivationEligibilityEpoch == 0 {
+		validator.ActivationEligibilityEpoch = FarFutureEpoch
+	}
+	if (validator.Status == beacon.ValidatorState_PendingInitialized || validator.Status == beacon.ValidatorState_PendingQueued) && validator.ActivationEpoch == 0 {
+		validator.ActivationEpoch = FarFutureEpoch
+	}
+	if validator.ExitEpoch == 0 {
+		validator.ExitEpoch = FarFutureEpoch
+	}
+	if validator.WithdrawableEpoch == 0 {
+		validator.WithdrawableEpoch = FarFutureEpoch
+	}
+	c.validators = append(c.validators, &validator)
+	return validator.Index
+}
+
+// Adds a number of anonymous active validators, e.g. to set the size of the validator set for churn calculations
+func (c *Chain) AddActiveValidators(count uint64, effectiveBalance uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for i := uint64(0); i < count; i++ {
+		index := uint64(len(c.validators))
+		pubkey := types.ValidatorPubkey{}
+		pubkey[0] = 0xff
+		for j := 0; j < 8; j++ {
+			pubkey[len(pubkey)-1-j] = byte(index >> (8 * j))
+		}
+		c.validators = append(c.validators, &Validator{
+			Pubkey:            pubkey,
+			Index:             index,
+			Balance:           effectiveBalance,
+			EffectiveBalance:  effectiveBalance,
+			Status:            beacon.ValidatorState_ActiveOngoing,
+			ExitEpoch:         FarFutureEpoch,
+			WithdrawableEpoch: FarFutureEpoch,
+		})
+	}
+}
+
+// Applies a change to a validator in the state
+func (c *Chain) UpdateValidator(index uint64, update func(validator *Validator)) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if index < uint64(len(c.validators)) {
+		update(c.validators[index])
+	}
+}
+
+// Get a copy of a validator from the state
+func (c *Chain) GetValidator(index uint64) (Validator, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if index >= uint64(len(c.validators)) {
+		return Validator{}, false
+	}
+	return *c.validators[index], true
+}
+
+// Adds or replaces the block at a slot
+func (c *Chain) SetBlock(block Block) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.blocks[block.Slot] = block
+}
+
+// Set the validators with proposer duties in an epoch
+func (c *Chain) SetProposerDuties(epoch uint64, validatorIndices []uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.proposerDuties[epoch] = validatorIndices
+}
+
+// Set the validators in the sync committee for a sync committee period
+func (c *Chain) SetSyncCommittee(period uint64, validatorIndices []uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.syncCommittees[period] = validatorIndices
+}
+
+// Set the attestation committees for an epoch. Epochs without scripted committees spread the active validators
+// across one committee per slot.
+func (c *Chain) SetCommittees(epoch uint64, committees []beacon.Committee) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.committees[epoch] = committees
+}
+
+// Get the voluntary exits that have been submitted so far
+func (c *Chain) GetVoluntaryExits() []VoluntaryExit {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	exits := make([]VoluntaryExit, len(c.exits))
+	copy(exits, c.exits)
+	return exits
+}
+
+// Makes the next count requests to the given path fail with the given HTTP status code
+func (c *Chain) FailRequests(path string, statusCode int, count int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.failures[path] = &failure{
+		statusCode: statusCode,
+		remaining:  count,
+	}
+}
+
+// Check if a request to the given path should fail, consuming one failure if so
+func (c *Chain) consumeFailure(path string) (int, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	f, exists := c.failures[path]
+	if !exists || f.remaining <= 0 {
+		return 0, false
+	}
+	f.remaining--
+	return f.statusCode, true
+}
+
+// Creates a random validator key, for adding validators whose messages need valid signatures
+func GenerateValidatorKey() (*eth2types.BLSPrivateKey, types.ValidatorPubkey, error) {
+	if err := eth2types.InitBLS(); err != nil {
+		return nil, types.ValidatorPubkey{}, err
+	}
+	key, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		return nil, types.ValidatorPubkey{}, err
+	}
+	return key, types.BytesToValidatorPubkey(key.PublicKey().Marshal()), nil
+}
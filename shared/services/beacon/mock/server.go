@@ -0,0 +1,624 @@
+package mock
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/services/beacon/client"
+	"github.com/stader-labs/stader-node/shared/types/eth2"
+	hexutil "github.com/stader-labs/stader-node/shared/utils/hex"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// An in-process fake Beacon node that serves the standard Beacon API from a scriptable chain state
+type Server struct {
+	*Chain
+	server *httptest.Server
+}
+
+// Creates and starts a new fake Beacon node with its head at the given slot
+func NewServer(config Config, headSlot uint64) *Server {
+	s := &Server{
+		Chain: newChain(config, headSlot),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Get the URL of the fake Beacon node
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Get a Beacon client connected to the fake Beacon node
+func (s *Server) Client() *client.StandardHttpClient {
+	return client.NewStandardHttpClient(s.server.URL)
+}
+
+// Shut down the fake Beacon node
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Routes a request to its handler
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+
+	path := r.URL.Path
+	if statusCode, fail := s.consumeFailure(path); fail {
+		writeError(w, statusCode, "scripted failure")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case r.Method == http.MethodGet && path == client.RequestSyncStatusPath:
+		s.getSyncStatus(w)
+	case r.Method == http.MethodGet && path == client.RequestEth2ConfigPath:
+		s.getSpec(w)
+	case r.Method == http.MethodGet && path == client.RequestEth2DepositContractMethod:
+		s.getDepositContract(w)
+	case r.Method == http.MethodGet && path == client.RequestGenesisPath:
+		s.getGenesis(w)
+	case r.Method == http.MethodPost && path == client.RequestVoluntaryExitPath:
+		s.postVoluntaryExit(w, r)
+	case r.Method == http.MethodGet && len(parts) == 6 && parts[2] == "beacon" && parts[3] == "states":
+		s.handleState(w, r, parts[4], parts[5])
+	case r.Method == http.MethodGet && len(parts) == 6 && parts[2] == "beacon" && parts[3] == "blocks" && parts[5] == "attestations":
+		s.getAttestations(w, parts[4])
+	case r.Method == http.MethodGet && len(parts) == 5 && parts[0] == "eth" && parts[1] == "v2" && parts[3] == "blocks":
+		s.getBlock(w, parts[4])
+	case r.Method == http.MethodPost && len(parts) == 6 && parts[2] == "validator" && parts[4] == "sync":
+		s.postSyncDuties(w, r, parts[5])
+	case r.Method == http.MethodGet && len(parts) == 6 && parts[2] == "validator" && parts[4] == "proposer":
+		s.getProposerDuties(w, parts[5])
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown route %s %s", r.Method, path))
+	}
+
+}
+
+// Routes a request for a state resource
+func (s *Server) handleState(w http.ResponseWriter, r *http.Request, stateId string, resource string) {
+	if _, err := s.resolveSlot(stateId); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	switch resource {
+	case "validators":
+		s.getValidators(w, r)
+	case "finality_checkpoints":
+		s.getFinalityCheckpoints(w)
+	case "fork":
+		s.getFork(w)
+	case "committees":
+		s.getCommittees(w, r)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown state resource %s", resource))
+	}
+}
+
+func (s *Server) getSyncStatus(w http.ResponseWriter) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	writeData(w, map[string]interface{}{
+		"head_slot":     uintString(s.headSlot),
+		"sync_distance": uintString(s.syncDistance),
+		"is_syncing":    s.syncing,
+	})
+}
+
+func (s *Server) getSpec(w http.ResponseWriter) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	spec := map[string]interface{}{
+		"SECONDS_PER_SLOT":                    uintString(s.config.SecondsPerSlot),
+		"SLOTS_PER_EPOCH":                     uintString(s.config.SlotsPerEpoch),
+		"EPOCHS_PER_SYNC_COMMITTEE_PERIOD":    uintString(s.config.EpochsPerSyncCommitteePeriod),
+		"MAX_SEED_LOOKAHEAD":                  uintString(s.config.MaxSeedLookahead),
+		"MIN_PER_EPOCH_CHURN_LIMIT":           uintString(s.config.MinPerEpochChurnLimit),
+		"CHURN_LIMIT_QUOTIENT":                uintString(s.config.ChurnLimitQuotient),
+		"MIN_VALIDATOR_WITHDRAWABILITY_DELAY": uintString(s.config.MinValidatorWithdrawabilityDelay),
+		"MAX_WITHDRAWALS_PER_PAYLOAD":         uintString(s.config.MaxWithdrawalsPerPayload),
+	}
+	if s.config.MaxPerEpochActivationChurnLimit > 0 {
+		spec["MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT"] = uintString(s.config.MaxPerEpochActivationChurnLimit)
+	}
+	writeData(w, spec)
+}
+
+func (s *Server) getDepositContract(w http.ResponseWriter) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	writeData(w, map[string]interface{}{
+		"chain_id": uintString(s.config.DepositChainID),
+		"address":  s.config.DepositContract.Hex(),
+	})
+}
+
+func (s *Server) getGenesis(w http.ResponseWriter) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	writeData(w, map[string]interface{}{
+		"genesis_time":            uintString(s.genesisTime),
+		"genesis_fork_version":    hexString(s.config.GenesisForkVersion),
+		"genesis_validators_root": hexString(s.config.GenesisValidatorsRoot),
+	})
+}
+
+func (s *Server) getFinalityCheckpoints(w http.ResponseWriter) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	writeData(w, map[string]interface{}{
+		"previous_justified": checkpoint(s.justifiedEpoch - minUint(s.justifiedEpoch, 1)),
+		"current_justified":  checkpoint(s.justifiedEpoch),
+		"finalized":          checkpoint(s.finalizedEpoch),
+	})
+}
+
+func (s *Server) getFork(w http.ResponseWriter) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	writeData(w, map[string]interface{}{
+		"previous_version": hexString(s.config.GenesisForkVersion),
+		"current_version":  hexString(s.config.GenesisForkVersion),
+		"epoch":            uintString(0),
+	})
+}
+
+func (s *Server) getValidators(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Filter by pubkey or index if requested
+	selected := []*Validator{}
+	ids := r.URL.Query().Get("id")
+	if ids == "" {
+		selected = s.validators
+	} else {
+		for _, id := range strings.Split(ids, ",") {
+			if validator := s.findValidator(id); validator != nil {
+				selected = append(selected, validator)
+			}
+		}
+	}
+
+	// Filter by status if requested
+	if statuses := r.URL.Query().Get("status"); statuses != "" {
+		wanted := map[string]bool{}
+		for _, status := range strings.Split(statuses, ",") {
+			wanted[status] = true
+		}
+		filtered := []*Validator{}
+		for _, validator := range selected {
+			if wanted[string(validator.Status)] {
+				filtered = append(filtered, validator)
+			}
+		}
+		selected = filtered
+	}
+
+	data := make([]interface{}, len(selected))
+	for i, validator := range selected {
+		data[i] = map[string]interface{}{
+			"index":   uintString(validator.Index),
+			"balance": uintString(validator.Balance),
+			"status":  string(validator.Status),
+			"validator": map[string]interface{}{
+				"pubkey":                       hexString(validator.Pubkey.Bytes()),
+				"withdrawal_credentials":       hexString(validator.WithdrawalCredentials.Bytes()),
+				"effective_balance":            uintString(validator.EffectiveBalance),
+				"slashed":                      validator.Slashed,
+				"activation_eligibility_epoch": uintString(validator.ActivationEligibilityEpoch),
+				"activation_epoch":             uintString(validator.ActivationEpoch),
+				"exit_epoch":                   uintString(validator.ExitEpoch),
+				"withdrawable_epoch":           uintString(validator.WithdrawableEpoch),
+			},
+		}
+	}
+	writeData(w, data)
+}
+
+func (s *Server) getCommittees(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	epoch := s.headSlot / s.config.SlotsPerEpoch
+	if epochString := r.URL.Query().Get("epoch"); epochString != "" {
+		var err error
+		epoch, err = strconv.ParseUint(epochString, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid epoch %s", epochString))
+			return
+		}
+	}
+
+	committees, exists := s.committees[epoch]
+	if !exists {
+		committees = s.getDefaultCommittees(epoch)
+	}
+	data := []interface{}{}
+	for _, committee := range committees {
+		validators := make([]string, len(committee.Validators))
+		for i, validator := range committee.Validators {
+			validators[i] = uintString(validator)
+		}
+		data = append(data, map[string]interface{}{
+			"index":      uintString(committee.Index),
+			"slot":       uintString(committee.Slot),
+			"validators": validators,
+		})
+	}
+	writeData(w, data)
+}
+
+func (s *Server) getAttestations(w http.ResponseWriter, blockId string) {
+	if _, exists := s.findBlock(blockId); !exists {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("block %s not found", blockId))
+		return
+	}
+	writeData(w, []interface{}{})
+}
+
+func (s *Server) getBlock(w http.ResponseWriter, blockId string) {
+	block, exists := s.findBlock(blockId)
+	if !exists {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("block %s not found", blockId))
+		return
+	}
+
+	body := map[string]interface{}{
+		"eth1_data": map[string]interface{}{
+			"deposit_root":  hexString(make([]byte, 32)),
+			"deposit_count": uintString(0),
+			"block_hash":    hexString(make([]byte, 32)),
+		},
+		"attestations": []interface{}{},
+	}
+	if block.HasExecutionPayload {
+		withdrawals := make([]interface{}, len(block.Withdrawals))
+		for i, withdrawal := range block.Withdrawals {
+			withdrawals[i] = map[string]interface{}{
+				"index":           uintString(withdrawal.Index),
+				"validator_index": uintString(withdrawal.ValidatorIndex),
+				"address":         hexString(withdrawal.Address.Bytes()),
+				"amount":          uintString(withdrawal.Amount),
+			}
+		}
+		body["execution_payload"] = map[string]interface{}{
+			"fee_recipient": hexString(block.FeeRecipient.Bytes()),
+			"block_number":  uintString(block.ExecutionBlockNumber),
+			"withdrawals":   withdrawals,
+		}
+	}
+	writeData(w, map[string]interface{}{
+		"message": map[string]interface{}{
+			"slot":           uintString(block.Slot),
+			"proposer_index": uintString(block.ProposerIndex),
+			"body":           body,
+		},
+	})
+}
+
+func (s *Server) postSyncDuties(w http.ResponseWriter, r *http.Request, epochString string) {
+	epoch, err := strconv.ParseUint(epochString, 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid epoch %s", epochString))
+		return
+	}
+	var indices []string
+	if err := json.NewDecoder(r.Body).Decode(&indices); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	members := map[uint64]bool{}
+	for _, index := range s.syncCommittees[epoch/s.config.EpochsPerSyncCommitteePeriod] {
+		members[index] = true
+	}
+	data := []interface{}{}
+	for _, indexString := range indices {
+		index, err := strconv.ParseUint(indexString, 10, 64)
+		if err != nil || !members[index] || index >= uint64(len(s.validators)) {
+			continue
+		}
+		data = append(data, map[string]interface{}{
+			"pubkey":                           hexString(s.validators[index].Pubkey.Bytes()),
+			"validator_index":                  uintString(index),
+			"validator_sync_committee_indices": []string{uintString(0)},
+		})
+	}
+	writeData(w, data)
+}
+
+func (s *Server) getProposerDuties(w http.ResponseWriter, epochString string) {
+	epoch, err := strconv.ParseUint(epochString, 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid epoch %s", epochString))
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	data := []interface{}{}
+	for i, index := range s.proposerDuties[epoch] {
+		if index >= uint64(len(s.validators)) {
+			continue
+		}
+		data = append(data, map[string]interface{}{
+			"pubkey":          hexString(s.validators[index].Pubkey.Bytes()),
+			"validator_index": uintString(index),
+			"slot":            uintString(epoch*s.config.SlotsPerEpoch + uint64(i)),
+		})
+	}
+	writeData(w, data)
+}
+
+// Spreads the active validators across one committee per slot of an epoch; the lock must be held by the caller
+func (s *Server) getDefaultCommittees(epoch uint64) []beacon.Committee {
+	committees := make([]beacon.Committee, s.config.SlotsPerEpoch)
+	for i := range committees {
+		committees[i] = beacon.Committee{
+			Slot:       epoch*s.config.SlotsPerEpoch + uint64(i),
+			Validators: []uint64{},
+		}
+	}
+	activeCount := uint64(0)
+	for _, validator := range s.validators {
+		if validator.ActivationEpoch <= epoch && epoch < validator.ExitEpoch && validator.Status != beacon.ValidatorState_PendingInitialized && validator.Status != beacon.ValidatorState_PendingQueued {
+			committee := &committees[activeCount%s.config.SlotsPerEpoch]
+			committee.Validators = append(committee.Validators, validator.Index)
+			activeCount++
+		}
+	}
+	return committees
+}
+
+// Accepts a voluntary exit if its signature is valid and the validator can exit
+func (s *Server) postVoluntaryExit(w http.ResponseWriter, r *http.Request) {
+
+	var request client.VoluntaryExitRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	validatorIndex := uint64(request.Message.ValidatorIndex)
+	epoch := uint64(request.Message.Epoch)
+	signature := types.BytesToValidatorSignature(request.Signature)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Check the validator
+	if validatorIndex >= uint64(len(s.validators)) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("validator %d does not exist", validatorIndex))
+		return
+	}
+	validator := s.validators[validatorIndex]
+	if validator.Status != beacon.ValidatorState_ActiveOngoing {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("validator %d is not active (%s)", validatorIndex, validator.Status))
+		return
+	}
+	headEpoch := s.headSlot / s.config.SlotsPerEpoch
+	if epoch > headEpoch {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("exit epoch %d is in the future", epoch))
+		return
+	}
+
+	// Check the signature
+	if err := s.verifyExitSignature(validator.Pubkey, validatorIndex, epoch, signature); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Start the exit
+	validator.Status = beacon.ValidatorState_ActiveExiting
+	validator.ExitEpoch = s.getExitQueueEpoch(headEpoch)
+	validator.WithdrawableEpoch = validator.ExitEpoch + s.config.MinValidatorWithdrawabilityDelay
+	s.exits = append(s.exits, VoluntaryExit{
+		ValidatorIndex: validatorIndex,
+		Epoch:          epoch,
+		Signature:      signature,
+	})
+	w.WriteHeader(http.StatusOK)
+
+}
+
+// Get the exit epoch for a newly initiated exit, following the exit churn; the lock must be held by the caller
+func (s *Server) getExitQueueEpoch(headEpoch uint64) uint64 {
+	exitQueueEpoch := headEpoch + 1 + s.config.MaxSeedLookahead
+	activeCount := uint64(0)
+	for _, validator := range s.validators {
+		if validator.ExitEpoch != FarFutureEpoch && validator.ExitEpoch > exitQueueEpoch {
+			exitQueueEpoch = validator.ExitEpoch
+		}
+		if validator.ActivationEpoch <= headEpoch && headEpoch < validator.ExitEpoch {
+			activeCount++
+		}
+	}
+	exitQueueChurn := uint64(0)
+	for _, validator := range s.validators {
+		if validator.ExitEpoch == exitQueueEpoch {
+			exitQueueChurn++
+		}
+	}
+	churnLimit := s.config.MinPerEpochChurnLimit
+	if s.config.ChurnLimitQuotient > 0 && activeCount/s.config.ChurnLimitQuotient > churnLimit {
+		churnLimit = activeCount / s.config.ChurnLimitQuotient
+	}
+	if exitQueueChurn >= churnLimit {
+		exitQueueEpoch++
+	}
+	return exitQueueEpoch
+}
+
+// Verifies a voluntary exit signature against the domain that StandardHttpClient uses for exits
+func (s *Server) verifyExitSignature(pubkey types.ValidatorPubkey, validatorIndex uint64, epoch uint64, signature types.ValidatorSignature) error {
+
+	if err := eth2types.InitBLS(); err != nil {
+		return err
+	}
+	forkVersion, err := hexutil.Decode(client.CapellaForkVersion)
+	if err != nil {
+		return err
+	}
+	var domainType [4]byte
+	copy(domainType[:], eth2types.DomainVoluntaryExit[:])
+	domain := eth2types.Domain(domainType, forkVersion, s.config.GenesisValidatorsRoot)
+
+	exitMessage := eth2.VoluntaryExit{
+		Epoch:          epoch,
+		ValidatorIndex: validatorIndex,
+	}
+	objectRoot, err := exitMessage.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	signingRoot := eth2.SigningRoot{
+		ObjectRoot: objectRoot[:],
+		Domain:     domain,
+	}
+	signingRootHash, err := signingRoot.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+
+	blsPubkey, err := eth2types.BLSPublicKeyFromBytes(pubkey.Bytes())
+	if err != nil {
+		return fmt.Errorf("invalid pubkey for validator %d: %w", validatorIndex, err)
+	}
+	blsSignature, err := eth2types.BLSSignatureFromBytes(signature.Bytes())
+	if err != nil {
+		return fmt.Errorf("invalid exit signature for validator %d: %w", validatorIndex, err)
+	}
+	if !blsSignature.Verify(signingRootHash[:], blsPubkey) {
+		return fmt.Errorf("exit signature for validator %d does not verify", validatorIndex)
+	}
+	return nil
+
+}
+
+// Get the slot for a state ID; the state itself is always the current one
+func (s *Server) resolveSlot(stateId string) (uint64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch stateId {
+	case "head", "justified":
+		return s.headSlot, nil
+	case "finalized":
+		return s.finalizedEpoch * s.config.SlotsPerEpoch, nil
+	case "genesis":
+		return 0, nil
+	}
+	slot, err := strconv.ParseUint(stateId, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid state ID %s", stateId)
+	}
+	if slot > s.headSlot {
+		return 0, fmt.Errorf("state %s not found", stateId)
+	}
+	return slot, nil
+}
+
+// Find a validator by its index or pubkey; the lock must be held by the caller
+func (s *Server) findValidator(id string) *Validator {
+	if strings.HasPrefix(id, "0x") {
+		pubkey, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(id))
+		if err != nil {
+			return nil
+		}
+		for _, validator := range s.validators {
+			if validator.Pubkey == pubkey {
+				return validator
+			}
+		}
+		return nil
+	}
+	index, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || index >= uint64(len(s.validators)) {
+		return nil
+	}
+	return s.validators[index]
+}
+
+// Find a block by its ID
+func (s *Server) findBlock(blockId string) (Block, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var slot uint64
+	switch blockId {
+	case "head":
+		slot = s.headSlot
+	case "finalized":
+		slot = s.finalizedEpoch * s.config.SlotsPerEpoch
+	case "genesis":
+		slot = 0
+	default:
+		var err error
+		slot, err = strconv.ParseUint(blockId, 10, 64)
+		if err != nil {
+			return Block{}, false
+		}
+	}
+	block, exists := s.blocks[slot]
+	return block, exists
+}
+
+// Write a successful response in the standard Beacon API envelope
+func writeData(w http.ResponseWriter, data interface{}) {
+	bytes, err := json.Marshal(map[string]interface{}{
+		"data": data,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", client.RequestContentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(bytes)
+}
+
+// Write an error response in the standard Beacon API format
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	bytes, _ := json.Marshal(map[string]interface{}{
+		"code":    statusCode,
+		"message": message,
+	})
+	w.Header().Set("Content-Type", client.RequestContentType)
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(bytes)
+}
+
+func checkpoint(epoch uint64) map[string]interface{} {
+	return map[string]interface{}{
+		"epoch": uintString(epoch),
+		"root":  hexString(make([]byte, 32)),
+	}
+}
+
+func uintString(value uint64) string {
+	return strconv.FormatUint(value, 10)
+}
+
+func hexString(value []byte) string {
+	return hexutil.AddPrefix(hex.EncodeToString(value))
+}
+
+func minUint(a uint64, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+// Settings for client pool health scoring
+const (
+	clientHealthCheckInterval time.Duration = 30 * time.Second
+	defaultReconnectDelay     time.Duration = 60 * time.Second
+
+	// Weight of the newest sample in the latency and error rate moving averages
+	clientStatsSampleWeight float64 = 0.2
+
+	// Score penalties
+	maxClientScore           float64 = 100
+	maxHeadLagPenalty        float64 = 50
+	maxLatencyPenalty        float64 = 25
+	latencyPenaltyPerMs      float64 = 0.05
+	errorRatePenalty         float64 = 50
+	priorityPenaltyPerRank   float64 = 1
+	unhealthyClientScore     float64 = 0
+	ecHeadLagPenaltyPerBlock float64 = 5
+	bcHeadLagPenaltyPerSlot  float64 = 5
+)
+
+// A single endpoint in a client pool and its health record
+type clientEndpoint struct {
+	url       string
+	priority  int
+	ready     bool
+	status    api.ClientStatus
+	head      uint64
+	headLag   uint64
+	latencyMs float64
+	errorRate float64
+	retryAt   time.Time
+}
+
+// An ordered pool of client endpoints that routes requests to the best healthy one
+type clientPool struct {
+	endpoints        []*clientEndpoint
+	headLagPenalty   float64
+	reconnectDelay   time.Duration
+	lastHealthCheck  time.Time
+	lastSelection    int
+	healthCheckMutex sync.Mutex
+	checkingHealth   bool
+	lock             sync.Mutex
+}
+
+// Creates a new pool from an ordered list of endpoint URLs; all endpoints start out as ready
+func newClientPool(urls []string, headLagPenalty float64, reconnectDelay time.Duration) *clientPool {
+	endpoints := make([]*clientEndpoint, len(urls))
+	for i, url := range urls {
+		endpoints[i] = &clientEndpoint{
+			url:      url,
+			priority: i,
+			ready:    true,
+		}
+	}
+	return &clientPool{
+		endpoints:      endpoints,
+		headLagPenalty: headLagPenalty,
+		reconnectDelay: reconnectDelay,
+		lastSelection:  -1,
+	}
+}
+
+// Parses the configured reconnect delay, falling back to the default if it's invalid
+func parseReconnectDelay(value interface{}) time.Duration {
+	delayString, ok := value.(string)
+	if !ok {
+		return defaultReconnectDelay
+	}
+	delay, err := time.ParseDuration(delayString)
+	if err != nil || delay <= 0 {
+		return defaultReconnectDelay
+	}
+	return delay
+}
+
+// Set whether an endpoint can be used, regardless of its health
+func (p *clientPool) setReady(index int, ready bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.endpoints[index].ready = ready
+}
+
+// Check if an endpoint is currently usable
+func (p *clientPool) isReady(index int) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.endpoints[index].ready
+}
+
+// Starts the periodic health check in the background if it's due and isn't already running, so requests never wait
+// on a slow endpoint's probe
+func (p *clientPool) refreshHealthIfDue(checkHealth func()) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.checkingHealth || p.lastHealthCheck.IsZero() || time.Since(p.lastHealthCheck) <= clientHealthCheckInterval {
+		return
+	}
+	p.checkingHealth = true
+	go func() {
+		checkHealth()
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		p.checkingHealth = false
+	}()
+}
+
+// Records the result of a health probe for an endpoint
+func (p *clientPool) setHealth(index int, status api.ClientStatus, head uint64, latency time.Duration, ready bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	endpoint := p.endpoints[index]
+	endpoint.status = status
+	endpoint.ready = ready
+	if status.IsWorking {
+		endpoint.head = head
+		endpoint.addSample(latency, false)
+	} else {
+		endpoint.addSample(latency, true)
+	}
+}
+
+// Recalculates each endpoint's head lag against the highest head in the pool, and marks the check as complete
+func (p *clientPool) finishHealthCheck() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var highestHead uint64
+	for _, endpoint := range p.endpoints {
+		if endpoint.status.IsWorking && endpoint.head > highestHead {
+			highestHead = endpoint.head
+		}
+	}
+	for _, endpoint := range p.endpoints {
+		if endpoint.status.IsWorking {
+			endpoint.headLag = highestHead - endpoint.head
+		} else {
+			endpoint.headLag = 0
+		}
+	}
+	p.lastHealthCheck = time.Now()
+}
+
+// Records the outcome of a request routed to an endpoint
+func (p *clientPool) recordResult(index int, latency time.Duration, disconnected bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	endpoint := p.endpoints[index]
+	endpoint.addSample(latency, disconnected)
+	if disconnected {
+		endpoint.ready = false
+		endpoint.retryAt = time.Now().Add(p.reconnectDelay)
+	}
+}
+
+// Get the indices of the usable endpoints, best first
+func (p *clientPool) getCandidates() []int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// Give disconnected endpoints another chance once their reconnect delay has passed
+	now := time.Now()
+	candidates := []int{}
+	for i, endpoint := range p.endpoints {
+		if !endpoint.ready && endpoint.status.Error == "" && !endpoint.retryAt.IsZero() && now.After(endpoint.retryAt) {
+			endpoint.ready = true
+			endpoint.retryAt = time.Time{}
+		}
+		if endpoint.ready {
+			candidates = append(candidates, i)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return p.score(p.endpoints[candidates[i]]) > p.score(p.endpoints[candidates[j]])
+	})
+	return candidates
+}
+
+// Log the endpoint that a request was routed to, returning true if it changed since the last request
+func (p *clientPool) setSelection(index int) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	changed := p.lastSelection != -1 && p.lastSelection != index
+	p.lastSelection = index
+	return changed
+}
+
+// Get the URL of an endpoint
+func (p *clientPool) getUrl(index int) string {
+	return p.endpoints[index].url
+}
+
+// Get the number of endpoints in the pool
+func (p *clientPool) size() int {
+	return len(p.endpoints)
+}
+
+// Builds the status report for every endpoint in the pool
+func (p *clientPool) getEndpointStatuses() []api.ClientEndpointStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	bestIndex := -1
+	bestScore := unhealthyClientScore
+	for i, endpoint := range p.endpoints {
+		if !endpoint.ready {
+			continue
+		}
+		score := p.score(endpoint)
+		if bestIndex == -1 || score > bestScore {
+			bestIndex = i
+			bestScore = score
+		}
+	}
+
+	statuses := make([]api.ClientEndpointStatus, len(p.endpoints))
+	for i, endpoint := range p.endpoints {
+		statuses[i] = api.ClientEndpointStatus{
+			Url:        endpoint.url,
+			Priority:   endpoint.priority,
+			Status:     endpoint.status,
+			Head:       endpoint.head,
+			HeadLag:    endpoint.headLag,
+			LatencyMs:  int64(endpoint.latencyMs),
+			ErrorRate:  endpoint.errorRate,
+			IsHealthy:  endpoint.ready,
+			IsSelected: i == bestIndex,
+		}
+		if endpoint.ready {
+			statuses[i].Score = p.score(endpoint)
+		}
+	}
+	return statuses
+}
+
+// Get the status of the best ready endpoint other than the primary, or the first fallback if none are ready
+func (p *clientPool) getBestFallbackStatus() api.ClientStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if len(p.endpoints) < 2 {
+		return api.ClientStatus{}
+	}
+	best := p.endpoints[1]
+	for _, endpoint := range p.endpoints[1:] {
+		if endpoint.ready && (!best.ready || p.score(endpoint) > p.score(best)) {
+			best = endpoint
+		}
+	}
+	return best.status
+}
+
+// Check if any endpoint other than the primary is ready
+func (p *clientPool) isFallbackReady() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, endpoint := range p.endpoints[1:] {
+		if endpoint.ready {
+			return true
+		}
+	}
+	return false
+}
+
+// Calculates an endpoint's health score; higher is better
+func (p *clientPool) score(endpoint *clientEndpoint) float64 {
+	if !endpoint.ready {
+		return unhealthyClientScore
+	}
+	score := maxClientScore
+	score -= minFloat(float64(endpoint.headLag)*p.headLagPenalty, maxHeadLagPenalty)
+	score -= minFloat(endpoint.latencyMs*latencyPenaltyPerMs, maxLatencyPenalty)
+	score -= endpoint.errorRate * errorRatePenalty
+	score -= float64(endpoint.priority) * priorityPenaltyPerRank
+	return score
+}
+
+// Adds a request sample to the endpoint's moving averages
+func (e *clientEndpoint) addSample(latency time.Duration, failed bool) {
+	failure := 0.0
+	if failed {
+		failure = 1.0
+	}
+	e.errorRate = e.errorRate*(1-clientStatsSampleWeight) + failure*clientStatsSampleWeight
+	if !failed {
+		latencyMs := float64(latency) / float64(time.Millisecond)
+		if e.latencyMs == 0 {
+			e.latencyMs = latencyMs
+		} else {
+			e.latencyMs = e.latencyMs*(1-clientStatsSampleWeight) + latencyMs*clientStatsSampleWeight
+		}
+	}
+}
+
+func minFloat(a float64, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
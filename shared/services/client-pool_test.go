@@ -0,0 +1,120 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+func TestClientPoolScore(t *testing.T) {
+	pool := newClientPool([]string{"primary", "fallback"}, ecHeadLagPenaltyPerBlock, defaultReconnectDelay)
+
+	// 2 blocks behind, 100ms, a 10% error rate and second in line
+	endpoint := pool.endpoints[1]
+	endpoint.headLag = 2
+	endpoint.latencyMs = 100
+	endpoint.errorRate = 0.1
+	if score := pool.score(endpoint); score != 79 {
+		t.Errorf("expected a score of 79, got %f", score)
+	}
+
+	// The head lag and latency penalties are capped
+	endpoint.headLag = 1000
+	endpoint.latencyMs = 100000
+	endpoint.errorRate = 0
+	if score := pool.score(endpoint); score != maxClientScore-maxHeadLagPenalty-maxLatencyPenalty-priorityPenaltyPerRank {
+		t.Errorf("expected the capped penalties, got a score of %f", score)
+	}
+
+	// Endpoints that aren't ready get the lowest score
+	endpoint.ready = false
+	if score := pool.score(endpoint); score != unhealthyClientScore {
+		t.Errorf("expected an unready endpoint to score %f, got %f", unhealthyClientScore, score)
+	}
+}
+
+func TestClientPoolFinishHealthCheck(t *testing.T) {
+	pool := newClientPool([]string{"primary", "fallback 1", "fallback 2"}, ecHeadLagPenaltyPerBlock, defaultReconnectDelay)
+	pool.setHealth(0, api.ClientStatus{IsWorking: true, IsSynced: true}, 97, 10*time.Millisecond, true)
+	pool.setHealth(1, api.ClientStatus{IsWorking: true, IsSynced: true}, 100, 10*time.Millisecond, true)
+	pool.setHealth(2, api.ClientStatus{Error: "connection refused"}, 0, 0, false)
+	pool.finishHealthCheck()
+
+	// Head lag is measured against the highest head of the working endpoints
+	expectedLags := []uint64{3, 0, 0}
+	for i, expectedLag := range expectedLags {
+		if pool.endpoints[i].headLag != expectedLag {
+			t.Errorf("expected endpoint %d to lag by %d, got %d", i, expectedLag, pool.endpoints[i].headLag)
+		}
+	}
+	if pool.lastHealthCheck.IsZero() {
+		t.Error("expected the health check to be marked as complete")
+	}
+	if pool.endpoints[2].errorRate == 0 {
+		t.Error("expected the failed probe to count as an error")
+	}
+}
+
+func TestClientPoolGetCandidates(t *testing.T) {
+	pool := newClientPool([]string{"primary", "fallback 1", "fallback 2"}, ecHeadLagPenaltyPerBlock, time.Hour)
+
+	// The second fallback is faster than the first, and the primary disconnects
+	pool.recordResult(1, 400*time.Millisecond, false)
+	pool.recordResult(2, 10*time.Millisecond, false)
+	pool.recordResult(0, 0, true)
+	candidates := pool.getCandidates()
+	if len(candidates) != 2 || candidates[0] != 2 || candidates[1] != 1 {
+		t.Fatalf("expected candidates [2 1], got %v", candidates)
+	}
+
+	// Once its reconnect delay has passed the primary is tried again
+	pool.endpoints[0].retryAt = time.Now().Add(-time.Second)
+	candidates = pool.getCandidates()
+	if len(candidates) != 3 || !pool.isReady(0) {
+		t.Fatalf("expected the primary to be a candidate again, got %v", candidates)
+	}
+
+	// Endpoints that failed their health check wait for the next one instead
+	pool.setHealth(1, api.ClientStatus{Error: "wrong chain"}, 0, 0, false)
+	pool.endpoints[1].retryAt = time.Now().Add(-time.Second)
+	for _, index := range pool.getCandidates() {
+		if index == 1 {
+			t.Error("expected the unhealthy endpoint not to be a candidate")
+		}
+	}
+}
+
+func TestClientPoolRefreshHealthIfDue(t *testing.T) {
+	pool := newClientPool([]string{"primary"}, ecHeadLagPenaltyPerBlock, defaultReconnectDelay)
+	checks := make(chan struct{}, 2)
+	release := make(chan struct{})
+	checkHealth := func() {
+		checks <- struct{}{}
+		<-release
+		pool.finishHealthCheck()
+	}
+
+	// Nothing runs before the first check or while the scores are fresh
+	pool.refreshHealthIfDue(checkHealth)
+	pool.finishHealthCheck()
+	pool.refreshHealthIfDue(checkHealth)
+	if len(checks) != 0 {
+		t.Fatal("expected no health check to start")
+	}
+
+	// A stale pool starts one check in the background without waiting for it, and only one at a time
+	pool.lastHealthCheck = time.Now().Add(-2 * clientHealthCheckInterval)
+	pool.refreshHealthIfDue(checkHealth)
+	pool.refreshHealthIfDue(checkHealth)
+	select {
+	case <-checks:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a health check to start")
+	}
+	close(release)
+	time.Sleep(10 * time.Millisecond)
+	if len(checks) != 0 {
+		t.Error("expected only one health check to run")
+	}
+}
@@ -20,9 +20,18 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package config
 
 import (
+	"strings"
+
 	"github.com/stader-labs/stader-node/shared/types/config"
 )
 
+// Descriptions of the additional fallback URLs, shared by the normal and Prysm configs
+const (
+	additionalUrlsDescription   string = "\n\nSeparate multiple URLs with commas. They will be added to the client pool in the order they are listed, after the fallback URL above."
+	additionalEcUrlsDescription string = "The URLs of any additional HTTP API endpoints for fallback Execution clients. The Stadernode will periodically score every Execution client by its sync state, head lag, latency and error rate, and route requests to the best healthy one." + additionalUrlsDescription
+	additionalCcUrlsDescription string = "The URLs of any additional HTTP Beacon API endpoints for fallback Consensus clients. The Stadernode will periodically score every Beacon Node by its sync state, head lag, latency and error rate, and route requests to the best healthy one." + additionalUrlsDescription
+)
+
 // Configuration for fallback Lighthouse
 type FallbackNormalConfig struct {
 	Title string `yaml:"-"`
@@ -32,6 +41,12 @@ type FallbackNormalConfig struct {
 
 	// The URL of the Beacon Node HTTP endpoint
 	CcHttpUrl config.Parameter `yaml:"ccHttpUrl,omitempty"`
+
+	// Additional Execution Client HTTP endpoints, in order of preference
+	AdditionalEcHttpUrls config.Parameter `yaml:"additionalEcHttpUrls,omitempty"`
+
+	// Additional Beacon Node HTTP endpoints, in order of preference
+	AdditionalCcHttpUrls config.Parameter `yaml:"additionalCcHttpUrls,omitempty"`
 }
 
 // Configuration for fallback Prysm
@@ -46,6 +61,12 @@ type FallbackPrysmConfig struct {
 
 	// The URL of the JSON-RPC endpoint for the Validator client
 	JsonRpcUrl config.Parameter `yaml:"jsonRpcUrl,omitempty"`
+
+	// Additional Execution Client HTTP endpoints, in order of preference
+	AdditionalEcHttpUrls config.Parameter `yaml:"additionalEcHttpUrls,omitempty"`
+
+	// Additional Beacon Node HTTP endpoints, in order of preference
+	AdditionalCcHttpUrls config.Parameter `yaml:"additionalCcHttpUrls,omitempty"`
 }
 
 // Generates a new FallbackNormalConfig configuration
@@ -76,6 +97,30 @@ func NewFallbackNormalConfig(cfg *StaderConfig) *FallbackNormalConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
+
+		AdditionalEcHttpUrls: config.Parameter{
+			ID:                   "additionalEcHttpUrls",
+			Name:                 "Additional Execution Client URLs",
+			Description:          additionalEcUrlsDescription,
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AdditionalCcHttpUrls: config.Parameter{
+			ID:                   "additionalCcHttpUrls",
+			Name:                 "Additional Beacon Node URLs",
+			Description:          additionalCcUrlsDescription,
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 }
 
@@ -119,6 +164,30 @@ func NewFallbackPrysmConfig(cfg *StaderConfig) *FallbackPrysmConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
+
+		AdditionalEcHttpUrls: config.Parameter{
+			ID:                   "additionalEcHttpUrls",
+			Name:                 "Additional Execution Client URLs",
+			Description:          additionalEcUrlsDescription,
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AdditionalCcHttpUrls: config.Parameter{
+			ID:                   "additionalCcHttpUrls",
+			Name:                 "Additional Beacon Node URLs",
+			Description:          additionalCcUrlsDescription,
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 }
 
@@ -127,6 +196,8 @@ func (cfg *FallbackNormalConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.EcHttpUrl,
 		&cfg.CcHttpUrl,
+		&cfg.AdditionalEcHttpUrls,
+		&cfg.AdditionalCcHttpUrls,
 	}
 }
 
@@ -136,6 +207,8 @@ func (cfg *FallbackPrysmConfig) GetParameters() []*config.Parameter {
 		&cfg.EcHttpUrl,
 		&cfg.CcHttpUrl,
 		&cfg.JsonRpcUrl,
+		&cfg.AdditionalEcHttpUrls,
+		&cfg.AdditionalCcHttpUrls,
 	}
 }
 
@@ -148,3 +221,53 @@ func (config *FallbackNormalConfig) GetConfigTitle() string {
 func (config *FallbackPrysmConfig) GetConfigTitle() string {
 	return config.Title
 }
+
+// Get the ordered list of fallback Execution client URLs for the selected Consensus client
+func (cfg *StaderConfig) GetFallbackEcUrls() []string {
+	if cfg.UseFallbackClients.Value != true {
+		return []string{}
+	}
+	if !cfg.IsNativeMode {
+		cc, _ := cfg.GetSelectedConsensusClient()
+		if cc == config.ConsensusClient_Prysm {
+			return getEndpointList(cfg.FallbackPrysm.EcHttpUrl, cfg.FallbackPrysm.AdditionalEcHttpUrls)
+		}
+	}
+	return getEndpointList(cfg.FallbackNormal.EcHttpUrl, cfg.FallbackNormal.AdditionalEcHttpUrls)
+}
+
+// Get the ordered list of fallback Beacon Node URLs for the selected Consensus client
+func (cfg *StaderConfig) GetFallbackCcUrls() []string {
+	if cfg.UseFallbackClients.Value != true {
+		return []string{}
+	}
+	if !cfg.IsNativeMode {
+		cc, _ := cfg.GetSelectedConsensusClient()
+		if cc == config.ConsensusClient_Prysm {
+			return getEndpointList(cfg.FallbackPrysm.CcHttpUrl, cfg.FallbackPrysm.AdditionalCcHttpUrls)
+		}
+	}
+	return getEndpointList(cfg.FallbackNormal.CcHttpUrl, cfg.FallbackNormal.AdditionalCcHttpUrls)
+}
+
+// Combine a fallback URL and a comma-separated list of additional URLs, dropping blanks and duplicates
+func getEndpointList(url config.Parameter, additionalUrls config.Parameter) []string {
+	urls := []string{}
+	seen := map[string]bool{}
+	candidates := []string{}
+	if value, ok := url.Value.(string); ok {
+		candidates = append(candidates, value)
+	}
+	if value, ok := additionalUrls.Value.(string); ok {
+		candidates = append(candidates, strings.Split(value, ",")...)
+	}
+	for _, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "" || seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		urls = append(urls, candidate)
+	}
+	return urls
+}
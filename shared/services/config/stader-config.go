@@ -1101,6 +1101,11 @@ func (cfg *StaderConfig) Validate() []string {
 		errors = append(errors, "You are using an externally-managed Execution client and a locally-managed Consensus client.\nThis configuration is not compatible with The Merge; please select either locally-managed or externally-managed for both the EC and CC.")
 	}
 
+	// The containers only get the password file; the environment, commands and systemd credentials of the host don't reach them
+	if !cfg.IsNativeMode && cfg.StaderNode.PasswordProvider.Value != config.PasswordProvider_File {
+		errors = append(errors, "The node password can only be read from an environment variable, a command or a systemd credential in Native mode, since they aren't passed into the Docker containers. Please set the Password Provider to File.")
+	}
+
 	// Ensure there's a MEV-boost URL
 	if !cfg.IsNativeMode && cfg.EnableMevBoost.Value == true {
 		if cfg.StaderNode.Network.Value.(config.Network) == config.Network_Zhejiang {
@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/mitchellh/go-homedir"
 	"github.com/stader-labs/stader-node/shared"
+	"github.com/stader-labs/stader-node/shared/services/passwords"
 	"github.com/stader-labs/stader-node/shared/types/config"
 	stader_backend "github.com/stader-labs/stader-node/shared/types/stader-backend"
 )
@@ -95,7 +96,40 @@ type StaderNodeConfig struct {
 	// Max tx fee for a single tx override
 	TxFeeCap config.Parameter `yaml:"txFeeCap,omitempty"`
 
-	// URL for an EC with archive mode, for manual rewards tree generation
+	// Where suggested fees come from
+	FeeEstimator config.Parameter `yaml:"feeEstimator,omitempty"`
+
+	// What signs for the node account
+	NodeSigner config.Parameter `yaml:"nodeSigner,omitempty"`
+
+	// The JSON-RPC endpoint of the external node account signer
+	ExternalSignerUrl config.Parameter `yaml:"externalSignerUrl,omitempty"`
+
+	// The node account held by the external signer
+	ExternalSignerAddress config.Parameter `yaml:"externalSignerAddress,omitempty"`
+
+	// Where the node password is kept
+	PasswordProvider config.Parameter `yaml:"passwordProvider,omitempty"`
+
+	// The environment variable holding the node password
+	PasswordEnvVar config.Parameter `yaml:"passwordEnvVar,omitempty"`
+
+	// The command that prints the node password
+	PasswordCommand config.Parameter `yaml:"passwordCommand,omitempty"`
+
+	// The systemd credential holding the node password
+	PasswordCredential config.Parameter `yaml:"passwordCredential,omitempty"`
+
+	// The Web3Signer that holds the validator keys
+	Web3SignerUrl config.Parameter `yaml:"web3SignerUrl,omitempty"`
+
+	// The Validator client key manager API that new validator keys are loaded through
+	KeymanagerApiUrl config.Parameter `yaml:"keymanagerApiUrl,omitempty"`
+
+	// The file holding the key manager API's bearer token
+	KeymanagerApiTokenPath config.Parameter `yaml:"keymanagerApiTokenPath,omitempty"`
+
+	// URL for an EC with archive mode, for manual rewards tree generation and historical metrics
 	ArchiveECUrl config.Parameter `yaml:"archiveEcUrl,omitempty"`
 
 	///////////////////////////
@@ -198,7 +232,7 @@ func NewStadernodeConfig(cfg *StaderConfig) *StaderNodeConfig {
 		PriorityFee: config.Parameter{
 			ID:                   "priorityFee",
 			Name:                 "Priority Fee",
-			Description:          "The default value for the priority fee (in gwei) for all of your transactions. This describes how much you're willing to pay *above the network's current base fee* - the higher this is, the more ETH you give to the validators for including your transaction, which generally means it will be included in a block faster (as long as your max fee is sufficiently high to cover the current network conditions).\n\nMust be larger than 0.",
+			Description:          "The default value for the priority fee (in gwei) for all of your transactions. This describes how much you're willing to pay *above the network's current base fee* - the higher this is, the more ETH you give to the validators for including your transaction, which generally means it will be included in a block faster (as long as your max fee is sufficiently high to cover the current network conditions).\n\nWhen the Fee Estimator is set to Execution Client and no Manual Max Fee is set, the estimated priority fee is used instead.\n\nMust be larger than 0.",
 			Type:                 config.ParameterType_Float,
 			Default:              map[config.Network]interface{}{config.Network_All: float64(2)},
 			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Guardian},
@@ -219,13 +253,180 @@ func NewStadernodeConfig(cfg *StaderConfig) *StaderNodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		FeeEstimator: config.Parameter{
+			ID:                   "feeEstimator",
+			Name:                 "Fee Estimator",
+			Description:          "Where the Stadernode gets its suggested max fee and priority fee from when you haven't set them yourself.\n\nThe Execution client option works them out from the fees paid in recent blocks, without contacting any third parties.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.FeeEstimator_ExecutionClient},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Execution Client",
+				Description: "Estimate fees from your Execution client's recent blocks (eth_feeHistory)",
+				Value:       config.FeeEstimator_ExecutionClient,
+			}, {
+				Name:        "Web Oracles",
+				Description: "Use the Etherchain gas oracle, falling back to Etherscan's. These are third-party services that can see when you are about to send a transaction.",
+				Value:       config.FeeEstimator_WebOracle,
+			}},
+		},
+
+		NodeSigner: config.Parameter{
+			ID:                   "nodeSigner",
+			Name:                 "Node Account Signer",
+			Description:          "What signs transactions and messages for your node account.\n\nUse an external signer to keep the node account's key in a separate, hardened process instead of deriving it from your node wallet. Your validator keys are still derived from the node wallet.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.NodeSigner_Local},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Node Wallet",
+				Description: "Sign with the node account key derived from your node wallet",
+				Value:       config.NodeSigner_Local,
+			}, {
+				Name:        "External (Clef)",
+				Description: "Send signing requests to a Clef-compatible signer over JSON-RPC (account_signTransaction and account_signData)",
+				Value:       config.NodeSigner_Clef,
+			}},
+		},
+
+		ExternalSignerUrl: config.Parameter{
+			ID:                   "externalSignerUrl",
+			Name:                 "External Signer URL",
+			Description:          "The HTTP, WebSocket or IPC endpoint of your external signer, such as http://clef:8550. Only used when the Node Account Signer is External.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ExternalSignerAddress: config.Parameter{
+			ID:                   "externalSignerAddress",
+			Name:                 "External Signer Address",
+			Description:          "The address of the node account held by your external signer. Leave this blank if the signer only holds one account.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PasswordProvider: config.Parameter{
+			ID:                   "passwordProvider",
+			Name:                 "Password Provider",
+			Description:          "Where the password that encrypts your node wallet is kept.\n\nThe other options keep it from ever being saved on disk in plaintext, which is useful for headless deployments. The node can't set or change the password with them; set it where it is kept before initializing or recovering the wallet.\n\n[orange]The other options are only supported in Native mode[white], where the Stader node runs as a service on this machine; the Docker containers only get the password file.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.PasswordProvider_File},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "File",
+				Description: "Save the password in a file in your data folder",
+				Value:       config.PasswordProvider_File,
+			}, {
+				Name:        "Environment Variable",
+				Description: "Read the password from an environment variable of the Stader node processes",
+				Value:       config.PasswordProvider_Env,
+			}, {
+				Name:        "Command",
+				Description: "Run a command that prints the password, such as your secrets manager's client",
+				Value:       config.PasswordProvider_Command,
+			}, {
+				Name:        "systemd Credential",
+				Description: "Read the password from a credential systemd passes to the Stader node service with LoadCredential= or LoadCredentialEncrypted=",
+				Value:       config.PasswordProvider_SystemdCredential,
+			}},
+		},
+
+		PasswordEnvVar: config.Parameter{
+			ID:                   "passwordEnvVar",
+			Name:                 "Password Environment Variable",
+			Description:          "The environment variable holding the node password. Only used when the Password Provider is Environment Variable.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: passwords.DefaultPasswordEnvVar},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PasswordCommand: config.Parameter{
+			ID:                   "passwordCommand",
+			Name:                 "Password Command",
+			Description:          "The shell command that prints the node password, such as `vault kv get -field=password secret/stader`. It is run when the wallet is first opened and must finish within 30 seconds. Only used when the Password Provider is Command.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PasswordCredential: config.Parameter{
+			ID:                   "passwordCredential",
+			Name:                 "Password Credential",
+			Description:          "The name of the systemd credential holding the node password. Only used when the Password Provider is systemd Credential.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: passwords.DefaultPasswordCredential},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Guardian},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Web3SignerUrl: config.Parameter{
+			ID:                   "web3SignerUrl",
+			Name:                 "Web3Signer URL",
+			Description:          "The URL of a Web3Signer to hold your validator keys, such as http://web3signer:9000. Its key manager API must be enabled.\n\nWhen this is set, new validator keys are imported into the Web3Signer instead of being saved in your Validator client's keystores, your Validator client signs through it, and deposits and exit messages are signed through it too.\n\nLeave this blank to keep your validator keys on this machine.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{"WEB3SIGNER_URL"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KeymanagerApiUrl: config.Parameter{
+			ID:                   "keymanagerApiUrl",
+			Name:                 "Keymanager API URL",
+			Description:          "The URL of your Validator client's key manager API, such as http://validator:7500.\n\nWhen this is set, new and recovered validator keys are loaded into the running Validator client through its API instead of being saved as keystore files, so it doesn't need to be restarted. This works with any Validator client that supports the standard key manager API, including ones Stader doesn't manage for you.\n\nLeave this blank to save validator keys as keystore files. It is ignored if a Web3Signer URL is set.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KeymanagerApiTokenPath: config.Parameter{
+			ID:                   "keymanagerApiTokenPath",
+			Name:                 "Keymanager API Token File",
+			Description:          "The path of the file your Validator client writes its key manager API token to, as seen by the Stader node.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		ArchiveECUrl: config.Parameter{
 			ID:                   "archiveECUrl",
 			Name:                 "Archive-Mode EC URL",
-			Description:          "[orange]**For manual Merkle rewards tree generation only.**[white]\n\nGenerating the Merkle rewards tree files for past rewards intervals typically requires an Execution client with Archive mode enabled, which is usually disabled on your primary and fallback Execution clients to save disk space.\nIf you want to generate your own rewards tree files for intervals from a long time ago, you may enter the URL of an Execution client with Archive access here.\n\nFor a free light client with Archive access, you may use https://www.alchemy.com/supernode.",
+			Description:          "[orange]**For manual Merkle rewards tree generation only.**[white]\n\nGenerating the Merkle rewards tree files for past rewards intervals typically requires an Execution client with Archive mode enabled, which is usually disabled on your primary and fallback Execution clients to save disk space.\nIf you want to generate your own rewards tree files for intervals from a long time ago, you may enter the URL of an Execution client with Archive access here.\n\nFor a free light client with Archive access, you may use https://www.alchemy.com/supernode.\n\nIt is also used to read metrics and `stader-cli node report` snapshots for slots older than your primary Execution client keeps state for.",
 			Type:                 config.ParameterType_String,
 			Default:              map[config.Network]interface{}{config.Network_All: ""},
-			AffectsContainers:    []config.ContainerID{config.ContainerID_Guardian},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Guardian, config.ContainerID_Node},
 			EnvironmentVariables: []string{},
 			CanBeBlank:           true,
 			OverwriteOnUpgrade:   false,
@@ -289,6 +490,17 @@ func (cfg *StaderNodeConfig) GetParameters() []*config.Parameter {
 		&cfg.ManualMaxFee,
 		&cfg.PriorityFee,
 		&cfg.TxFeeCap,
+		&cfg.FeeEstimator,
+		&cfg.NodeSigner,
+		&cfg.ExternalSignerUrl,
+		&cfg.ExternalSignerAddress,
+		&cfg.PasswordProvider,
+		&cfg.PasswordEnvVar,
+		&cfg.PasswordCommand,
+		&cfg.PasswordCredential,
+		&cfg.Web3SignerUrl,
+		&cfg.KeymanagerApiUrl,
+		&cfg.KeymanagerApiTokenPath,
 		&cfg.ArchiveECUrl,
 	}
 }
@@ -364,6 +576,14 @@ func (cfg *StaderNodeConfig) GetValidatorKeychainPath() string {
 	return filepath.Join(DaemonDataPath, "validators")
 }
 
+func (cfg *StaderNodeConfig) GetTxJournalPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), "tx-journal.json")
+	}
+
+	return filepath.Join(DaemonDataPath, "tx-journal.json")
+}
+
 func (cfg *StaderNodeConfig) GetWalletPathInCLI() string {
 	return filepath.Join(cfg.DataPath.Value.(string), "wallet")
 }
@@ -0,0 +1,268 @@
+package dryrun
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/stader-lib/contracts"
+	"github.com/stader-labs/stader-node/stader-lib/stader"
+)
+
+// The topic of ERC20 Transfer events
+var transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// An execution client that can trace calls
+type Client interface {
+	stader.ExecutionClient
+	TraceCall(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int, tracerOptions map[string]interface{}, result interface{}) error
+}
+
+// A call in a callTracer trace
+type callFrame struct {
+	Type  string          `json:"type"`
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to"`
+	Value *hexutil.Big    `json:"value"`
+	Error string          `json:"error"`
+	Calls []callFrame     `json:"calls"`
+	Logs  []callLog       `json:"logs"`
+}
+
+// A log emitted by a call in a callTracer trace
+type callLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+// The balance of an account in ETH or an ERC20 token
+type balanceKey struct {
+	address common.Address
+	token   common.Address
+	isToken bool
+}
+
+// Simulate an unsigned transaction against the latest block with eth_call, and trace it to find the events it would emit
+// and the balances it would change
+func Simulate(client Client, from common.Address, tx *types.Transaction) (api.DryRunSimulation, error) {
+
+	simulation := api.DryRunSimulation{
+		From:           from,
+		To:             tx.To(),
+		Nonce:          tx.Nonce(),
+		Value:          tx.Value(),
+		Data:           tx.Data(),
+		Method:         txmanager.GetMethodName(tx.Data()),
+		Arguments:      decodeArguments(tx.Data()),
+		GasLimit:       tx.Gas(),
+		MaxFee:         tx.GasFeeCap(),
+		MaxPriorityFee: tx.GasTipCap(),
+	}
+
+	// Pin the simulation to the latest block
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return api.DryRunSimulation{}, fmt.Errorf("error getting the latest block: %w", err)
+	}
+	simulation.BlockNumber = header.Number.Uint64()
+
+	// Run the transaction
+	call := ethereum.CallMsg{
+		From:      from,
+		To:        tx.To(),
+		Gas:       tx.Gas(),
+		GasFeeCap: tx.GasFeeCap(),
+		GasTipCap: tx.GasTipCap(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	}
+	if _, err := client.CallContract(context.Background(), call, header.Number); err != nil {
+		simulation.Reverted = true
+		simulation.RevertReason = stader.DecodeRevert(err).Error()
+		return simulation, nil
+	}
+	simulation.PredictedGas, err = client.EstimateGas(context.Background(), ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	})
+	if err != nil {
+		return api.DryRunSimulation{}, fmt.Errorf("error estimating gas: %w", stader.DecodeRevert(err))
+	}
+
+	// Trace it for internal transfers and events; without a trace, only the transaction's own value is known
+	var trace callFrame
+	err = client.TraceCall(context.Background(), call, header.Number, map[string]interface{}{
+		"tracer":       "callTracer",
+		"tracerConfig": map[string]interface{}{"withLog": true},
+	}, &trace)
+	if err != nil {
+		simulation.TraceError = err.Error()
+		trace = callFrame{Type: "CALL", From: from, To: tx.To(), Value: (*hexutil.Big)(tx.Value())}
+	}
+
+	// The sender pays for the gas
+	keys := []balanceKey{}
+	changes := map[balanceKey]*big.Int{}
+	addChange := func(key balanceKey, amount *big.Int) {
+		if _, exists := changes[key]; !exists {
+			keys = append(keys, key)
+			changes[key] = big.NewInt(0)
+		}
+		changes[key].Add(changes[key], amount)
+	}
+	gasPrice := new(big.Int).Set(tx.GasFeeCap())
+	if header.BaseFee != nil && new(big.Int).Add(header.BaseFee, tx.GasTipCap()).Cmp(gasPrice) < 0 {
+		gasPrice.Add(header.BaseFee, tx.GasTipCap())
+	}
+	gasCost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(simulation.PredictedGas))
+	addChange(balanceKey{address: from}, gasCost.Neg(gasCost))
+
+	// Walk the trace
+	var walk func(frame callFrame)
+	walk = func(frame callFrame) {
+		if frame.Error != "" {
+			// Reverted calls don't move anything
+			return
+		}
+		switch frame.Type {
+		case "CALL", "CREATE", "CREATE2", "SELFDESTRUCT":
+			if frame.Value != nil && frame.To != nil && frame.Value.ToInt().Sign() > 0 {
+				value := frame.Value.ToInt()
+				addChange(balanceKey{address: frame.From}, new(big.Int).Neg(value))
+				addChange(balanceKey{address: *frame.To}, value)
+			}
+		}
+		for _, log := range frame.Logs {
+			simulation.Events = append(simulation.Events, decodeEvent(log))
+			if len(log.Topics) == 3 && log.Topics[0] == transferTopic && len(log.Data) == 32 {
+				amount := new(big.Int).SetBytes(log.Data)
+				addChange(balanceKey{address: common.BytesToAddress(log.Topics[1].Bytes()), token: log.Address, isToken: true}, new(big.Int).Neg(amount))
+				addChange(balanceKey{address: common.BytesToAddress(log.Topics[2].Bytes()), token: log.Address, isToken: true}, amount)
+			}
+		}
+		for _, child := range frame.Calls {
+			walk(child)
+		}
+	}
+	walk(trace)
+
+	symbols := map[common.Address]string{}
+	for _, key := range keys {
+		if changes[key].Sign() == 0 {
+			continue
+		}
+		change := api.DryRunBalanceChange{
+			Address: key.address,
+			Symbol:  "ETH",
+			Change:  changes[key],
+		}
+		if key.isToken {
+			token := key.token
+			change.Token = &token
+			if _, exists := symbols[token]; !exists {
+				symbols[token] = getTokenSymbol(client, token, header.Number)
+			}
+			change.Symbol = symbols[token]
+		}
+		simulation.BalanceChanges = append(simulation.BalanceChanges, change)
+	}
+
+	return simulation, nil
+
+}
+
+// Decode a transaction's arguments if it calls a known contract method
+func decodeArguments(data []byte) []api.DryRunArgument {
+	method := txmanager.GetMethod(data)
+	if method == nil {
+		return nil
+	}
+	values, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil
+	}
+	arguments := make([]api.DryRunArgument, len(values))
+	for i, value := range values {
+		arguments[i] = api.DryRunArgument{
+			Name:  method.Inputs[i].Name,
+			Type:  method.Inputs[i].Type.String(),
+			Value: txmanager.FormatValue(value),
+		}
+	}
+	return arguments
+}
+
+// Decode a log into a known event and its arguments
+func decodeEvent(log callLog) api.DryRunEvent {
+	decoded := api.DryRunEvent{Address: log.Address}
+	if len(log.Topics) == 0 {
+		decoded.Name = "anonymous"
+		return decoded
+	}
+	event := txmanager.GetEvent(log.Topics[0])
+	if event == nil {
+		decoded.Name = log.Topics[0].Hex()
+		return decoded
+	}
+	decoded.Name = event.RawName
+
+	values := map[string]interface{}{}
+	indexed := abi.Arguments{}
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:]); err != nil {
+		return decoded
+	}
+	if err := event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+		return decoded
+	}
+	for _, input := range event.Inputs {
+		decoded.Arguments = append(decoded.Arguments, api.DryRunArgument{
+			Name:  input.Name,
+			Type:  input.Type.String(),
+			Value: txmanager.FormatValue(values[input.Name]),
+		})
+	}
+	return decoded
+}
+
+// Get an ERC20 token's symbol, or its address if it doesn't have one
+func getTokenSymbol(client Client, token common.Address, blockNumber *big.Int) string {
+	erc20Abi, err := abi.JSON(strings.NewReader(contracts.Erc20MetaData.ABI))
+	if err != nil {
+		return token.Hex()
+	}
+	input, err := erc20Abi.Pack("symbol")
+	if err != nil {
+		return token.Hex()
+	}
+	output, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &token, Data: input}, blockNumber)
+	if err != nil {
+		return token.Hex()
+	}
+	values, err := erc20Abi.Unpack("symbol", output)
+	if err != nil || len(values) == 0 {
+		return token.Hex()
+	}
+	symbol, ok := values[0].(string)
+	if !ok || symbol == "" {
+		return token.Hex()
+	}
+	return symbol
+}
@@ -0,0 +1,139 @@
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stader-labs/stader-node/stader-lib/contracts"
+	"github.com/stader-labs/stader-node/stader-lib/stader"
+)
+
+// An execution client that serves a fixed trace
+type fakeClient struct {
+	stader.ExecutionClient
+	erc20    abi.ABI
+	revert   error
+	trace    string
+	traceErr error
+}
+
+func (c *fakeClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: big.NewInt(1000), BaseFee: big.NewInt(10e9)}, nil
+}
+
+func (c *fakeClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if len(call.Data) >= 4 && string(call.Data[:4]) == string(c.erc20.Methods["symbol"].ID) {
+		return c.erc20.Methods["symbol"].Outputs.Pack("SD")
+	}
+	return nil, c.revert
+}
+
+func (c *fakeClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return 50000, nil
+}
+
+func (c *fakeClient) TraceCall(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int, tracerOptions map[string]interface{}, result interface{}) error {
+	if c.traceErr != nil {
+		return c.traceErr
+	}
+	return json.Unmarshal([]byte(c.trace), result)
+}
+
+func TestSimulate(t *testing.T) {
+	erc20Abi, err := abi.JSON(strings.NewReader(contracts.Erc20MetaData.ABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	collateral := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	token := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	// Approving the collateral contract, which emits an Approval and then moves SD and ETH in a subcall
+	data, err := erc20Abi.Pack("approve", collateral, big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{Nonce: 3, GasTipCap: big.NewInt(1e9), GasFeeCap: big.NewInt(30e9), Gas: 75000, To: &token, Value: big.NewInt(0), Data: data})
+	amount := common.LeftPadBytes(big.NewInt(100).Bytes(), 32)
+	trace := map[string]interface{}{
+		"type": "CALL", "from": node, "to": token, "value": "0x0",
+		"logs": []map[string]interface{}{{
+			"address": token,
+			"topics":  []common.Hash{erc20Abi.Events["Approval"].ID, common.BytesToHash(node.Bytes()), common.BytesToHash(collateral.Bytes())},
+			"data":    hexutil.Bytes(amount),
+		}},
+		"calls": []map[string]interface{}{{
+			"type": "CALL", "from": token, "to": collateral, "value": "0x5",
+			"logs": []map[string]interface{}{{
+				"address": token,
+				"topics":  []common.Hash{transferTopic, common.BytesToHash(node.Bytes()), common.BytesToHash(collateral.Bytes())},
+				"data":    hexutil.Bytes(amount),
+			}},
+		}, {
+			"type": "CALL", "from": token, "to": node, "value": "0x7", "error": "execution reverted",
+		}},
+	}
+	traceBytes, err := json.Marshal(trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &fakeClient{erc20: erc20Abi, trace: string(traceBytes)}
+
+	simulation, err := Simulate(client, node, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if simulation.Reverted || simulation.BlockNumber != 1000 || simulation.PredictedGas != 50000 || simulation.Method != "approve" {
+		t.Errorf("unexpected simulation: %+v", simulation)
+	}
+	if len(simulation.Arguments) != 2 || simulation.Arguments[0].Value != collateral.Hex() || simulation.Arguments[1].Value != "100" {
+		t.Errorf("unexpected arguments: %+v", simulation.Arguments)
+	}
+	if len(simulation.Events) != 2 || simulation.Events[0].Name != "Approval" || simulation.Events[0].Arguments[2].Value != "100" || simulation.Events[1].Name != "Transfer" {
+		t.Errorf("unexpected events: %+v", simulation.Events)
+	}
+
+	// Gas at 11 gwei, the token's 5 wei, and the SD transfer; the reverted subcall moves nothing
+	expected := map[string]*big.Int{
+		node.Hex() + " ETH":       big.NewInt(-50000 * 11e9),
+		token.Hex() + " ETH":      big.NewInt(-5),
+		collateral.Hex() + " ETH": big.NewInt(5),
+		node.Hex() + " SD":        big.NewInt(-100),
+		collateral.Hex() + " SD":  big.NewInt(100),
+	}
+	if len(simulation.BalanceChanges) != len(expected) {
+		t.Errorf("expected %d balance changes, got %+v", len(expected), simulation.BalanceChanges)
+	}
+	for _, change := range simulation.BalanceChanges {
+		if amount, exists := expected[change.Address.Hex()+" "+change.Symbol]; !exists || amount.Cmp(change.Change) != 0 {
+			t.Errorf("unexpected balance change of %s %s for %s", change.Change, change.Symbol, change.Address.Hex())
+		}
+	}
+
+	// Without a trace only the gas is known, and reverts stop the simulation
+	client.traceErr = errors.New("the method debug_traceCall does not exist/is not available")
+	simulation, err = Simulate(client, node, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if simulation.TraceError == "" || len(simulation.Events) != 0 || len(simulation.BalanceChanges) != 1 {
+		t.Errorf("unexpected simulation without a trace: %+v", simulation)
+	}
+	client.revert = errors.New("execution reverted: ERC20: insufficient allowance")
+	simulation, err = Simulate(client, node, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !simulation.Reverted || simulation.RevertReason != "execution reverted: ERC20: insufficient allowance" {
+		t.Errorf("unexpected reverted simulation: %+v", simulation)
+	}
+}
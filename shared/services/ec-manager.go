@@ -24,30 +24,36 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/fatih/color"
 	"github.com/stader-labs/stader-node/shared/services/config"
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
 	"github.com/stader-labs/stader-node/shared/types/api"
 	cfgtypes "github.com/stader-labs/stader-node/shared/types/config"
 	"github.com/stader-labs/stader-node/shared/utils/log"
 )
 
 // This is a proxy for multiple ETH clients, providing natural fallback support if one of them fails.
+// The first client is the primary; the rest are fallbacks in order of preference. Requests are routed to the
+// healthiest ready client.
 type ExecutionClientManager struct {
-	primaryEcUrl    string
-	fallbackEcUrl   string
-	primaryEc       *ethclient.Client
-	fallbackEc      *ethclient.Client
+	clients         []*ethclient.Client
+	rpcClients      []*rpc.Client
+	pool            *clientPool
+	chainId         uint
 	logger          log.ColorLogger
-	primaryReady    bool
-	fallbackReady   bool
 	ignoreSyncCheck bool
+	journal         *txmanager.Journal
 }
 
 // This is a signature for a wrapped ethclient.Client function
@@ -57,7 +63,6 @@ type ecFunction func(*ethclient.Client) (interface{}, error)
 func NewExecutionClientManager(cfg *config.StaderConfig) (*ExecutionClientManager, error) {
 
 	var primaryEcUrl string
-	var fallbackEcUrl string
 
 	// Get the primary EC url
 	if cfg.IsNativeMode {
@@ -68,42 +73,35 @@ func NewExecutionClientManager(cfg *config.StaderConfig) (*ExecutionClientManage
 		primaryEcUrl = cfg.ExternalExecution.HttpUrl.Value.(string)
 	}
 
-	// Get the fallback EC url, if applicable
-	if cfg.UseFallbackClients.Value == true {
-		if cfg.IsNativeMode {
-			fallbackEcUrl = cfg.FallbackNormal.EcHttpUrl.Value.(string)
-		} else {
-			cc, _ := cfg.GetSelectedConsensusClient()
-			switch cc {
-			case cfgtypes.ConsensusClient_Prysm:
-				fallbackEcUrl = cfg.FallbackPrysm.EcHttpUrl.Value.(string)
-			default:
-				fallbackEcUrl = cfg.FallbackNormal.EcHttpUrl.Value.(string)
-			}
+	// Get the fallback EC urls, if applicable
+	ecUrls := []string{primaryEcUrl}
+	for _, fallbackEcUrl := range cfg.GetFallbackEcUrls() {
+		if fallbackEcUrl != primaryEcUrl {
+			ecUrls = append(ecUrls, fallbackEcUrl)
 		}
 	}
 
-	primaryEc, err := ethclient.Dial(primaryEcUrl)
-	if err != nil {
-		return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", primaryEcUrl, err)
-	}
-
-	var fallbackEc *ethclient.Client
-	if fallbackEcUrl != "" {
-		fallbackEc, err = ethclient.Dial(fallbackEcUrl)
+	clients := make([]*ethclient.Client, len(ecUrls))
+	rpcClients := make([]*rpc.Client, len(ecUrls))
+	for i, ecUrl := range ecUrls {
+		rpcClient, err := rpc.Dial(ecUrl)
 		if err != nil {
-			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
+			if i == 0 {
+				return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", ecUrl, err)
+			}
+			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", ecUrl, err)
 		}
+		clients[i] = ethclient.NewClient(rpcClient)
+		rpcClients[i] = rpcClient
 	}
 
 	return &ExecutionClientManager{
-		primaryEcUrl:  primaryEcUrl,
-		fallbackEcUrl: fallbackEcUrl,
-		primaryEc:     primaryEc,
-		fallbackEc:    fallbackEc,
-		logger:        log.NewColorLogger(color.FgYellow),
-		primaryReady:  true,
-		fallbackReady: fallbackEc != nil,
+		clients:    clients,
+		rpcClients: rpcClients,
+		pool:       newClientPool(ecUrls, ecHeadLagPenaltyPerBlock, parseReconnectDelay(cfg.ReconnectDelay.Value)),
+		chainId:    cfg.StaderNode.GetChainID(),
+		logger:     log.NewColorLogger(color.FgYellow),
+		journal:    txmanager.NewJournal(os.ExpandEnv(cfg.StaderNode.GetTxJournalPath())),
 	}, nil
 
 }
@@ -197,6 +195,18 @@ func (p *ExecutionClientManager) SuggestGasPrice(ctx context.Context) (*big.Int,
 	return result.(*big.Int), err
 }
 
+// FeeHistory retrieves the base fees and priority fee percentiles of recent blocks, ending at lastBlock
+// (or the latest block if it's nil).
+func (p *ExecutionClientManager) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ethereum.FeeHistory), err
+}
+
 // SuggestGasTipCap retrieves the currently suggested 1559 priority fee to allow
 // a timely execution of a transaction.
 func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
@@ -224,12 +234,40 @@ func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.
 	return result.(uint64), err
 }
 
+// TraceCall runs a call with debug_traceCall at the given block (or the latest block if it's nil), using the given
+// tracer options, and unmarshals the trace into result.
+func (p *ExecutionClientManager) TraceCall(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int, tracerOptions map[string]interface{}, result interface{}) error {
+	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		for i, candidate := range p.clients {
+			if candidate == client {
+				return nil, p.rpcClients[i].CallContext(ctx, result, "debug_traceCall", toTraceCallArg(call), toBlockNumArg(blockNumber), tracerOptions)
+			}
+		}
+		return nil, fmt.Errorf("unknown Execution client")
+	})
+	return err
+}
+
 // SendTransaction injects the transaction into the pending pool for execution.
+// Sent transactions are recorded in the transaction journal.
 func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.Transaction) error {
 	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
 		return nil, client.SendTransaction(ctx, tx)
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	// The transaction is already out, so failing to record it shouldn't fail the send
+	if err := p.journal.Record(tx); err != nil {
+		p.logger.Printlnf("WARNING: could not record transaction %s in the journal: %s", tx.Hash().Hex(), err.Error())
+	}
+	return nil
+}
+
+// Get the journal of transactions sent through this manager
+func (p *ExecutionClientManager) GetJournal() *txmanager.Journal {
+	return p.journal
 }
 
 /// ==========================
@@ -351,45 +389,81 @@ func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.Sy
 func (p *ExecutionClientManager) CheckStatus(cfg *config.StaderConfig) *api.ClientManagerStatus {
 
 	status := &api.ClientManagerStatus{
-		FallbackEnabled: p.fallbackEc != nil,
+		FallbackEnabled: p.pool.size() > 1,
 	}
 
 	// Ignore the sync check and just use the predefined settings if requested
 	if p.ignoreSyncCheck {
-		status.PrimaryClientStatus.IsWorking = p.primaryReady
-		status.PrimaryClientStatus.IsSynced = p.primaryReady
+		status.PrimaryClientStatus.IsWorking = p.pool.isReady(0)
+		status.PrimaryClientStatus.IsSynced = p.pool.isReady(0)
 		if status.FallbackEnabled {
-			status.FallbackClientStatus.IsWorking = p.fallbackReady
-			status.FallbackClientStatus.IsSynced = p.fallbackReady
+			status.FallbackClientStatus.IsWorking = p.pool.isFallbackReady()
+			status.FallbackClientStatus.IsSynced = p.pool.isFallbackReady()
 		}
+		status.Endpoints = p.pool.getEndpointStatuses()
 		return status
 	}
 
-	// Get the primary EC status
-	status.PrimaryClientStatus = checkEcStatus(p.primaryEc)
-
-	// Flag if primary client is ready
-	p.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
+	// Score every client in the pool
+	p.checkHealth()
 
-	// Get the fallback EC status if applicable
+	status.Endpoints = p.pool.getEndpointStatuses()
+	status.PrimaryClientStatus = status.Endpoints[0].Status
 	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkEcStatus(p.fallbackEc)
-		// Check if fallback is using the expected network
-		expectedChainID := cfg.StaderNode.GetChainID()
-		if status.FallbackClientStatus.NetworkId != expectedChainID {
-			p.fallbackReady = false
-			colorReset := "\033[0m"
-			colorYellow := "\033[33m"
-			status.FallbackClientStatus.Error = fmt.Sprintf("The fallback client is using a different chain [%s%s%s, Chain ID %d] than what your node is configured for [%s, Chain ID %d]", colorYellow, getNetworkNameFromId(status.FallbackClientStatus.NetworkId), colorReset, status.FallbackClientStatus.NetworkId, getNetworkNameFromId(expectedChainID), expectedChainID)
-			return status
-		}
+		status.FallbackClientStatus = p.pool.getBestFallbackStatus()
 	}
 
-	p.fallbackReady = (status.FallbackEnabled && status.FallbackClientStatus.IsWorking && status.FallbackClientStatus.IsSynced)
-
 	return status
 }
 
+// Probes every client in the pool concurrently and updates their health scores
+func (p *ExecutionClientManager) checkHealth() {
+
+	p.pool.healthCheckMutex.Lock()
+	defer p.pool.healthCheckMutex.Unlock()
+
+	var wg sync.WaitGroup
+	for i, client := range p.clients {
+		wg.Add(1)
+		go func(index int, client *ethclient.Client) {
+			defer wg.Done()
+
+			// Get the sync status
+			status := checkEcStatus(client)
+			ready := status.IsWorking && status.IsSynced
+
+			// Get the head block and measure the latency
+			var head uint64
+			var latency time.Duration
+			if status.IsWorking {
+				var err error
+				start := time.Now()
+				head, err = client.BlockNumber(context.Background())
+				latency = time.Since(start)
+				if err != nil {
+					status.Error = fmt.Sprintf("Head block check failed with [%s]", err.Error())
+					status.IsWorking = false
+					status.IsSynced = false
+					ready = false
+				}
+			}
+
+			// Check if a fallback is using the expected network
+			if index > 0 && status.IsWorking && status.NetworkId != p.chainId {
+				ready = false
+				colorReset := "\033[0m"
+				colorYellow := "\033[33m"
+				status.Error = fmt.Sprintf("The fallback client is using a different chain [%s%s%s, Chain ID %d] than what your node is configured for [%s, Chain ID %d]", colorYellow, getNetworkNameFromId(status.NetworkId), colorReset, status.NetworkId, getNetworkNameFromId(p.chainId), p.chainId)
+			}
+
+			p.pool.setHealth(index, status, head, latency, ready)
+		}(i, client)
+	}
+	wg.Wait()
+
+	p.pool.finishHealthCheck()
+}
+
 func getNetworkNameFromId(networkId uint) string {
 	switch networkId {
 	case 1:
@@ -471,41 +545,36 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 
 }
 
-// Attempts to run a function progressively through each client until one succeeds or they all fail.
+// Attempts to run a function progressively through each client, best first, until one succeeds or they all fail.
 func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{}, error) {
 
-	// Check if we can use the primary
-	if p.primaryReady {
-		// Try to run the function on the primary
-		result, err := function(p.primaryEc)
-		if err != nil {
-			if p.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				p.logger.Printlnf("WARNING: Primary Execution client disconnected (%s), using fallback...", err.Error())
-				p.primaryReady = false
-				return p.runFunction(function)
-			}
-
-			// If it's a different error, just return it
-			return nil, err
-		}
+	// Refresh the health scores in the background if they're stale
+	if !p.ignoreSyncCheck {
+		p.pool.refreshHealthIfDue(p.checkHealth)
+	}
 
-		// If there's no error, return the result
-		return result, nil
+	candidates := p.pool.getCandidates()
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no Execution clients were ready")
 	}
 
-	if p.fallbackReady {
-		// Try to run the function on the fallback
-		result, err := function(p.fallbackEc)
-		if err != nil {
-			if p.isDisconnected(err) {
-				// If it's disconnected, log it and try the fallback
-				p.logger.Printlnf("WARNING: Fallback Execution client disconnected (%s)", err.Error())
-				p.fallbackReady = false
-				return nil, fmt.Errorf("all Execution clients failed")
-			}
+	for _, index := range candidates {
+		// Try to run the function on the client
+		start := time.Now()
+		result, err := function(p.clients[index])
+		if err != nil && p.isDisconnected(err) {
+			// If it's disconnected, log it and try the next client
+			p.pool.recordResult(index, time.Since(start), true)
+			p.logger.Printlnf("WARNING: %s Execution client disconnected (%s), trying the next one...", p.getClientName(index), err.Error())
+			continue
+		}
+		p.pool.recordResult(index, time.Since(start), false)
+		if p.pool.setSelection(index) {
+			p.logger.Printlnf("NOTE: switched to the %s Execution client", p.getClientName(index))
+		}
 
-			// If it's a different error, just return it
+		// If it's a different error, just return it
+		if err != nil {
 			return nil, err
 		}
 
@@ -513,10 +582,53 @@ func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{},
 		return result, nil
 	}
 
-	return nil, fmt.Errorf("no Execution clients were ready")
+	return nil, fmt.Errorf("all Execution clients failed")
+}
+
+// Get a display name for a client in the pool
+func (p *ExecutionClientManager) getClientName(index int) string {
+	if index == 0 {
+		return "Primary"
+	}
+	return fmt.Sprintf("Fallback #%d", index)
 }
 
 // Returns true if the error was a connection failure and a backup client is available
 func (p *ExecutionClientManager) isDisconnected(err error) bool {
 	return strings.Contains(err.Error(), "dial tcp")
 }
+
+// Encode a call the way eth_call and debug_traceCall expect it
+func toTraceCallArg(call ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": call.From,
+		"to":   call.To,
+	}
+	if len(call.Data) > 0 {
+		arg["data"] = hexutil.Bytes(call.Data)
+	}
+	if call.Value != nil {
+		arg["value"] = (*hexutil.Big)(call.Value)
+	}
+	if call.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(call.Gas)
+	}
+	if call.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(call.GasPrice)
+	}
+	if call.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(call.GasFeeCap)
+	}
+	if call.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(call.GasTipCap)
+	}
+	return arg
+}
+
+// Encode a block number, using "latest" for nil
+func toBlockNumArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	return hexutil.EncodeBig(number)
+}
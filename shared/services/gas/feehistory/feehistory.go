@@ -0,0 +1,118 @@
+package feehistory
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+// The number of recent blocks to sample priority fees from
+const FeeHistoryBlocks uint64 = 20
+
+// The priority fee percentiles for the slow, standard and fast tiers
+var rewardPercentiles = []float64{10, 50, 90}
+
+// The number of full blocks each tier's max fee can absorb before the base fee outgrows it.
+// The base fee can rise by at most 12.5% per block.
+var projectedBlocks = []int{1, 3, 6}
+
+// An execution client that supports eth_feeHistory
+type Client interface {
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+// The max fee and priority fee for one speed tier
+type FeeTier struct {
+	MaxFeeWei         *big.Int
+	MaxPriorityFeeWei *big.Int
+	Time              string
+}
+
+type GasFeeSuggestion struct {
+	// The base fee of the next block
+	BaseFeeWei *big.Int
+
+	Slow     FeeTier
+	Standard FeeTier
+	Fast     FeeTier
+}
+
+// Estimate fees from the execution client's recent blocks
+func GetGasPrices(client Client) (GasFeeSuggestion, error) {
+
+	history, err := client.FeeHistory(context.Background(), FeeHistoryBlocks, nil, rewardPercentiles)
+	if err != nil {
+		return GasFeeSuggestion{}, fmt.Errorf("error getting fee history: %w", err)
+	}
+	if len(history.BaseFee) == 0 {
+		return GasFeeSuggestion{}, fmt.Errorf("the execution client returned an empty fee history")
+	}
+
+	// The last base fee is the one for the next block
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+
+	tiers := make([]FeeTier, len(rewardPercentiles))
+	for i := range rewardPercentiles {
+		priorityFee := medianReward(history, i)
+		tiers[i] = FeeTier{
+			MaxFeeWei:         new(big.Int).Add(projectBaseFee(baseFee, projectedBlocks[i]), priorityFee),
+			MaxPriorityFeeWei: priorityFee,
+		}
+	}
+	tiers[0].Time = ">1 Minute"
+	tiers[1].Time = "~30 Seconds"
+	tiers[2].Time = "~12 Seconds"
+
+	return GasFeeSuggestion{
+		BaseFeeWei: new(big.Int).Set(baseFee),
+		Slow:       tiers[0],
+		Standard:   tiers[1],
+		Fast:       tiers[2],
+	}, nil
+
+}
+
+// Get the tier's fees with a different priority fee, keeping the same headroom for the base fee.
+// The tier's own priority fee is used if the given one is nil.
+func (t FeeTier) WithPriorityFee(maxPriorityFee *big.Int) (*big.Int, *big.Int) {
+	if maxPriorityFee == nil {
+		return new(big.Int).Set(t.MaxFeeWei), new(big.Int).Set(t.MaxPriorityFeeWei)
+	}
+	maxFee := new(big.Int).Sub(t.MaxFeeWei, t.MaxPriorityFeeWei)
+	return maxFee.Add(maxFee, maxPriorityFee), new(big.Int).Set(maxPriorityFee)
+}
+
+// Get the median of a reward percentile across the sampled blocks, skipping empty blocks which report zero rewards
+func medianReward(history *ethereum.FeeHistory, percentile int) *big.Int {
+	rewards := []*big.Int{}
+	for i, blockRewards := range history.Reward {
+		if i < len(history.GasUsedRatio) && history.GasUsedRatio[i] == 0 {
+			continue
+		}
+		if percentile < len(blockRewards) && blockRewards[percentile] != nil {
+			rewards = append(rewards, blockRewards[percentile])
+		}
+	}
+	if len(rewards) == 0 {
+		return big.NewInt(0)
+	}
+	sort.Slice(rewards, func(i, j int) bool {
+		return rewards[i].Cmp(rewards[j]) < 0
+	})
+	return new(big.Int).Set(rewards[len(rewards)/2])
+}
+
+// Get the highest the base fee can reach after the given number of full blocks
+func projectBaseFee(baseFee *big.Int, blocks int) *big.Int {
+	projected := new(big.Int).Set(baseFee)
+	for i := 0; i < blocks; i++ {
+		// Round up each step, like the protocol's minimum increase of 1 wei
+		increase := new(big.Int).Add(projected, big.NewInt(7))
+		increase.Div(increase, big.NewInt(8))
+		projected.Add(projected, increase)
+	}
+	return projected
+}
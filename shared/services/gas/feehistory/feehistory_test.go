@@ -0,0 +1,67 @@
+package feehistory
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+type fakeClient struct {
+	history *ethereum.FeeHistory
+}
+
+func (c *fakeClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return c.history, nil
+}
+
+func gwei(amount int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(amount), big.NewInt(1e9))
+}
+
+func TestGetGasPrices(t *testing.T) {
+	client := &fakeClient{
+		history: &ethereum.FeeHistory{
+			OldestBlock: big.NewInt(100),
+			Reward: [][]*big.Int{
+				{gwei(1), gwei(2), gwei(5)},
+				{gwei(0), gwei(0), gwei(0)},
+				{gwei(1), gwei(3), gwei(6)},
+				{gwei(2), gwei(3), gwei(9)},
+			},
+			BaseFee:      []*big.Int{gwei(10), gwei(11), gwei(10), gwei(12), gwei(16)},
+			GasUsedRatio: []float64{0.5, 0, 0.6, 0.9},
+		},
+	}
+
+	suggestion, err := GetGasPrices(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if suggestion.BaseFeeWei.Cmp(gwei(16)) != 0 {
+		t.Errorf("expected the next block's base fee of 16 gwei, got %s", suggestion.BaseFeeWei)
+	}
+
+	// The empty block is skipped, so the medians come from the other three
+	if suggestion.Slow.MaxPriorityFeeWei.Cmp(gwei(1)) != 0 || suggestion.Standard.MaxPriorityFeeWei.Cmp(gwei(3)) != 0 || suggestion.Fast.MaxPriorityFeeWei.Cmp(gwei(6)) != 0 {
+		t.Errorf("unexpected priority fees: %s, %s, %s", suggestion.Slow.MaxPriorityFeeWei, suggestion.Standard.MaxPriorityFeeWei, suggestion.Fast.MaxPriorityFeeWei)
+	}
+
+	// 16 gwei grows to 18, 22.78125 and 32.43697... gwei over 1, 3 and 6 full blocks
+	if expected := new(big.Int).Add(gwei(18), gwei(1)); suggestion.Slow.MaxFeeWei.Cmp(expected) != 0 {
+		t.Errorf("expected slow max fee %s, got %s", expected, suggestion.Slow.MaxFeeWei)
+	}
+	if expected := new(big.Int).Add(big.NewInt(22781250000), gwei(3)); suggestion.Standard.MaxFeeWei.Cmp(expected) != 0 {
+		t.Errorf("expected standard max fee %s, got %s", expected, suggestion.Standard.MaxFeeWei)
+	}
+	if suggestion.Fast.MaxFeeWei.Cmp(suggestion.Standard.MaxFeeWei) <= 0 {
+		t.Errorf("expected the fast max fee to be higher than the standard one")
+	}
+
+	// A requested priority fee keeps the tier's base fee headroom
+	maxFee, maxPriorityFee := suggestion.Standard.WithPriorityFee(gwei(2))
+	if maxPriorityFee.Cmp(gwei(2)) != 0 || maxFee.Cmp(new(big.Int).Add(big.NewInt(22781250000), gwei(2))) != 0 {
+		t.Errorf("unexpected fees with a 2 gwei priority fee: %s, %s", maxFee, maxPriorityFee)
+	}
+}
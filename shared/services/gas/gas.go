@@ -23,12 +23,15 @@ import (
 	"fmt"
 	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/stader-labs/stader-node/shared/utils/log"
 
 	"github.com/stader-labs/stader-node/shared/services/gas/etherchain"
 	"github.com/stader-labs/stader-node/shared/services/gas/etherscan"
 	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	cfgtypes "github.com/stader-labs/stader-node/shared/types/config"
 	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
 	"github.com/stader-labs/stader-node/shared/utils/math"
 	staderCore "github.com/stader-labs/stader-node/stader-lib/stader"
@@ -47,6 +50,7 @@ func AssignMaxFeeAndLimit(gasInfo staderCore.GasInfo, staderClient *stader.Clien
 
 	// Get the current settings from the CLI arguments
 	maxFeeGwei, maxPriorityFeeGwei, gasLimit := staderClient.GetGasSettings()
+	requestedPriorityFeeGwei := maxPriorityFeeGwei
 
 	// Get the max fee - prioritize the CLI arguments, default to the config file setting
 	if maxFeeGwei == 0 {
@@ -55,9 +59,11 @@ func AssignMaxFeeAndLimit(gasInfo staderCore.GasInfo, staderClient *stader.Clien
 			maxFeeGwei = eth.WeiToGwei(maxFee)
 		}
 	}
+	useFeeHistory := maxFeeGwei == 0 && cfg.StaderNode.FeeEstimator.Value != cfgtypes.FeeEstimator_WebOracle
 
-	// Get the priority fee - prioritize the CLI arguments, default to the config file setting
-	if maxPriorityFeeGwei == 0 {
+	// Get the priority fee - prioritize the CLI arguments, default to the config file setting.
+	// Fees estimated by the Execution client come with their own priority fee.
+	if maxPriorityFeeGwei == 0 && !useFeeHistory {
 		maxPriorityFee := eth.GweiToWei(cfg.StaderNode.PriorityFee.Value.(float64))
 		if maxPriorityFee == nil || maxPriorityFee.Uint64() == 0 {
 			fmt.Printf("%sNOTE: max priority fee not set or set to 0, defaulting to 2 gwei%s\n", log.ColorYellow, log.ColorReset)
@@ -82,6 +88,18 @@ func AssignMaxFeeAndLimit(gasInfo staderCore.GasInfo, staderClient *stader.Clien
 		}
 		fmt.Printf("Total cost: %.4f to %.4f ETH%s\n", lowLimit, highLimit, log.ColorReset)
 
+	} else if useFeeHistory {
+		estimate, err := staderClient.GetFeeEstimate()
+		if err != nil {
+			return fmt.Errorf("Error estimating fees from the Execution client: %w\nYou can set the fees yourself with the --maxFee and --maxPrioFee flags.", err)
+		}
+		tier := estimate.Fast
+		if !headless {
+			tier = handleFeeEstimate(estimate, gasInfo)
+		}
+		maxFeeGwei, maxPriorityFeeGwei = getTierFees(tier, requestedPriorityFeeGwei)
+		fmt.Printf("%sUsing a max fee of %.2f gwei and a priority fee of %.2f gwei.\n%s", log.ColorBlue, maxFeeGwei, maxPriorityFeeGwei, log.ColorReset)
+
 	} else {
 		if headless {
 			maxFeeWei, err := GetHeadlessMaxFeeWei()
@@ -124,7 +142,7 @@ func AssignMaxFeeAndLimit(gasInfo staderCore.GasInfo, staderClient *stader.Clien
 
 }
 
-// Get the suggested max fee for service operations
+// Get the suggested max fee for service operations from the web oracles
 func GetHeadlessMaxFeeWei() (*big.Int, error) {
 	etherchainData, err := etherchain.GetGasPrices()
 	if err == nil {
@@ -140,6 +158,73 @@ func GetHeadlessMaxFeeWei() (*big.Int, error) {
 	return nil, fmt.Errorf("Error getting gas price suggestions: %w", err)
 }
 
+// Print the fee tiers estimated by the Execution client and ask which one to use, or for a custom max fee
+func handleFeeEstimate(estimate api.FeeEstimateResponse, gasInfo staderCore.GasInfo) api.FeeTier {
+
+	fmt.Printf("%sCurrent network fees (base fee %.2f gwei):%s\n", log.ColorBlue, eth.WeiToGwei(estimate.BaseFee), log.ColorReset)
+	tiers := []struct {
+		name string
+		tier api.FeeTier
+	}{{"Slow", estimate.Slow}, {"Standard", estimate.Standard}, {"Fast", estimate.Fast}}
+	for _, tier := range tiers {
+		maxFeeGwei := eth.WeiToGwei(tier.tier.MaxFee)
+		fmt.Printf("  %-8s  max fee %8.2f gwei, priority fee %6.2f gwei (%s), costs up to %.4f ETH\n",
+			tier.name,
+			maxFeeGwei,
+			eth.WeiToGwei(tier.tier.MaxPriorityFee),
+			tier.tier.Time,
+			maxFeeGwei/eth.WeiPerGwei*float64(gasInfo.SafeGasLimit))
+	}
+
+	for {
+		desiredPrice := cliutils.Prompt(
+			"Please enter slow, standard or fast, your own max fee in gwei (including the priority fee), or leave blank for fast:",
+			"^(?i:slow|standard|fast)?$|^(?:[1-9]\\d*|0)?(?:\\.\\d+)?$",
+			"Not a valid choice or gas price, try again:")
+
+		switch strings.ToLower(desiredPrice) {
+		case "", "fast":
+			return estimate.Fast
+		case "standard":
+			return estimate.Standard
+		case "slow":
+			return estimate.Slow
+		}
+
+		desiredPriceFloat, err := strconv.ParseFloat(desiredPrice, 64)
+		if err != nil {
+			fmt.Printf("Not a valid gas price (%s), try again.\n", err.Error())
+			continue
+		}
+		if desiredPriceFloat <= 0 {
+			fmt.Println("Max fee must be greater than zero.")
+			continue
+		}
+
+		// Keep the fast tier's priority fee unless it doesn't fit under the custom max fee
+		tier := api.FeeTier{
+			MaxFee:         eth.GweiToWei(desiredPriceFloat),
+			MaxPriorityFee: estimate.Fast.MaxPriorityFee,
+		}
+		if tier.MaxPriorityFee.Cmp(tier.MaxFee) > 0 {
+			tier.MaxPriorityFee = tier.MaxFee
+		}
+		return tier
+	}
+
+}
+
+// Get the max fee and priority fee to use from a fee tier, replacing its priority fee with the requested one if provided
+func getTierFees(tier api.FeeTier, requestedPriorityFeeGwei float64) (float64, float64) {
+	maxFeeGwei := eth.WeiToGwei(tier.MaxFee)
+	maxPriorityFeeGwei := eth.WeiToGwei(tier.MaxPriorityFee)
+	if requestedPriorityFeeGwei != 0 {
+		maxFeeGwei += requestedPriorityFeeGwei - maxPriorityFeeGwei
+		maxPriorityFeeGwei = requestedPriorityFeeGwei
+	}
+	return maxFeeGwei, maxPriorityFeeGwei
+}
+
 func handleEtherchainGasPrices(gasSuggestion etherchain.GasFeeSuggestion, priorityFee float64) float64 {
 	fastGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.FastWei)+priorityFee, 0)
 
@@ -184,7 +269,7 @@ func handleEtherscanGasPrices(gasSuggestion etherscan.GasFeeSuggestion, priority
 
 		desiredPriceFloat, err := strconv.ParseFloat(desiredPrice, 64)
 		if err != nil {
-			fmt.Println("Not a valid gas price (%s), try again.", err.Error())
+			fmt.Printf("Not a valid gas price (%s), try again.\n", err.Error())
 			continue
 		}
 		if desiredPriceFloat <= 0 {
@@ -0,0 +1,199 @@
+package keymanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	hexutils "github.com/stader-labs/stader-node/shared/utils/hex"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+// Config
+const (
+	RequestTimeout = 30 * time.Second
+
+	keystoresPath = "/eth/v1/keystores"
+)
+
+// Import and delete statuses
+const (
+	StatusImported  = "imported"
+	StatusDuplicate = "duplicate"
+	StatusDeleted   = "deleted"
+	StatusNotActive = "not_active"
+	StatusNotFound  = "not_found"
+	StatusError     = "error"
+)
+
+// A Validator client's key manager API, as defined in https://github.com/ethereum/keymanager-APIs
+type Client struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// A validator key loaded into the Validator client
+type ValidatorKey struct {
+	Pubkey         types.ValidatorPubkey
+	DerivationPath string
+	ReadOnly       bool
+}
+
+// The result of importing or deleting a keystore
+type Status struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// Request types
+type importKeystoresRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+type deleteKeystoresRequest struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+
+// Response types
+type listKeystoresResponse struct {
+	Data []struct {
+		ValidatingPubkey string `json:"validating_pubkey"`
+		DerivationPath   string `json:"derivation_path"`
+		ReadOnly         bool   `json:"readonly"`
+	} `json:"data"`
+}
+type importKeystoresResponse struct {
+	Data []Status `json:"data"`
+}
+type deleteKeystoresResponse struct {
+	Data               []Status `json:"data"`
+	SlashingProtection string   `json:"slashing_protection"`
+}
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// Create a new client for the key manager API at the URL, authenticated with the API token
+func NewClient(url string, token string) *Client {
+	return &Client{
+		url:    strings.TrimSuffix(url, "/"),
+		token:  strings.TrimSpace(token),
+		client: &http.Client{Timeout: RequestTimeout},
+	}
+}
+
+// Get the validator keys loaded into the Validator client
+func (c *Client) ListKeystores() ([]ValidatorKey, error) {
+	responseBody, err := c.request(http.MethodGet, keystoresPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Could not list the Validator client's keystores: %w", err)
+	}
+	var response listKeystoresResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode the Validator client's keystores: %w", err)
+	}
+	keys := make([]ValidatorKey, len(response.Data))
+	for i, data := range response.Data {
+		pubkey, err := types.HexToValidatorPubkey(hexutils.RemovePrefix(data.ValidatingPubkey))
+		if err != nil {
+			return nil, fmt.Errorf("The Validator client returned an invalid public key %s: %w", data.ValidatingPubkey, err)
+		}
+		keys[i] = ValidatorKey{
+			Pubkey:         pubkey,
+			DerivationPath: data.DerivationPath,
+			ReadOnly:       data.ReadOnly,
+		}
+	}
+	return keys, nil
+}
+
+// Import EIP-2335 keystores into the Validator client, along with an optional EIP-3076 slashing protection history
+func (c *Client) ImportKeystores(keystores []string, passwords []string, slashingProtection string) ([]Status, error) {
+	responseBody, err := c.request(http.MethodPost, keystoresPath, importKeystoresRequest{
+		Keystores:          keystores,
+		Passwords:          passwords,
+		SlashingProtection: slashingProtection,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not import keystores into the Validator client: %w", err)
+	}
+	var response importKeystoresResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode the Validator client's import response: %w", err)
+	}
+	if len(response.Data) != len(keystores) {
+		return nil, fmt.Errorf("The Validator client returned %d import results for %d keystores", len(response.Data), len(keystores))
+	}
+	return response.Data, nil
+}
+
+// Delete validator keys from the Validator client, returning the EIP-3076 slashing protection history it had for them
+func (c *Client) DeleteKeystores(pubkeys []types.ValidatorPubkey) ([]Status, string, error) {
+	pubkeyStrings := make([]string, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		pubkeyStrings[i] = hexutil.Encode(pubkey.Bytes())
+	}
+	responseBody, err := c.request(http.MethodDelete, keystoresPath, deleteKeystoresRequest{
+		Pubkeys: pubkeyStrings,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not delete keystores from the Validator client: %w", err)
+	}
+	var response deleteKeystoresResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, "", fmt.Errorf("Could not decode the Validator client's delete response: %w", err)
+	}
+	if len(response.Data) != len(pubkeys) {
+		return nil, "", fmt.Errorf("The Validator client returned %d delete results for %d keystores", len(response.Data), len(pubkeys))
+	}
+	return response.Data, response.SlashingProtection, nil
+}
+
+// Make a request to the key manager API and return the response body
+func (c *Client) request(method string, path string, body interface{}) ([]byte, error) {
+
+	var requestBody *bytes.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("Could not encode request: %w", err)
+		}
+		requestBody = bytes.NewReader(bodyBytes)
+	} else {
+		requestBody = bytes.NewReader(nil)
+	}
+
+	request, err := http.NewRequest(method, c.url+path, requestBody)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Authorization", "Bearer "+c.token)
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		var errResponse errorResponse
+		if err := json.Unmarshal(responseBody, &errResponse); err == nil && errResponse.Message != "" {
+			return nil, fmt.Errorf("HTTP status %d: %s", response.StatusCode, errResponse.Message)
+		}
+		return nil, fmt.Errorf("HTTP status %d: %s", response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	return responseBody, nil
+
+}
@@ -0,0 +1,87 @@
+package keymanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+func TestClient(t *testing.T) {
+	pubkey := types.BytesToValidatorPubkey(bytes.Repeat([]byte{0x11}, types.ValidatorPubkeyLength))
+	slashingProtection := `{"metadata":{"interchange_format_version":"5"},"data":[]}`
+	var lastImport importKeystoresRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer api-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(errorResponse{Message: "bad token"})
+			return
+		}
+		if r.URL.Path != keystoresPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"data":[{"validating_pubkey":"` + hexutil.Encode(pubkey.Bytes()) + `","derivation_path":"m/12381/3600/0/0/0","readonly":false}]}`))
+		case http.MethodPost:
+			if err := json.NewDecoder(r.Body).Decode(&lastImport); err != nil || len(lastImport.Keystores) != len(lastImport.Passwords) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(importKeystoresResponse{Data: []Status{{Status: StatusImported}}})
+		case http.MethodDelete:
+			var request deleteKeystoresRequest
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil || len(request.Pubkeys) != 1 || request.Pubkeys[0] != hexutil.Encode(pubkey.Bytes()) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(deleteKeystoresResponse{Data: []Status{{Status: StatusDeleted}}, SlashingProtection: slashingProtection})
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL+"/", "api-token\n")
+
+	// List
+	keys, err := client.ListKeystores()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0].Pubkey != pubkey || keys[0].DerivationPath != "m/12381/3600/0/0/0" {
+		t.Errorf("unexpected keystores %v", keys)
+	}
+
+	// Import, with the slashing protection history
+	statuses, err := client.ImportKeystores([]string{"{}"}, []string{"password"}, slashingProtection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0].Status != StatusImported {
+		t.Errorf("unexpected import statuses %v", statuses)
+	}
+	if lastImport.SlashingProtection != slashingProtection {
+		t.Errorf("the slashing protection history was not sent, got %q", lastImport.SlashingProtection)
+	}
+
+	// Delete, getting the slashing protection history back
+	statuses, exported, err := client.DeleteKeystores([]types.ValidatorPubkey{pubkey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0].Status != StatusDeleted || exported != slashingProtection {
+		t.Errorf("unexpected delete result %v %q", statuses, exported)
+	}
+
+	// A bad token is rejected with the API's message
+	if _, err := NewClient(server.URL, "wrong").ListKeystores(); err == nil || !bytes.Contains([]byte(err.Error()), []byte("bad token")) {
+		t.Errorf("expected the bad token to be rejected, got %v", err)
+	}
+}
@@ -22,8 +22,6 @@ package passwords
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"os"
 )
 
 // Config
@@ -34,34 +32,30 @@ const (
 
 // Password manager
 type PasswordManager struct {
-	passwordPath string
+	provider PasswordProvider
 }
 
-// Create new password manager
+// Create new password manager that keeps the password in a file
 func NewPasswordManager(passwordPath string) *PasswordManager {
+	return NewPasswordManagerWithProvider(NewFileProvider(passwordPath))
+}
+
+// Create new password manager that gets the password from a provider
+func NewPasswordManagerWithProvider(provider PasswordProvider) *PasswordManager {
 	return &PasswordManager{
-		passwordPath: passwordPath,
+		provider: provider,
 	}
 }
 
 // Check if the password has been set
 func (pm *PasswordManager) IsPasswordSet() bool {
-	_, err := ioutil.ReadFile(pm.passwordPath)
+	_, err := pm.provider.GetPassword()
 	return (err == nil)
 }
 
 // Get the password
 func (pm *PasswordManager) GetPassword() (string, error) {
-
-	// Read from disk
-	password, err := ioutil.ReadFile(pm.passwordPath)
-	if err != nil {
-		return "", fmt.Errorf("Could not read password from disk: %w", err)
-	}
-
-	// Return
-	return string(password), nil
-
+	return pm.provider.GetPassword()
 }
 
 // Set the password
@@ -77,9 +71,9 @@ func (pm *PasswordManager) SetPassword(password string) error {
 		return fmt.Errorf("Password must be at least %d characters long", MinPasswordLength)
 	}
 
-	// Write to disk
-	if err := ioutil.WriteFile(pm.passwordPath, []byte(password), FileMode); err != nil {
-		return fmt.Errorf("Could not write password to disk: %w", err)
+	// Save it
+	if err := pm.provider.StorePassword(password); err != nil {
+		return err
 	}
 
 	// Return
@@ -87,19 +81,53 @@ func (pm *PasswordManager) SetPassword(password string) error {
 
 }
 
-// Delete the password
-func (pm *PasswordManager) DeletePassword() error {
+// Replace the password
+func (pm *PasswordManager) ChangePassword(password string) error {
+
+	// Check the password can be replaced
+	if err := pm.CheckNewPassword(password); err != nil {
+		return err
+	}
+
+	// Save it
+	if err := pm.provider.StorePassword(password); err != nil {
+		return err
+	}
+
+	// Return
+	return nil
+
+}
+
+// Check that a new password can replace the current one
+func (pm *PasswordManager) CheckNewPassword(password string) error {
 
-	// Check if it exists
-	_, err := os.Stat(pm.passwordPath)
-	if os.IsNotExist(err) {
-		return nil
-	} else if err != nil {
-		return fmt.Errorf("error checking password file path: %w", err)
+	// Check password is set
+	if !pm.IsPasswordSet() {
+		return errors.New("Password is not set")
 	}
 
-	// Delete it
-	err = os.Remove(pm.passwordPath)
-	return err
+	// Check password length
+	if len(password) < MinPasswordLength {
+		return fmt.Errorf("Password must be at least %d characters long", MinPasswordLength)
+	}
+
+	// Return
+	return nil
 
 }
+
+// Check if the node can save the password itself
+func (pm *PasswordManager) IsReadOnly() bool {
+	return !pm.provider.IsWritable()
+}
+
+// Get the path of the password file, or an empty string if the password isn't kept in a file
+func (pm *PasswordManager) GetPasswordPath() string {
+	return pm.provider.GetPasswordPath()
+}
+
+// Delete the password
+func (pm *PasswordManager) DeletePassword() error {
+	return pm.provider.DeletePassword()
+}
@@ -0,0 +1,230 @@
+package passwords
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Provider defaults
+const (
+	DefaultPasswordEnvVar     = "STADER_NODE_PASSWORD"
+	DefaultPasswordCredential = "stader-node-password"
+	PasswordCommandTimeout    = 30 * time.Second
+	credentialsDirectoryEnv   = "CREDENTIALS_DIRECTORY"
+)
+
+// Where the node password is kept
+type PasswordProvider interface {
+	// Get the password; it must fail if the password is not set
+	GetPassword() (string, error)
+
+	// Save the password, replacing any existing one
+	StorePassword(password string) error
+
+	// Remove the password
+	DeletePassword() error
+
+	// Whether the node can save the password itself
+	IsWritable() bool
+
+	// The file the password is saved in, or an empty string if it isn't saved by the node
+	GetPasswordPath() string
+}
+
+// Error returned by providers that can't be written to
+type ReadOnlyPasswordError struct {
+	Source string
+}
+
+func (e *ReadOnlyPasswordError) Error() string {
+	return fmt.Sprintf("The password is read from %s and can't be changed by the node", e.Source)
+}
+
+// A plaintext password file
+type FileProvider struct {
+	path string
+}
+
+func NewFileProvider(path string) *FileProvider {
+	return &FileProvider{path: path}
+}
+
+func (p *FileProvider) GetPassword() (string, error) {
+	password, err := ioutil.ReadFile(p.path)
+	if err != nil {
+		return "", fmt.Errorf("Could not read password from disk: %w", err)
+	}
+	return string(password), nil
+}
+
+// Write to a temporary file and move it over the old one, so the password is never left half written
+func (p *FileProvider) StorePassword(password string) error {
+	tempPath := p.path + ".tmp"
+	if err := ioutil.WriteFile(tempPath, []byte(password), FileMode); err != nil {
+		return fmt.Errorf("Could not write password to disk: %w", err)
+	}
+	if err := os.Rename(tempPath, p.path); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("Could not write password to disk: %w", err)
+	}
+	return nil
+}
+
+func (p *FileProvider) DeletePassword() error {
+	_, err := os.Stat(p.path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error checking password file path: %w", err)
+	}
+	return os.Remove(p.path)
+}
+
+func (p *FileProvider) IsWritable() bool {
+	return true
+}
+
+func (p *FileProvider) GetPasswordPath() string {
+	return p.path
+}
+
+// An environment variable of the process that opens the wallet
+type EnvProvider struct {
+	name string
+}
+
+func NewEnvProvider(name string) *EnvProvider {
+	if name == "" {
+		name = DefaultPasswordEnvVar
+	}
+	return &EnvProvider{name: name}
+}
+
+func (p *EnvProvider) GetPassword() (string, error) {
+	password, exists := os.LookupEnv(p.name)
+	if !exists || password == "" {
+		return "", fmt.Errorf("The %s environment variable is not set", p.name)
+	}
+	return password, nil
+}
+
+func (p *EnvProvider) StorePassword(password string) error {
+	return &ReadOnlyPasswordError{Source: fmt.Sprintf("the %s environment variable", p.name)}
+}
+
+func (p *EnvProvider) DeletePassword() error {
+	return nil
+}
+
+func (p *EnvProvider) IsWritable() bool {
+	return false
+}
+
+func (p *EnvProvider) GetPasswordPath() string {
+	return ""
+}
+
+// A command that prints the password, such as a secrets manager client.
+// It is run once through the shell and its output is kept in memory, without the trailing newline.
+type CommandProvider struct {
+	command  string
+	lock     sync.Mutex
+	password string
+}
+
+func NewCommandProvider(command string) *CommandProvider {
+	return &CommandProvider{command: command}
+}
+
+func (p *CommandProvider) GetPassword() (string, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.password != "" {
+		return p.password, nil
+	}
+	if p.command == "" {
+		return "", errors.New("No password command is configured")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), PasswordCommandTimeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", p.command)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("The password command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	password := strings.TrimRight(stdout.String(), "\r\n")
+	if password == "" {
+		return "", errors.New("The password command did not print a password")
+	}
+	p.password = password
+	return password, nil
+}
+
+func (p *CommandProvider) StorePassword(password string) error {
+	return &ReadOnlyPasswordError{Source: "the password command"}
+}
+
+func (p *CommandProvider) DeletePassword() error {
+	return nil
+}
+
+func (p *CommandProvider) IsWritable() bool {
+	return false
+}
+
+func (p *CommandProvider) GetPasswordPath() string {
+	return ""
+}
+
+// A systemd service credential (LoadCredential= or LoadCredentialEncrypted=), which systemd decrypts into memory
+// and exposes to the service under $CREDENTIALS_DIRECTORY
+type SystemdCredentialProvider struct {
+	name string
+}
+
+func NewSystemdCredentialProvider(name string) *SystemdCredentialProvider {
+	if name == "" {
+		name = DefaultPasswordCredential
+	}
+	return &SystemdCredentialProvider{name: name}
+}
+
+func (p *SystemdCredentialProvider) GetPassword() (string, error) {
+	dir := os.Getenv(credentialsDirectoryEnv)
+	if dir == "" {
+		return "", fmt.Errorf("No systemd credentials were passed to the node; add LoadCredential=%s to its service", p.name)
+	}
+	password, err := ioutil.ReadFile(filepath.Join(dir, p.name))
+	if err != nil {
+		return "", fmt.Errorf("Could not read the %s systemd credential: %w", p.name, err)
+	}
+	return string(password), nil
+}
+
+func (p *SystemdCredentialProvider) StorePassword(password string) error {
+	return &ReadOnlyPasswordError{Source: fmt.Sprintf("the %s systemd credential", p.name)}
+}
+
+func (p *SystemdCredentialProvider) DeletePassword() error {
+	return nil
+}
+
+func (p *SystemdCredentialProvider) IsWritable() bool {
+	return false
+}
+
+func (p *SystemdCredentialProvider) GetPasswordPath() string {
+	return ""
+}
@@ -0,0 +1,76 @@
+package passwords
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProviders(t *testing.T) {
+	dir := t.TempDir()
+
+	// Files can be set, changed and deleted
+	pm := NewPasswordManager(filepath.Join(dir, "password"))
+	if pm.IsPasswordSet() || pm.IsReadOnly() {
+		t.Fatal("expected an unset, writable password file")
+	}
+	if err := pm.SetPassword("node-password-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.ChangePassword("node-password-2"); err != nil {
+		t.Fatal(err)
+	}
+	if password, err := pm.GetPassword(); err != nil || password != "node-password-2" {
+		t.Errorf("expected the changed password, got %s: %v", password, err)
+	}
+	if err := pm.DeletePassword(); err != nil || pm.IsPasswordSet() {
+		t.Errorf("expected the password to be deleted: %v", err)
+	}
+
+	// Environment variables are read-only
+	os.Setenv("TEST_NODE_PASSWORD", "env-node-password")
+	defer os.Unsetenv("TEST_NODE_PASSWORD")
+	pm = NewPasswordManagerWithProvider(NewEnvProvider("TEST_NODE_PASSWORD"))
+	if password, err := pm.GetPassword(); err != nil || password != "env-node-password" {
+		t.Errorf("expected the password from the environment, got %s: %v", password, err)
+	}
+	var readOnlyErr *ReadOnlyPasswordError
+	if err := pm.ChangePassword("new-node-password"); !pm.IsReadOnly() || !errors.As(err, &readOnlyErr) {
+		t.Errorf("expected the environment provider to be read-only, got %v", err)
+	}
+	if NewPasswordManagerWithProvider(NewEnvProvider("TEST_MISSING_PASSWORD")).IsPasswordSet() {
+		t.Error("expected a missing environment variable to leave the password unset")
+	}
+
+	// Commands are run once, without their trailing newline
+	counter := filepath.Join(dir, "runs")
+	command := NewCommandProvider("echo run >> " + counter + "; printf 'command-node-password\\n'")
+	for i := 0; i < 2; i++ {
+		if password, err := command.GetPassword(); err != nil || password != "command-node-password" {
+			t.Errorf("expected the password from the command, got %q: %v", password, err)
+		}
+	}
+	if runs, _ := ioutil.ReadFile(counter); string(runs) != "run\n" {
+		t.Errorf("expected the command to run once, got %q", string(runs))
+	}
+	if _, err := NewCommandProvider("echo denied >&2; exit 1").GetPassword(); err == nil {
+		t.Error("expected a failing command to return an error")
+	}
+
+	// systemd credentials are read from the credentials directory
+	credential := NewSystemdCredentialProvider("")
+	os.Unsetenv(credentialsDirectoryEnv)
+	if _, err := credential.GetPassword(); err == nil {
+		t.Error("expected an error without a credentials directory")
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, DefaultPasswordCredential), []byte("credential-node-password"), FileMode); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv(credentialsDirectoryEnv, dir)
+	defer os.Unsetenv(credentialsDirectoryEnv)
+	if password, err := credential.GetPassword(); err != nil || password != "credential-node-password" {
+		t.Errorf("expected the password from the credential, got %s: %v", password, err)
+	}
+}
@@ -62,15 +62,20 @@ func RequireNodePassword(c *cli.Context) error {
 	return nil
 }
 
+// Requires the node account's address to be known, from either the node wallet or a watch-only wallet.
+// Commands that sign fail when they ask a watch-only wallet for a key.
 func RequireNodeWallet(c *cli.Context) error {
 	if err := RequireNodePassword(c); err != nil {
+		if isNodeWalletWatchOnly(c) {
+			return nil
+		}
 		return err
 	}
 	nodeWalletInitialized, err := getNodeWalletInitialized(c)
 	if err != nil {
 		return err
 	}
-	if !nodeWalletInitialized {
+	if !nodeWalletInitialized && !isNodeWalletWatchOnly(c) {
 		return errors.New("The node wallet has not been initialized. Please run './stader-cli wallet init' and try again.")
 	}
 	return nil
@@ -147,6 +152,9 @@ func WaitNodePassword(c *cli.Context, verbose bool) error {
 }
 
 func WaitNodeWallet(c *cli.Context, verbose bool) error {
+	if isNodeWalletWatchOnly(c) {
+		return nil
+	}
 	if err := WaitNodePassword(c, verbose); err != nil {
 		return err
 	}
@@ -220,6 +228,15 @@ func getNodeWalletInitialized(c *cli.Context) (bool, error) {
 	return w.GetInitialized()
 }
 
+// Check if the node wallet only knows the node account's address
+func isNodeWalletWatchOnly(c *cli.Context) bool {
+	w, err := GetWallet(c)
+	if err != nil {
+		return false
+	}
+	return w.IsWatchOnly()
+}
+
 // Check if the node is registered
 func isNodeRegistered(c *cli.Context) (bool, error) {
 	w, err := GetWallet(c)
@@ -275,12 +292,12 @@ func checkExecutionClientStatus(ecMgr *ExecutionClientManager, cfg *config.Stade
 
 	// Check the EC status
 	mgrStatus := ecMgr.CheckStatus(cfg)
-	if ecMgr.primaryReady {
+	if ecMgr.pool.isReady(0) {
 		return true, nil, nil
 	}
 
 	// If the primary isn't synced but there's a fallback and it is, return true
-	if ecMgr.fallbackReady {
+	if ecMgr.pool.isFallbackReady() {
 		if mgrStatus.PrimaryClientStatus.Error != "" {
 			log.Printf("Primary execution client is unavailable (%s), using fallback execution client...\n", mgrStatus.PrimaryClientStatus.Error)
 		} else {
@@ -294,13 +311,15 @@ func checkExecutionClientStatus(ecMgr *ExecutionClientManager, cfg *config.Stade
 	// Is the primary working and syncing? If so, wait for it
 	if mgrStatus.PrimaryClientStatus.IsWorking && mgrStatus.PrimaryClientStatus.Error == "" {
 		log.Printf("Fallback execution client is not configured or unavailable, waiting for primary execution client to finish syncing (%.2f%%)\n", mgrStatus.PrimaryClientStatus.SyncProgress*100)
-		return false, ecMgr.primaryEc, nil
+		return false, ecMgr.clients[0], nil
 	}
 
-	// Is the fallback working and syncing? If so, wait for it
-	if mgrStatus.FallbackEnabled && mgrStatus.FallbackClientStatus.IsWorking && mgrStatus.FallbackClientStatus.Error == "" {
-		log.Printf("Primary execution client is unavailable (%s), waiting for the fallback execution client to finish syncing (%.2f%%)\n", mgrStatus.PrimaryClientStatus.Error, mgrStatus.FallbackClientStatus.SyncProgress*100)
-		return false, ecMgr.fallbackEc, nil
+	// Is a fallback working and syncing? If so, wait for it
+	for i, endpoint := range mgrStatus.Endpoints {
+		if i > 0 && endpoint.Status.IsWorking && endpoint.Status.Error == "" {
+			log.Printf("Primary execution client is unavailable (%s), waiting for the fallback execution client to finish syncing (%.2f%%)\n", mgrStatus.PrimaryClientStatus.Error, endpoint.Status.SyncProgress*100)
+			return false, ecMgr.clients[i], nil
+		}
 	}
 
 	// If neither client is working, report the errors
@@ -315,12 +334,12 @@ func checkBeaconClientStatus(bcMgr *BeaconClientManager) (bool, error) {
 
 	// Check the BC status
 	mgrStatus := bcMgr.CheckStatus()
-	if bcMgr.primaryReady {
+	if bcMgr.pool.isReady(0) {
 		return true, nil
 	}
 
 	// If the primary isn't synced but there's a fallback and it is, return true
-	if bcMgr.fallbackReady {
+	if bcMgr.pool.isFallbackReady() {
 		if mgrStatus.PrimaryClientStatus.Error != "" {
 			log.Printf("Primary consensus client is unavailable (%s), using fallback consensus client...\n", mgrStatus.PrimaryClientStatus.Error)
 		} else {
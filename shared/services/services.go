@@ -21,28 +21,41 @@ package services
 
 import (
 	"fmt"
+	"io/ioutil"
 	"math/big"
 	"os"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	stader_config "github.com/stader-labs/stader-node/stader-lib/stader-config"
 
 	"github.com/docker/docker/client"
 	"github.com/stader-labs/stader-node/stader-lib/stader"
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
 	"github.com/stader-labs/stader-node/stader-lib/utils/eth"
 	"github.com/urfave/cli"
 
 	"github.com/stader-labs/stader-node/shared/services/config"
+	"github.com/stader-labs/stader-node/shared/services/dryrun"
+	"github.com/stader-labs/stader-node/shared/services/gas/feehistory"
+	"github.com/stader-labs/stader-node/shared/services/keymanager"
 	"github.com/stader-labs/stader-node/shared/services/passwords"
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
 	"github.com/stader-labs/stader-node/shared/services/wallet"
+	kmkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/keymanager"
 	lhkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/lighthouse"
 
 	lokeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/lodestar"
 	nmkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/nimbus"
 	prkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/prysm"
 	tkkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/teku"
+	w3skeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/web3signer"
+	"github.com/stader-labs/stader-node/shared/services/web3signer"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	cfgtypes "github.com/stader-labs/stader-node/shared/types/config"
 	staderUtils "github.com/stader-labs/stader-node/shared/utils/stdr"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
 )
 
 // Config
@@ -93,6 +106,27 @@ func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
 	return getWallet(c, cfg, pm)
 }
 
+// Get a signer for one of the wallet's validator keys, which signs through the Web3Signer if one is configured.
+// The key is only loaded from the wallet when there is no Web3Signer.
+func GetValidatorSigner(c *cli.Context, pubkey stadertypes.ValidatorPubkey) (validator.Signer, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if web3SignerUrl := cfg.StaderNode.Web3SignerUrl.Value.(string); web3SignerUrl != "" {
+		return validator.NewRemoteSigner(web3signer.NewClient(web3SignerUrl), pubkey), nil
+	}
+	w, err := GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	validatorKey, err := w.GetValidatorKeyByPubkey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+	return validator.NewLocalSigner(validatorKey), nil
+}
+
 func GetEthClient(c *cli.Context) (*ExecutionClientManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -367,6 +401,19 @@ func GetPoolUtilsContract(c *cli.Context) (*stader.PoolUtilsContractManager, err
 	return stader.NewPoolUtils(ec, poolUtilsAddress)
 }
 
+func GetMultiCaller(c *cli.Context) (*stader.MultiCaller, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := getEthClient(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return stader.NewMultiCaller(ec, stader.Multicall3Address)
+}
+
 func GetPenaltyTrackerContract(c *cli.Context) (*stader.PenaltyTrackerContractManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -452,11 +499,25 @@ func getConfig(c *cli.Context) (*config.StaderConfig, error) {
 
 func getPasswordManager(cfg *config.StaderConfig) *passwords.PasswordManager {
 	initPasswordManager.Do(func() {
-		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.StaderNode.GetPasswordPath()))
+		passwordManager = passwords.NewPasswordManagerWithProvider(getPasswordProvider(cfg))
 	})
 	return passwordManager
 }
 
+// Get the provider the node password is kept by
+func getPasswordProvider(cfg *config.StaderConfig) passwords.PasswordProvider {
+	switch cfg.StaderNode.PasswordProvider.Value {
+	case cfgtypes.PasswordProvider_Env:
+		return passwords.NewEnvProvider(cfg.StaderNode.PasswordEnvVar.Value.(string))
+	case cfgtypes.PasswordProvider_Command:
+		return passwords.NewCommandProvider(cfg.StaderNode.PasswordCommand.Value.(string))
+	case cfgtypes.PasswordProvider_SystemdCredential:
+		return passwords.NewSystemdCredentialProvider(cfg.StaderNode.PasswordCredential.Value.(string))
+	default:
+		return passwords.NewFileProvider(os.ExpandEnv(cfg.StaderNode.GetPasswordPath()))
+	}
+}
+
 func getWallet(c *cli.Context, cfg *config.StaderConfig, pm *passwords.PasswordManager) (*wallet.Wallet, error) {
 	var err error
 	initNodeWallet.Do(func() {
@@ -469,9 +530,12 @@ func getWallet(c *cli.Context, cfg *config.StaderConfig, pm *passwords.PasswordM
 			maxFee = eth.GweiToWei(maxFeeFloat)
 		}
 
+		// Without a max fee, the Execution client's fee estimate brings its own priority fee
+		useFeeHistory := maxFee == nil && cfg.StaderNode.FeeEstimator.Value != cfgtypes.FeeEstimator_WebOracle
+
 		var maxPriorityFee *big.Int
 		maxPriorityFeeFloat := c.GlobalFloat64("maxPrioFee")
-		if maxPriorityFeeFloat == 0 {
+		if maxPriorityFeeFloat == 0 && !useFeeHistory {
 			maxPriorityFeeFloat = cfg.StaderNode.PriorityFee.Value.(float64)
 		}
 		if maxPriorityFeeFloat != 0 {
@@ -484,8 +548,62 @@ func getWallet(c *cli.Context, cfg *config.StaderConfig, pm *passwords.PasswordM
 		if err != nil {
 			return
 		}
+		if cfg.StaderNode.NodeSigner.Value == cfgtypes.NodeSigner_Clef {
+			var externalSigner *wallet.ExternalSigner
+			externalSigner, err = getExternalSigner(cfg)
+			if err != nil {
+				return
+			}
+			nodeWallet.SetExternalSigner(externalSigner)
+		}
+		if c.GlobalBool("dry-run") {
+			nodeWallet.SetTxInterceptor(func(from common.Address, tx *types.Transaction) error {
+				ec, err := getEthClient(c, cfg)
+				if err != nil {
+					return err
+				}
+				simulation, err := dryrun.Simulate(ec, from, tx)
+				if err != nil {
+					return fmt.Errorf("error simulating transaction: %w", err)
+				}
+				return &api.DryRunError{Simulation: simulation}
+			})
+		} else if c.GlobalBool("export-unsigned-tx") {
+			nodeWallet.SetTxInterceptor(func(from common.Address, tx *types.Transaction) error {
+				return &api.UnsignedTxError{UnsignedTx: txmanager.NewUnsignedTx(nodeWallet.GetChainID(), from, tx)}
+			})
+		}
+		if useFeeHistory {
+			nodeWallet.SetFeeEstimator(func(maxPriorityFee *big.Int) (*big.Int, *big.Int, error) {
+				ec, err := getEthClient(c, cfg)
+				if err != nil {
+					return nil, nil, err
+				}
+				suggestion, err := feehistory.GetGasPrices(ec)
+				if err != nil {
+					return nil, nil, err
+				}
+				maxFee, maxPriorityFee := suggestion.Fast.WithPriorityFee(maxPriorityFee)
+				return maxFee, maxPriorityFee, nil
+			})
+		}
 
-		// Keystores
+		// Keystores; a Web3Signer or a Validator client's key manager API takes the place of the Validator clients' keystore files
+		if web3SignerUrl := cfg.StaderNode.Web3SignerUrl.Value.(string); web3SignerUrl != "" {
+			web3SignerKeystore := w3skeystore.NewKeystore(web3signer.NewClient(web3SignerUrl), os.ExpandEnv(cfg.StaderNode.GetValidatorKeychainPath()))
+			nodeWallet.AddKeystore("web3signer", web3SignerKeystore)
+			return
+		}
+		if keymanagerUrl := cfg.StaderNode.KeymanagerApiUrl.Value.(string); keymanagerUrl != "" {
+			var keymanagerClient *keymanager.Client
+			keymanagerClient, err = getKeymanagerClient(cfg, keymanagerUrl)
+			if err != nil {
+				return
+			}
+			keymanagerKeystore := kmkeystore.NewKeystore(keymanagerClient, os.ExpandEnv(cfg.StaderNode.GetValidatorKeychainPath()))
+			nodeWallet.AddKeystore("keymanager", keymanagerKeystore)
+			return
+		}
 		lighthouseKeystore := lhkeystore.NewKeystore(os.ExpandEnv(cfg.StaderNode.GetValidatorKeychainPath()), pm)
 		nimbusKeystore := nmkeystore.NewKeystore(os.ExpandEnv(cfg.StaderNode.GetValidatorKeychainPath()), pm)
 		prysmKeystore := prkeystore.NewKeystore(os.ExpandEnv(cfg.StaderNode.GetValidatorKeychainPath()), pm)
@@ -500,6 +618,33 @@ func getWallet(c *cli.Context, cfg *config.StaderConfig, pm *passwords.PasswordM
 	return nodeWallet, err
 }
 
+func getKeymanagerClient(cfg *config.StaderConfig, url string) (*keymanager.Client, error) {
+	tokenPath := cfg.StaderNode.KeymanagerApiTokenPath.Value.(string)
+	if tokenPath == "" {
+		return nil, fmt.Errorf("A Keymanager API URL is configured, but no Keymanager API token file is. Please run 'stader-cli service config' and set it.")
+	}
+	token, err := ioutil.ReadFile(os.ExpandEnv(tokenPath))
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the Keymanager API token: %w", err)
+	}
+	return keymanager.NewClient(url, string(token)), nil
+}
+
+func getExternalSigner(cfg *config.StaderConfig) (*wallet.ExternalSigner, error) {
+	url := cfg.StaderNode.ExternalSignerUrl.Value.(string)
+	if url == "" {
+		return nil, fmt.Errorf("The node account signer is set to External, but no External Signer URL is configured. Please run 'stader-cli service config' and set it.")
+	}
+	var address common.Address
+	if addressString := cfg.StaderNode.ExternalSignerAddress.Value.(string); addressString != "" {
+		if !common.IsHexAddress(addressString) {
+			return nil, fmt.Errorf("The External Signer Address '%s' is not a valid address", addressString)
+		}
+		address = common.HexToAddress(addressString)
+	}
+	return wallet.NewExternalSigner(url, address)
+}
+
 func getEthClient(c *cli.Context, cfg *config.StaderConfig) (*ExecutionClientManager, error) {
 	var err error
 	initECManager.Do(func() {
@@ -511,7 +656,7 @@ func getEthClient(c *cli.Context, cfg *config.StaderConfig) (*ExecutionClientMan
 				ecManager.ignoreSyncCheck = true
 			}
 			if c.GlobalBool("force-fallbacks") {
-				ecManager.primaryReady = false
+				ecManager.pool.setReady(0, false)
 			}
 		}
 	})
@@ -529,7 +674,7 @@ func getBeaconClient(c *cli.Context, cfg *config.StaderConfig) (*BeaconClientMan
 				bcManager.ignoreSyncCheck = true
 			}
 			if c.GlobalBool("force-fallbacks") {
-				bcManager.primaryReady = false
+				bcManager.pool.setReady(0, false)
 			}
 		}
 	})
@@ -21,6 +21,7 @@ package stader
 
 import (
 	"bufio"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -49,6 +50,7 @@ import (
 	"github.com/mitchellh/go-homedir"
 
 	"github.com/stader-labs/stader-node/shared/services/config"
+	"github.com/stader-labs/stader-node/shared/types/api"
 	cfgtypes "github.com/stader-labs/stader-node/shared/types/config"
 	staderUtils "github.com/stader-labs/stader-node/shared/utils/stdr"
 )
@@ -116,6 +118,8 @@ type Client struct {
 	debugPrint         bool
 	ignoreSyncCheck    bool
 	forceFallbacks     bool
+	dryRun             bool
+	exportUnsignedTx   bool
 }
 
 // Create new Stader client from CLI context
@@ -126,11 +130,13 @@ func NewClientFromCtx(c *cli.Context) (*Client, error) {
 		c.GlobalFloat64("maxPrioFee"),
 		c.GlobalUint64("gasLimit"),
 		c.GlobalString("nonce"),
-		c.GlobalBool("debug"))
+		c.GlobalBool("debug"),
+		c.GlobalBool("dry-run"),
+		c.GlobalString("export-unsigned-tx") != "")
 }
 
 // Create new Stader client
-func NewClient(configPath string, daemonPath string, maxFee float64, maxPrioFee float64, gasLimit uint64, customNonce string, debug bool) (*Client, error) {
+func NewClient(configPath string, daemonPath string, maxFee float64, maxPrioFee float64, gasLimit uint64, customNonce string, debug bool, dryRun bool, exportUnsignedTx bool) (*Client, error) {
 
 	// Initialize SSH client if configured for SSH
 	var sshClient *ssh.Client
@@ -158,6 +164,8 @@ func NewClient(configPath string, daemonPath string, maxFee float64, maxPrioFee
 		debugPrint:         debug,
 		forceFallbacks:     false,
 		ignoreSyncCheck:    false,
+		dryRun:             dryRun,
+		exportUnsignedTx:   exportUnsignedTx,
 	}
 
 	return client, nil
@@ -1588,15 +1596,16 @@ func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
 		if err != nil {
 			return []byte{}, err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s api %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args)
+		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s api %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), c.getTxModeFlag(), args)
 	} else {
-		cmd = fmt.Sprintf("%s --settings %s %s %s %s %s api %s",
+		cmd = fmt.Sprintf("%s --settings %s %s %s %s %s %s api %s",
 			c.daemonPath,
 			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, SettingsFile)),
 			ignoreSyncCheckFlag,
 			forceFallbackECFlag,
 			c.getGasOpts(),
 			c.getCustomNonce(),
+			c.getTxModeFlag(),
 			args)
 	}
 
@@ -1621,13 +1630,13 @@ func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, othe
 		if err != nil {
 			return []byte{}, err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s api %s", envArgs, shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args)
+		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s %s api %s", envArgs, shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), c.getTxModeFlag(), args)
 	} else {
 		envArgs := ""
 		for key, value := range envVars {
 			envArgs += fmt.Sprintf("%s=%s ", key, shellescape.Quote(value))
 		}
-		cmd = fmt.Sprintf("%s %s --settings %s %s %s %s %s api %s",
+		cmd = fmt.Sprintf("%s %s --settings %s %s %s %s %s %s api %s",
 			envArgs,
 			c.daemonPath,
 			shellescape.Quote(fmt.Sprintf("%s/%s", c.configPath, SettingsFile)),
@@ -1635,6 +1644,7 @@ func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, othe
 			forceFallbackECFlag,
 			c.getGasOpts(),
 			c.getCustomNonce(),
+			c.getTxModeFlag(),
 			args)
 	}
 
@@ -1693,6 +1703,22 @@ func (c *Client) runApiCall(cmd string) ([]byte, error) {
 	c.maxPrioFee = c.originalMaxPrioFee
 	c.gasLimit = c.originalGasLimit
 
+	// Dry runs respond with the simulation of the first transaction instead of the command's response
+	if err == nil && c.dryRun {
+		var dryRunResponse api.DryRunResponse
+		if json.Unmarshal(output, &dryRunResponse) == nil && dryRunResponse.DryRun != nil {
+			return nil, &api.DryRunError{Simulation: *dryRunResponse.DryRun}
+		}
+	}
+
+	// Likewise, exports respond with the first transaction for offline signing
+	if err == nil && c.exportUnsignedTx {
+		var unsignedTxResponse api.UnsignedTxResponse
+		if json.Unmarshal(output, &unsignedTxResponse) == nil && unsignedTxResponse.UnsignedTx != nil {
+			return nil, &api.UnsignedTxError{UnsignedTx: *unsignedTxResponse.UnsignedTx}
+		}
+	}
+
 	return output, err
 }
 
@@ -1717,6 +1743,17 @@ func (c *Client) getGasOpts() string {
 	return opts
 }
 
+// Get the flag that makes the API simulate or export transactions instead of sending them
+func (c *Client) getTxModeFlag() string {
+	if c.dryRun {
+		return "--dry-run"
+	}
+	if c.exportUnsignedTx {
+		return "--export-unsigned-tx"
+	}
+	return ""
+}
+
 func (c *Client) getCustomNonce() string {
 	// Set the custom nonce
 	nonce := ""
@@ -0,0 +1,102 @@
+package stader
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stader-labs/stader-node/shared/services/config"
+	"github.com/stader-labs/stader-node/shared/utils/stdr"
+)
+
+// Creates a native mode config whose data folder is a temporary directory
+func newTestConfig(t *testing.T) *config.StaderConfig {
+	dataPath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dataPath, "validators"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.NewStaderConfig(dataPath, true)
+	cfg.StaderNode.DataPath.Value = dataPath
+	return cfg
+}
+
+// Reads the fee recipient from the file the way the validator client's environment would
+func readFeeRecipient(t *testing.T, cfg *config.StaderConfig) common.Address {
+	bytes, err := ioutil.ReadFile(cfg.StaderNode.GetFeeRecipientFilePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefix := fmt.Sprintf("%s=", config.FeeRecipientEnvVar)
+	contents := strings.TrimSpace(string(bytes))
+	if !strings.HasPrefix(contents, prefix) {
+		t.Fatalf("fee recipient file has unexpected contents '%s'", contents)
+	}
+	return common.HexToAddress(strings.TrimPrefix(contents, prefix))
+}
+
+func TestFeeRecipientFlow(t *testing.T) {
+	feeRecipientInfo := &stdr.FeeRecipientInfo{
+		SocializingPoolAddress: common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		FeeDistributorAddress:  common.HexToAddress("0x2222222222222222222222222222222222222222"),
+	}
+
+	testCases := []struct {
+		name                string
+		isInSocializingPool bool
+		updatable           bool
+		expected            common.Address
+	}{
+		{"socializing pool", true, true, feeRecipientInfo.SocializingPoolAddress},
+		{"joined socializing pool recently", true, false, feeRecipientInfo.FeeDistributorAddress},
+		{"fee distributor", false, true, feeRecipientInfo.FeeDistributorAddress},
+		{"left socializing pool recently", false, false, feeRecipientInfo.SocializingPoolAddress},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg := newTestConfig(t)
+			feeRecipientInfo.IsInSocializingPool = testCase.isInSocializingPool
+			correctFeeRecipient := stdr.GetCorrectFeeRecipient(feeRecipientInfo, testCase.updatable)
+			if correctFeeRecipient != testCase.expected {
+				t.Fatalf("expected fee recipient %s, got %s", testCase.expected.Hex(), correctFeeRecipient.Hex())
+			}
+
+			// The file doesn't exist yet
+			fileExists, _, err := CheckFeeRecipientFile(correctFeeRecipient, cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fileExists {
+				t.Fatal("expected fee recipient file not to exist yet")
+			}
+
+			// Write it and make sure it's now considered correct
+			if err := UpdateFeeRecipientFile(correctFeeRecipient, cfg); err != nil {
+				t.Fatal(err)
+			}
+			fileExists, correctAddress, err := CheckFeeRecipientFile(correctFeeRecipient, cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !fileExists || !correctAddress {
+				t.Fatalf("expected fee recipient file to exist and be correct, got exists=%t correct=%t", fileExists, correctAddress)
+			}
+			if feeRecipient := readFeeRecipient(t, cfg); feeRecipient != testCase.expected {
+				t.Errorf("expected the validator client to read fee recipient %s, got %s", testCase.expected.Hex(), feeRecipient.Hex())
+			}
+
+			// A change of fee recipient makes the file stale
+			_, correctAddress, err = CheckFeeRecipientFile(common.HexToAddress("0x3333333333333333333333333333333333333333"), cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if correctAddress {
+				t.Fatal("expected fee recipient file to be stale for a different address")
+			}
+		})
+	}
+}
@@ -24,12 +24,14 @@ import (
 	"fmt"
 	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	string_utils "github.com/stader-labs/stader-node/shared/utils/string-utils"
 	"github.com/stader-labs/stader-node/stader-lib/types"
 
 	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/shared/types/eth2"
 )
 
 // Get node status
@@ -207,8 +209,8 @@ func (c *Client) GetNodeDepositSdAllowance() (api.NodeDepositSdAllowanceResponse
 }
 
 // Check whether the node can make a deposit
-func (c *Client) CanNodeDeposit(amountWei *big.Int, numValidators *big.Int, reloadKeys bool) (api.CanNodeDepositResponse, error) {
-	responseBytes, err := c.callAPI(fmt.Sprintf("validator can-deposit %s %s %t", amountWei.String(), numValidators, reloadKeys))
+func (c *Client) CanNodeDeposit(amountWei *big.Int, numValidators *big.Int, reloadKeys bool, importedPubkeys []types.ValidatorPubkey) (api.CanNodeDepositResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("validator can-deposit %s %s %t", amountWei.String(), numValidators, reloadKeys), joinPubkeys(importedPubkeys))
 	if err != nil {
 		return api.CanNodeDepositResponse{}, fmt.Errorf("could not get can validator deposit status: %w", err)
 	}
@@ -252,6 +254,39 @@ func (c *Client) ExitValidator(validatorPubKey types.ValidatorPubkey) (api.ExitV
 	return response, nil
 }
 
+// Check whether the keys of exited validators can be removed, or find all the keys that can be
+func (c *Client) CanRemoveValidatorKeys(validatorPubKeys []types.ValidatorPubkey, allWithdrawn bool) (api.CanRemoveValidatorKeysResponse, error) {
+	responseBytes, err := c.callAPI("validator can-remove-keys", joinPubkeys(validatorPubKeys), fmt.Sprint(allWithdrawn))
+	if err != nil {
+		return api.CanRemoveValidatorKeysResponse{}, fmt.Errorf("could not get can-remove-keys status: %w", err)
+	}
+	var response api.CanRemoveValidatorKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanRemoveValidatorKeysResponse{}, fmt.Errorf("could not decode can-remove-keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanRemoveValidatorKeysResponse{}, fmt.Errorf("could not get can-remove-keys status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Export the slashing protection history, then remove the keys of exited validators
+func (c *Client) RemoveValidatorKeys(validatorPubKeys []types.ValidatorPubkey) (api.RemoveValidatorKeysResponse, error) {
+	responseBytes, err := c.callAPI("validator remove-keys", joinPubkeys(validatorPubKeys))
+	if err != nil {
+		return api.RemoveValidatorKeysResponse{}, fmt.Errorf("could not remove validator keys: %w", err)
+	}
+	var response api.RemoveValidatorKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RemoveValidatorKeysResponse{}, fmt.Errorf("could not decode remove-keys response: %w", err)
+	}
+	if response.Error != "" {
+		// Keep the slashing protection history of keys that may already be deleted from the Validator client
+		return response, fmt.Errorf("could not remove validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
 func (c *Client) GetContractsInfo() (api.ContractsInfoResponse, error) {
 	responseBytes, err := c.callAPI("node get-contracts-info")
 	if err != nil {
@@ -268,8 +303,8 @@ func (c *Client) GetContractsInfo() (api.ContractsInfoResponse, error) {
 }
 
 // Make a node deposit
-func (c *Client) NodeDeposit(amountWei *big.Int, numValidators *big.Int, reloadKeys bool) (api.NodeDepositResponse, error) {
-	responseBytes, err := c.callAPI(fmt.Sprintf("validator deposit %s %s %t", amountWei.String(), numValidators, reloadKeys))
+func (c *Client) NodeDeposit(amountWei *big.Int, numValidators *big.Int, reloadKeys bool, importedPubkeys []types.ValidatorPubkey) (api.NodeDepositResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("validator deposit %s %s %t", amountWei.String(), numValidators, reloadKeys), joinPubkeys(importedPubkeys))
 	if err != nil {
 		return api.NodeDepositResponse{}, fmt.Errorf("could not make validator deposit as er: %w", err)
 	}
@@ -283,6 +318,62 @@ func (c *Client) NodeDeposit(amountWei *big.Int, numValidators *big.Int, reloadK
 	return response, nil
 }
 
+// Import validator keystores generated outside of the node wallet
+func (c *Client) ImportKeystores(keystores []api.KeystoreImport, reloadKeys bool) (api.ImportKeystoresResponse, error) {
+	keystoresJson, err := json.Marshal(keystores)
+	if err != nil {
+		return api.ImportKeystoresResponse{}, fmt.Errorf("could not encode keystores: %w", err)
+	}
+	responseBytes, err := c.callAPI("validator import-keystores", string(keystoresJson), strconv.FormatBool(reloadKeys))
+	if err != nil {
+		return api.ImportKeystoresResponse{}, fmt.Errorf("could not import keystores: %w", err)
+	}
+	var response api.ImportKeystoresResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ImportKeystoresResponse{}, fmt.Errorf("could not decode import keystores response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ImportKeystoresResponse{}, fmt.Errorf("could not import keystores: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the deposit data of the node's validators in the staking-deposit-cli format
+func (c *Client) ExportDepositData() (api.ExportDepositDataResponse, error) {
+	responseBytes, err := c.callAPI("validator export-deposit-data")
+	if err != nil {
+		return api.ExportDepositDataResponse{}, fmt.Errorf("could not export deposit data: %w", err)
+	}
+	var response api.ExportDepositDataResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ExportDepositDataResponse{}, fmt.Errorf("could not decode export deposit data response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ExportDepositDataResponse{}, fmt.Errorf("could not export deposit data: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Verify deposit data in the staking-deposit-cli format
+func (c *Client) VerifyDepositData(deposits []eth2.LaunchpadDepositData) (api.VerifyDepositDataResponse, error) {
+	depositsJson, err := json.Marshal(deposits)
+	if err != nil {
+		return api.VerifyDepositDataResponse{}, fmt.Errorf("could not encode deposit data: %w", err)
+	}
+	responseBytes, err := c.callAPI("validator verify-deposit-data", string(depositsJson))
+	if err != nil {
+		return api.VerifyDepositDataResponse{}, fmt.Errorf("could not verify deposit data: %w", err)
+	}
+	var response api.VerifyDepositDataResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.VerifyDepositDataResponse{}, fmt.Errorf("could not decode verify deposit data response: %w", err)
+	}
+	if response.Error != "" {
+		return api.VerifyDepositDataResponse{}, fmt.Errorf("could not verify deposit data: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node can send tokens
 func (c *Client) CanNodeSend(amountWei *big.Int, token string) (api.CanNodeSendResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node can-send %s %s", amountWei.String(), token))
@@ -348,6 +439,40 @@ func (c *Client) SignMessage(message string) (api.NodeSignResponse, error) {
 	return response, nil
 }
 
+// Use the node private key to sign an EIP-712 typed data document
+func (c *Client) SignTypedData(typedDataJson []byte) (api.NodeSignResponse, error) {
+	responseBytes, err := c.callAPI("node sign-typed-data", string(typedDataJson))
+	if err != nil {
+		return api.NodeSignResponse{}, fmt.Errorf("could not sign typed data: %w", err)
+	}
+
+	var response api.NodeSignResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeSignResponse{}, fmt.Errorf("could not decode node sign response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeSignResponse{}, fmt.Errorf("could not sign typed data: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Recover the signer of a personal message or EIP-712 typed data document and check it against an address
+func (c *Client) VerifySignature(signatureType string, message string, signature string, expectedAddress common.Address) (api.VerifySignatureResponse, error) {
+	responseBytes, err := c.callAPI("node verify-signature", signatureType, message, signature, expectedAddress.Hex())
+	if err != nil {
+		return api.VerifySignatureResponse{}, fmt.Errorf("could not verify signature: %w", err)
+	}
+
+	var response api.VerifySignatureResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.VerifySignatureResponse{}, fmt.Errorf("could not decode verify signature response: %w", err)
+	}
+	if response.Error != "" {
+		return api.VerifySignatureResponse{}, fmt.Errorf("could not verify signature: %s", response.Error)
+	}
+	return response, nil
+}
+
 func (c *Client) CanSendElRewards() (api.CanSendElRewardsResponse, error) {
 	responseBytes, err := c.callAPI("node can-send-el-rewards")
 	if err != nil {
@@ -634,3 +759,28 @@ func (c *Client) SetRewardAddress(operatorRewardAddress common.Address) (api.Set
 	}
 	return response, nil
 }
+
+// Get snapshots of the node's collateral, validators and rewards between two dates or blocks
+func (c *Client) NodeReport(from, to, interval string) (api.NodeReportResponse, error) {
+	responseBytes, err := c.callAPI("node report", from, to, interval)
+	if err != nil {
+		return api.NodeReportResponse{}, fmt.Errorf("could not get node report: %w", err)
+	}
+	var response api.NodeReportResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeReportResponse{}, fmt.Errorf("could not decode node report response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeReportResponse{}, fmt.Errorf("could not get node report: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Join validator pubkeys into a comma-separated API argument
+func joinPubkeys(pubkeys []types.ValidatorPubkey) string {
+	pubkeyStrings := make([]string, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		pubkeyStrings[i] = pubkey.Hex()
+	}
+	return strings.Join(pubkeyStrings, ",")
+}
@@ -22,7 +22,14 @@ package stader
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
 
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/stader-labs/stader-node/shared/services/backup"
 	"github.com/stader-labs/stader-node/shared/types/api"
 )
 
@@ -59,3 +66,103 @@ func (c *Client) GetClientStatus() (api.ClientStatusResponse, error) {
 	}
 	return response, nil
 }
+
+// Estimates transaction fees from the Execution client's recent blocks
+func (c *Client) GetFeeEstimate() (api.FeeEstimateResponse, error) {
+	responseBytes, err := c.callAPI("service get-fee-estimate")
+	if err != nil {
+		return api.FeeEstimateResponse{}, fmt.Errorf("Could not get fee estimate: %w", err)
+	}
+	var response api.FeeEstimateResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.FeeEstimateResponse{}, fmt.Errorf("Could not decode fee estimate response: %w", err)
+	}
+	if response.Error != "" {
+		return api.FeeEstimateResponse{}, fmt.Errorf("Could not get fee estimate: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Writes an encrypted backup of the node to a file
+func (c *Client) ServiceBackup(passphrase string, outputPath string) (api.ServiceBackupResponse, error) {
+
+	// The backup is written by the daemon to the backups folder, which the CLI creates so it can move the file out again
+	backupDir, err := c.getBackupDir()
+	if err != nil {
+		return api.ServiceBackupResponse{}, err
+	}
+	responseBytes, err := c.callAPI("service backup", passphrase)
+	if err != nil {
+		return api.ServiceBackupResponse{}, fmt.Errorf("Could not back up the node: %w", err)
+	}
+	var response api.ServiceBackupResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ServiceBackupResponse{}, fmt.Errorf("Could not decode backup response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ServiceBackupResponse{}, fmt.Errorf("Could not back up the node: %s", response.Error)
+	}
+
+	// Copy it to the output path
+	backupPath := filepath.Join(backupDir, response.File)
+	defer os.Remove(backupPath)
+	data, err := ioutil.ReadFile(backupPath)
+	if err != nil {
+		return api.ServiceBackupResponse{}, fmt.Errorf("Could not read backup: %w", err)
+	}
+	if info, err := os.Stat(outputPath); err == nil && info.IsDir() {
+		outputPath = filepath.Join(outputPath, response.File)
+	}
+	if err := ioutil.WriteFile(outputPath, data, 0600); err != nil {
+		return api.ServiceBackupResponse{}, fmt.Errorf("Could not save backup: %w", err)
+	}
+	response.File = outputPath
+	return response, nil
+
+}
+
+// Verifies an encrypted backup file and restores the node from it
+func (c *Client) ServiceRestore(backupPath string, passphrase string, overwrite bool) (api.ServiceRestoreResponse, error) {
+
+	// Hand the backup to the daemon through the backups folder
+	backupDir, err := c.getBackupDir()
+	if err != nil {
+		return api.ServiceRestoreResponse{}, err
+	}
+	data, err := ioutil.ReadFile(backupPath)
+	if err != nil {
+		return api.ServiceRestoreResponse{}, fmt.Errorf("Could not read backup: %w", err)
+	}
+	file := filepath.Base(backupPath)
+	if err := ioutil.WriteFile(filepath.Join(backupDir, file), data, 0644); err != nil {
+		return api.ServiceRestoreResponse{}, fmt.Errorf("Could not copy backup: %w", err)
+	}
+	defer os.Remove(filepath.Join(backupDir, file))
+
+	responseBytes, err := c.callAPI("service restore", file, passphrase, strconv.FormatBool(overwrite))
+	if err != nil {
+		return api.ServiceRestoreResponse{}, fmt.Errorf("Could not restore the node: %w", err)
+	}
+	var response api.ServiceRestoreResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ServiceRestoreResponse{}, fmt.Errorf("Could not decode restore response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ServiceRestoreResponse{}, fmt.Errorf("Could not restore the node: %s", response.Error)
+	}
+	return response, nil
+
+}
+
+// Get the folder backups are exchanged with the daemon through, creating it if it doesn't exist
+func (c *Client) getBackupDir() (string, error) {
+	configPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return "", fmt.Errorf("error expanding config path: %w", err)
+	}
+	backupDir := filepath.Join(configPath, backup.BackupFolder)
+	if err := os.MkdirAll(backupDir, backup.ArchiveDirMode); err != nil {
+		return "", fmt.Errorf("error creating the backups folder: %w", err)
+	}
+	return backupDir, nil
+}
@@ -0,0 +1,109 @@
+package stader
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+// Get the transactions in the node's journal
+func (c *Client) TxList() (api.TxListResponse, error) {
+	responseBytes, err := c.callAPI("tx list")
+	if err != nil {
+		return api.TxListResponse{}, fmt.Errorf("could not get transaction list: %w", err)
+	}
+	var response api.TxListResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TxListResponse{}, fmt.Errorf("could not decode transaction list response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TxListResponse{}, fmt.Errorf("could not get transaction list: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the status of a transaction and its replacements
+func (c *Client) TxStatus(hash common.Hash) (api.TxStatusResponse, error) {
+	responseBytes, err := c.callAPI("tx status", hash.Hex())
+	if err != nil {
+		return api.TxStatusResponse{}, fmt.Errorf("could not get transaction status: %w", err)
+	}
+	var response api.TxStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TxStatusResponse{}, fmt.Errorf("could not decode transaction status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TxStatusResponse{}, fmt.Errorf("could not get transaction status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether a pending transaction can be sped up
+func (c *Client) CanTxSpeedUp(hash common.Hash) (api.CanReplaceTxResponse, error) {
+	return c.canReplaceTx("tx can-speed-up", hash)
+}
+
+// Resend a pending transaction with higher fees
+func (c *Client) TxSpeedUp(hash common.Hash) (api.ReplaceTxResponse, error) {
+	return c.replaceTx("tx speed-up", hash)
+}
+
+// Check whether a pending transaction can be cancelled
+func (c *Client) CanTxCancel(hash common.Hash) (api.CanReplaceTxResponse, error) {
+	return c.canReplaceTx("tx can-cancel", hash)
+}
+
+// Cancel a pending transaction
+func (c *Client) TxCancel(hash common.Hash) (api.ReplaceTxResponse, error) {
+	return c.replaceTx("tx cancel", hash)
+}
+
+func (c *Client) canReplaceTx(command string, hash common.Hash) (api.CanReplaceTxResponse, error) {
+	responseBytes, err := c.callAPI(command, hash.Hex())
+	if err != nil {
+		return api.CanReplaceTxResponse{}, fmt.Errorf("could not get %s status: %w", command, err)
+	}
+	var response api.CanReplaceTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanReplaceTxResponse{}, fmt.Errorf("could not decode %s response: %w", command, err)
+	}
+	if response.Error != "" {
+		return api.CanReplaceTxResponse{}, fmt.Errorf("could not get %s status: %s", command, response.Error)
+	}
+	return response, nil
+}
+
+func (c *Client) replaceTx(command string, hash common.Hash) (api.ReplaceTxResponse, error) {
+	responseBytes, err := c.callAPI(command, hash.Hex())
+	if err != nil {
+		return api.ReplaceTxResponse{}, fmt.Errorf("could not replace transaction: %w", err)
+	}
+	var response api.ReplaceTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ReplaceTxResponse{}, fmt.Errorf("could not decode %s response: %w", command, err)
+	}
+	if response.Error != "" {
+		return api.ReplaceTxResponse{}, fmt.Errorf("could not replace transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Send a transaction that was signed offline
+func (c *Client) TxBroadcast(rawTx []byte) (api.BroadcastTxResponse, error) {
+	responseBytes, err := c.callAPI("tx broadcast", hexutil.Encode(rawTx))
+	if err != nil {
+		return api.BroadcastTxResponse{}, fmt.Errorf("could not broadcast transaction: %w", err)
+	}
+	var response api.BroadcastTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.BroadcastTxResponse{}, fmt.Errorf("could not decode broadcast transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.BroadcastTxResponse{}, fmt.Errorf("could not broadcast transaction: %s", response.Error)
+	}
+	return response, nil
+}
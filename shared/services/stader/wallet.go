@@ -23,7 +23,9 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/alessio/shellescape"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
 	"github.com/stader-labs/stader-node/shared/types/api"
 )
 
@@ -59,6 +61,22 @@ func (c *Client) SetPassword(password string) (api.SetPasswordResponse, error) {
 	return response, nil
 }
 
+// Change wallet password
+func (c *Client) ChangePassword(currentPassword string, newPassword string) (api.ChangePasswordResponse, error) {
+	responseBytes, err := c.callAPI("wallet change-password", currentPassword, newPassword)
+	if err != nil {
+		return api.ChangePasswordResponse{}, fmt.Errorf("Could not change wallet password: %w", err)
+	}
+	var response api.ChangePasswordResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ChangePasswordResponse{}, fmt.Errorf("Could not decode change wallet password response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ChangePasswordResponse{}, fmt.Errorf("Could not change wallet password: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Initialize wallet
 func (c *Client) InitWallet(derivationPath string) (api.InitWalletResponse, error) {
 	responseBytes, err := c.callAPI("wallet init --derivation-path", derivationPath)
@@ -75,8 +93,24 @@ func (c *Client) InitWallet(derivationPath string) (api.InitWalletResponse, erro
 	return response, nil
 }
 
+// Initialize a watch-only wallet
+func (c *Client) WatchWallet(address common.Address) (api.WatchWalletResponse, error) {
+	responseBytes, err := c.callAPI("wallet watch", address.Hex())
+	if err != nil {
+		return api.WatchWalletResponse{}, fmt.Errorf("Could not initialize watch-only wallet: %w", err)
+	}
+	var response api.WatchWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.WatchWalletResponse{}, fmt.Errorf("Could not decode watch wallet response: %w", err)
+	}
+	if response.Error != "" {
+		return api.WatchWalletResponse{}, fmt.Errorf("Could not initialize watch-only wallet: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Recover wallet
-func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, derivationPath string, walletIndex uint) (api.RecoverWalletResponse, error) {
+func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, derivationPath string, walletIndex uint, keyStartIndex uint, keyEndIndex uint, validatorKeyPath string) (api.RecoverWalletResponse, error) {
 	command := "wallet recover "
 	if skipValidatorKeyRecovery {
 		command += "--skip-validator-key-recovery "
@@ -84,6 +118,7 @@ func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, d
 	if walletIndex != 0 {
 		command += fmt.Sprintf("--wallet-index %d ", walletIndex)
 	}
+	command += getKeyRecoveryFlags(keyStartIndex, keyEndIndex, validatorKeyPath)
 	command += "--derivation-path"
 
 	responseBytes, err := c.callAPI(command, derivationPath, mnemonic)
@@ -100,6 +135,41 @@ func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, d
 	return response, nil
 }
 
+// Recover the validator keys of an initialized wallet
+func (c *Client) RecoverValidatorKeys(keyStartIndex uint, keyEndIndex uint, validatorKeyPath string, testOnly bool) (api.RecoverValidatorKeysResponse, error) {
+	command := "wallet recover-validator-keys " + getKeyRecoveryFlags(keyStartIndex, keyEndIndex, validatorKeyPath)
+	if testOnly {
+		command += "--test-only"
+	}
+	responseBytes, err := c.callAPI(command)
+	if err != nil {
+		return api.RecoverValidatorKeysResponse{}, fmt.Errorf("Could not recover validator keys: %w", err)
+	}
+	var response api.RecoverValidatorKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RecoverValidatorKeysResponse{}, fmt.Errorf("Could not decode recover validator keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RecoverValidatorKeysResponse{}, fmt.Errorf("Could not recover validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the flags for the validator key search range and path
+func getKeyRecoveryFlags(keyStartIndex uint, keyEndIndex uint, validatorKeyPath string) string {
+	flags := ""
+	if keyStartIndex != 0 {
+		flags += fmt.Sprintf("--key-start-index %d ", keyStartIndex)
+	}
+	if keyEndIndex != 0 {
+		flags += fmt.Sprintf("--key-end-index %d ", keyEndIndex)
+	}
+	if validatorKeyPath != "" {
+		flags += fmt.Sprintf("--validator-key-path %s ", shellescape.Quote(validatorKeyPath))
+	}
+	return flags
+}
+
 // Search and recover wallet
 func (c *Client) SearchAndRecoverWallet(mnemonic string, address common.Address, skipValidatorKeyRecovery bool) (api.SearchAndRecoverWalletResponse, error) {
 	command := "wallet search-and-recover "
@@ -200,3 +270,39 @@ func (c *Client) ExportWallet() (api.ExportWalletResponse, error) {
 	}
 	return response, nil
 }
+
+// Check whether a mnemonic is the one the node wallet was created from
+func (c *Client) VerifyMnemonic(mnemonic string) (api.VerifyMnemonicResponse, error) {
+	responseBytes, err := c.callAPI("wallet verify-mnemonic", mnemonic)
+	if err != nil {
+		return api.VerifyMnemonicResponse{}, fmt.Errorf("Could not verify mnemonic: %w", err)
+	}
+	var response api.VerifyMnemonicResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.VerifyMnemonicResponse{}, fmt.Errorf("Could not decode verify mnemonic response: %w", err)
+	}
+	if response.Error != "" {
+		return api.VerifyMnemonicResponse{}, fmt.Errorf("Could not verify mnemonic: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Sign a transaction that was exported for offline signing
+func (c *Client) SignTx(unsignedTx txmanager.UnsignedTx) (api.SignTxResponse, error) {
+	unsignedTxJson, err := json.Marshal(unsignedTx)
+	if err != nil {
+		return api.SignTxResponse{}, fmt.Errorf("Could not encode unsigned transaction: %w", err)
+	}
+	responseBytes, err := c.callAPI("wallet sign-tx", string(unsignedTxJson))
+	if err != nil {
+		return api.SignTxResponse{}, fmt.Errorf("Could not sign transaction: %w", err)
+	}
+	var response api.SignTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SignTxResponse{}, fmt.Errorf("Could not decode sign transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SignTxResponse{}, fmt.Errorf("Could not sign transaction: %s", response.Error)
+	}
+	return response, nil
+}
@@ -33,11 +33,13 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 type MetricsCacheManager struct {
 	cfg          *config.StaderConfig
 	ec           stader.ExecutionClient
+	archiveEc    stader.ExecutionClient
 	bc           beacon.Client
 	log          *log.ColorLogger
 	Config       *config.StaderConfig
@@ -69,6 +71,15 @@ func NewMetricsCache(c *cli.Context, cfg *config.StaderConfig, ec stader.Executi
 		return nil, err
 	}
 
+	// Connect to the archive EC if there is one, so the cache can be built for historical slots
+	archiveEcUrl := cfg.StaderNode.ArchiveECUrl.Value.(string)
+	if archiveEcUrl != "" {
+		m.archiveEc, err = ethclient.Dial(archiveEcUrl)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to archive EC at %s: %w", archiveEcUrl, err)
+		}
+	}
+
 	return m, nil
 
 }
@@ -89,6 +100,11 @@ func (m *MetricsCacheManager) GetHeadStateForNode(nodeAddress common.Address) (*
 	return m.getNodeMetrics(nodeAddress, targetSlot)
 }
 
+// Get the state as of a historical slot. Slots older than the primary EC keeps state for require an archive EC.
+func (m *MetricsCacheManager) GetStateForSlot(nodeAddress common.Address, slotNumber uint64) (*MetricsCache, error) {
+	return m.getNodeMetrics(nodeAddress, slotNumber)
+}
+
 func (m *MetricsCacheManager) GetHeadSlot() (uint64, error) {
 	// Get the latest EL block
 	latestBlockHeader, err := m.ec.HeaderByNumber(context.Background(), nil)
@@ -105,7 +121,7 @@ func (m *MetricsCacheManager) GetHeadSlot() (uint64, error) {
 }
 
 func (m *MetricsCacheManager) getNodeMetrics(nodeAddress common.Address, slotNumber uint64) (*MetricsCache, error) {
-	state, err := CreateMetricsCache(m.c, m.cfg.StaderNode, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig, nodeAddress)
+	state, err := CreateMetricsCache(m.c, m.cfg.StaderNode, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig, nodeAddress, m.archiveEc)
 	if err != nil {
 		return nil, err
 	}
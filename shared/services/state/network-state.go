@@ -1,11 +1,11 @@
 package state
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"time"
 
-	"github.com/stader-labs/stader-node/shared/services"
 	"github.com/stader-labs/stader-node/shared/utils/stdr"
 	"github.com/stader-labs/stader-node/stader-lib/contracts"
 	"github.com/urfave/cli"
@@ -29,11 +29,15 @@ import (
 	"github.com/stader-labs/stader-node/stader-lib/tokens"
 	"github.com/stader-labs/stader-node/stader-lib/types"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 const SixDecimalRound = 6
 
+// The number of recent blocks that a non-archive EC keeps the state for
+const recentStateBlocks uint64 = 128
+
 type MetricDetails struct {
 	// Network details
 
@@ -127,6 +131,10 @@ type MetricsCache struct {
 	// Validator details
 	ValidatorDetails map[types.ValidatorPubkey]beacon.ValidatorStatus
 
+	// Per-validator penalties and withdraw vault balances, in wei
+	ValidatorPenalties    map[types.ValidatorPubkey]*big.Int
+	WithdrawVaultBalances map[types.ValidatorPubkey]*big.Int
+
 	// Internal fields
 	log *log.ColorLogger
 }
@@ -140,37 +148,68 @@ func CreateMetricsCache(
 	slotNumber uint64,
 	beaconConfig beacon.Eth2Config,
 	nodeAddress common.Address,
+	archiveEc stader.ExecutionClient,
 ) (*MetricsCache, error) {
-	prnAddress, err := services.GetPermissionlessNodeRegistryAddress(c)
+	// Get the execution block for the given slot
+	beaconBlock, exists, err := bc.GetBeaconBlock(fmt.Sprintf("%d", slotNumber))
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon block for slot %d: %w", slotNumber, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("slot %d did not have a Beacon block", slotNumber)
+	}
+
+	// Get the corresponding block on the EL
+	elBlockNumber := beaconBlock.ExecutionBlockNumber
+
+	// Every read is pinned to that block so the cache is a consistent snapshot
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(elBlockNumber),
+	}
+
+	// Regular ECs only keep recent state, so older blocks have to be read from the archive EC if there is one
+	latestBlockNumber, err := ec.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest EL block: %w", err)
+	}
+	if archiveEc != nil && latestBlockNumber > elBlockNumber+recentStateBlocks {
+		ec = archiveEc
+	}
+
+	// Look up the contracts as they were at that block too
+	sdcfg, err := stader.NewStaderConfig(ec, cfg.GetStaderConfigAddress())
+	if err != nil {
+		return nil, err
+	}
+	prnAddress, err := stader_config.GetPermissionlessNodeRegistryAddress(sdcfg, opts)
 	if err != nil {
 		return nil, err
 	}
-	ptAddress, err := services.GetPenaltyTrackerAddress(c)
+	ptAddress, err := stader_config.GetPenaltyTrackerAddress(sdcfg, opts)
 	if err != nil {
 		return nil, err
 	}
-	sdcAddress, err := services.GetSdCollateralAddress(c)
+	sdcAddress, err := stader_config.GetSdCollateralAddress(sdcfg, opts)
 	if err != nil {
 		return nil, err
 	}
-	ethxAddress, err := services.GetEthxTokenAddress(c)
+	ethxAddress, err := stader_config.GetEthxTokenAddress(sdcfg, opts)
 	if err != nil {
 		return nil, err
 	}
-	sdTokenAddress, err := services.GetSdTokenAddress(c)
+	sdTokenAddress, err := stader_config.GetSdTokenAddress(sdcfg, opts)
 	if err != nil {
 		return nil, err
 	}
-	stakePoolManagerAddress, err := services.GetStakePoolManagerAddress(c)
+	stakePoolManagerAddress, err := stader_config.GetStakePoolManagerAddress(sdcfg, opts)
 	if err != nil {
 		return nil, err
 	}
-	poolUtilsAddress, err := services.GetPoolUtilsAddress(c)
+	poolUtilsAddress, err := stader_config.GetPoolUtilsAddress(sdcfg, opts)
 	if err != nil {
 		return nil, err
 	}
-	staderConfigAddress := cfg.GetStaderConfigAddress()
-	socializingPoolAddress, err := services.GetSocializingPoolAddress(c)
+	socializingPoolAddress, err := stader_config.GetSocializingPoolContractAddress(sdcfg, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -203,27 +242,15 @@ func CreateMetricsCache(
 	if err != nil {
 		return nil, err
 	}
-	sdcfg, err := stader.NewStaderConfig(ec, staderConfigAddress)
-	if err != nil {
-		return nil, err
-	}
 	sp, err := stader.NewSocializingPool(ec, socializingPoolAddress)
 	if err != nil {
 		return nil, err
 	}
-
-	// Get the execution block for the given slot
-	beaconBlock, exists, err := bc.GetBeaconBlock(fmt.Sprintf("%d", slotNumber))
+	mc, err := stader.NewMultiCaller(ec, stader.Multicall3Address)
 	if err != nil {
-		return nil, fmt.Errorf("error getting Beacon block for slot %d: %w", slotNumber, err)
-	}
-	if !exists {
-		return nil, fmt.Errorf("slot %d did not have a Beacon block", slotNumber)
+		return nil, err
 	}
 
-	// Get the corresponding block on the EL
-	elBlockNumber := beaconBlock.ExecutionBlockNumber
-
 	// Create the state wrapper
 	state := &MetricsCache{
 		BeaconSlotNumber: slotNumber,
@@ -237,51 +264,51 @@ func CreateMetricsCache(
 	start := time.Now()
 
 	// fetch all validator pub keys
-	operatorId, err := node.GetOperatorId(prn, nodeAddress, nil)
+	operatorId, err := node.GetOperatorId(prn, nodeAddress, opts)
 	if err != nil {
 		return nil, err
 	}
-	operatorElRewardAddress, err := node.GetNodeElRewardAddress(prn, 1, operatorId, nil)
+	operatorElRewardAddress, err := node.GetNodeElRewardAddress(prn, 1, operatorId, opts)
 	if err != nil {
 		return nil, err
 	}
-	elRewardAddressBalance, err := tokens.GetEthBalance(prn.Client, operatorElRewardAddress, nil)
+	elRewardAddressBalance, err := tokens.GetEthBalance(prn.Client, operatorElRewardAddress, opts)
 	if err != nil {
 		return nil, err
 	}
-	operatorElRewards, err := pool_utils.CalculateRewardShare(putils, 1, elRewardAddressBalance, nil)
+	operatorElRewards, err := pool_utils.CalculateRewardShare(putils, 1, elRewardAddressBalance, opts)
 	if err != nil {
 		return nil, err
 	}
-	operatorSdColletaral, err := sd_collateral.GetOperatorSdBalance(sdc, nodeAddress, nil)
+	operatorSdColletaral, err := sd_collateral.GetOperatorSdBalance(sdc, nodeAddress, opts)
 	if err != nil {
 		return nil, err
 	}
-	totalValidatorKeys, err := node.GetTotalValidatorKeys(prn, operatorId, nil)
+	totalValidatorKeys, err := node.GetTotalValidatorKeys(prn, operatorId, opts)
 	if err != nil {
 		return nil, err
 	}
-	poolThreshold, err := sd_collateral.GetPoolThreshold(sdc, 1, nil)
+	poolThreshold, err := sd_collateral.GetPoolThreshold(sdc, 1, opts)
 	if err != nil {
 		return nil, err
 	}
-	operatorSdCollateralInEth, err := sd_collateral.ConvertSdToEth(sdc, operatorSdColletaral, nil)
+	operatorSdCollateralInEth, err := sd_collateral.ConvertSdToEth(sdc, operatorSdColletaral, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	operatorNonTerminalKeys, err := node.GetTotalNonTerminalValidatorKeys(prn, nodeAddress, totalValidatorKeys, nil)
+	operatorNonTerminalKeys, err := node.GetTotalNonTerminalValidatorKeys(prn, nodeAddress, totalValidatorKeys, opts)
 	if err != nil {
 		return nil, err
 	}
 	operatorEthCollateral := float64(4 * operatorNonTerminalKeys)
 
-	nextRewardCycleDetails, err := socializing_pool.GetRewardDetails(sp, nil)
+	nextRewardCycleDetails, err := socializing_pool.GetRewardDetails(sp, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	validatorInfoMap, pubkeys, err := stdr.GetAllValidatorsRegisteredWithOperator(prn, operatorId, nodeAddress, nil)
+	validatorInfoMap, pubkeys, err := stdr.GetAllValidatorsRegisteredWithOperator(prn, mc, operatorId, nodeAddress, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -306,12 +333,33 @@ func CreateMetricsCache(
 	if err != nil {
 		return nil, err
 	}
-	for _, pubKey := range pubkeys {
-		totalValidatorPenalty, err := penalty_tracker.GetCumulativeValidatorPenalty(pt, pubKey, nil)
-		if err != nil {
-			return nil, err
-		}
-		cumulativePenalty.Add(cumulativePenalty, totalValidatorPenalty)
+	validatorPenalties, err := penalty_tracker.GetCumulativeValidatorPenalties(pt, mc, pubkeys, opts)
+	if err != nil {
+		return nil, err
+	}
+	withdrawVaults := make([]common.Address, len(pubkeys))
+	for i, pubKey := range pubkeys {
+		withdrawVaults[i] = validatorInfoMap[pubKey].WithdrawVaultAddress
+	}
+	withdrawVaultBalances, err := tokens.GetEthBalances(mc, withdrawVaults, opts)
+	if err != nil {
+		return nil, err
+	}
+	withdrawVaultRewardShares, err := pool_utils.CalculateRewardShares(putils, mc, 1, withdrawVaultBalances, opts)
+	if err != nil {
+		return nil, err
+	}
+	rewardsThreshold, err := stader_config.GetRewardsThreshold(sdcfg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	state.ValidatorPenalties = make(map[types.ValidatorPubkey]*big.Int, len(pubkeys))
+	state.WithdrawVaultBalances = make(map[types.ValidatorPubkey]*big.Int, len(pubkeys))
+	for i, pubKey := range pubkeys {
+		cumulativePenalty.Add(cumulativePenalty, validatorPenalties[i])
+		state.ValidatorPenalties[pubKey] = validatorPenalties[i]
+		state.WithdrawVaultBalances[pubKey] = withdrawVaultBalances[i]
 
 		validatorContractInfo, ok := validatorInfoMap[pubKey]
 		if !ok {
@@ -365,23 +413,10 @@ func CreateMetricsCache(
 			activeValidators.Add(activeValidators, big.NewInt(1))
 		}
 
-		validatorWithdrawVault := validatorInfoMap[pubKey].WithdrawVaultAddress
-		withdrawVaultBalance, err := tokens.GetEthBalance(prn.Client, validatorWithdrawVault, nil)
-		if err != nil {
-			return nil, err
-		}
-		withdrawVaultRewardShares, err := pool_utils.CalculateRewardShare(putils, 1, withdrawVaultBalance, nil)
-		if err != nil {
-			return nil, err
-		}
-		rewardsThreshold, err := stader_config.GetRewardsThreshold(sdcfg, nil)
-		if err != nil {
-			return nil, err
-		}
-		if withdrawVaultRewardShares.OperatorShare.Cmp(rewardsThreshold) > 0 {
+		if withdrawVaultRewardShares[i].OperatorShare.Cmp(rewardsThreshold) > 0 {
 			continue
 		} else {
-			totalClRewards.Add(totalClRewards, withdrawVaultRewardShares.OperatorShare)
+			totalClRewards.Add(totalClRewards, withdrawVaultRewardShares[i].OperatorShare)
 		}
 	}
 
@@ -393,7 +428,7 @@ func CreateMetricsCache(
 
 	start = time.Now()
 
-	rewardClaimData, err := getClaimedAndUnclaimedSocializingSdAndEth(cfg, sp, nodeAddress)
+	rewardClaimData, err := getClaimedAndUnclaimedSocializingSdAndEth(cfg, sp, nodeAddress, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -406,45 +441,45 @@ func CreateMetricsCache(
 
 	metricsDetails := MetricDetails{}
 
-	sdPrice, err := sd_collateral.ConvertEthToSd(sdc, big.NewInt(1000000000000000000), nil)
+	sdPrice, err := sd_collateral.ConvertEthToSd(sdc, big.NewInt(1000000000000000000), opts)
 	if err != nil {
 		return nil, err
 	}
-	ethPrice, err := sd_collateral.ConvertSdToEth(sdc, big.NewInt(1000000000000000000), nil)
+	ethPrice, err := sd_collateral.ConvertSdToEth(sdc, big.NewInt(1000000000000000000), opts)
 	if err != nil {
 		return nil, err
 	}
-	totalOperators, err := node.GetNextOperatorId(prn, nil)
+	totalOperators, err := node.GetNextOperatorId(prn, opts)
 	if err != nil {
 		return nil, err
 	}
-	totalValidators, err := node.GetNextValidatorId(prn, nil)
+	totalValidators, err := node.GetNextValidatorId(prn, opts)
 	if err != nil {
 		return nil, err
 	}
-	totalActiveValidators, err := node.GetTotalActiveValidators(prn, nil)
+	totalActiveValidators, err := node.GetTotalActiveValidators(prn, opts)
 	if err != nil {
 		return nil, err
 	}
-	prnEthBalanceInWei, err := tokens.GetEthBalance(prn.Client, prnAddress, nil)
+	prnEthBalanceInWei, err := tokens.GetEthBalance(prn.Client, prnAddress, opts)
 	if err != nil {
 		return nil, err
 	}
 	prnEthBalance := eth.WeiToEth(prnEthBalanceInWei)
 	totalQueuedValidators := prnEthBalance / 3
-	totalSdCollateral, err := tokens.BalanceOf(sdt, sdcAddress, nil)
+	totalSdCollateral, err := tokens.BalanceOf(sdt, sdcAddress, opts)
 	if err != nil {
 		return nil, err
 	}
-	permissionlessPoolThreshold, err := sd_collateral.GetPoolThreshold(sdc, 1, nil)
+	permissionlessPoolThreshold, err := sd_collateral.GetPoolThreshold(sdc, 1, opts)
 	if err != nil {
 		return nil, err
 	}
-	ethxSupply, err := tokens.TotalSupply(ethx, nil)
+	ethxSupply, err := tokens.TotalSupply(ethx, opts)
 	if err != nil {
 		return nil, err
 	}
-	totalStakedAssets, err := stake_pool_manager.GetTotalAssets(spm, nil)
+	totalStakedAssets, err := stake_pool_manager.GetTotalAssets(spm, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -515,6 +550,7 @@ func getClaimedAndUnclaimedSocializingSdAndEth(
 	cfg *config.StaderNodeConfig,
 	sp *stader.SocializingPoolContractManager,
 	nodeAccount common.Address,
+	opts *bind.CallOpts,
 ) (struct {
 	unclaimedEth *big.Int
 	unclaimedSd  *big.Int
@@ -538,7 +574,7 @@ func getClaimedAndUnclaimedSocializingSdAndEth(
 	outstruct.claimedEth = big.NewInt(0)
 	outstruct.claimedSd = big.NewInt(0)
 
-	rewardDetails, err := socializing_pool.GetRewardDetails(sp, nil)
+	rewardDetails, err := socializing_pool.GetRewardDetails(sp, opts)
 	if err != nil {
 		return outstruct, err
 	}
@@ -555,7 +591,7 @@ func getClaimedAndUnclaimedSocializingSdAndEth(
 		if !exists {
 			continue
 		}
-		claimed, err := socializing_pool.HasClaimedRewards(sp, nodeAccount, big.NewInt(i), nil)
+		claimed, err := socializing_pool.HasClaimedRewards(sp, nodeAccount, big.NewInt(i), opts)
 		if err != nil {
 			return outstruct, err
 		}
@@ -0,0 +1,330 @@
+package txmanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stader-labs/stader-node/stader-lib/stader"
+)
+
+// The most transactions kept in the journal; the oldest finished ones are dropped first
+const maxJournalEntries = 1000
+
+type TxStatus string
+
+const (
+	TxStatus_Pending  TxStatus = "pending"
+	TxStatus_Mined    TxStatus = "mined"
+	TxStatus_Failed   TxStatus = "failed"
+	TxStatus_Replaced TxStatus = "replaced"
+	TxStatus_Dropped  TxStatus = "dropped"
+)
+
+type TxKind string
+
+const (
+	TxKind_Original TxKind = "original"
+	TxKind_SpeedUp  TxKind = "speed-up"
+	TxKind_Cancel   TxKind = "cancel"
+)
+
+// A transaction sent by the node
+type JournalEntry struct {
+	Hash           common.Hash     `json:"hash"`
+	Kind           TxKind          `json:"kind"`
+	Method         string          `json:"method"`
+	From           common.Address  `json:"from"`
+	To             *common.Address `json:"to"`
+	Nonce          uint64          `json:"nonce"`
+	Value          *big.Int        `json:"value"`
+	Data           hexutil.Bytes   `json:"data"`
+	GasLimit       uint64          `json:"gasLimit"`
+	MaxFee         *big.Int        `json:"maxFee"`
+	MaxPriorityFee *big.Int        `json:"maxPriorityFee"`
+	Replaces       *common.Hash    `json:"replaces,omitempty"`
+	ReplacedBy     *common.Hash    `json:"replacedBy,omitempty"`
+	Status         TxStatus        `json:"status"`
+	BlockNumber    uint64          `json:"blockNumber,omitempty"`
+	SubmittedAt    time.Time       `json:"submittedAt"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+}
+
+// A record of every transaction the node has sent, stored as JSON on disk.
+// The journal is re-read before every change and a lock file is held around each read and write, so the daemon and API processes can share it.
+type Journal struct {
+	path string
+	lock sync.Mutex
+}
+
+// Create a journal backed by the file at the given path; the file is created when the first transaction is recorded
+func NewJournal(path string) *Journal {
+	return &Journal{
+		path: path,
+	}
+}
+
+// Get every transaction in the journal, oldest first
+func (j *Journal) Entries() ([]JournalEntry, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	unlock, err := j.lockFile()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return j.load()
+}
+
+// Get a transaction from the journal
+func (j *Journal) Get(hash common.Hash) (JournalEntry, bool, error) {
+	entries, err := j.Entries()
+	if err != nil {
+		return JournalEntry{}, false, err
+	}
+	for _, entry := range entries {
+		if entry.Hash == hash {
+			return entry, true, nil
+		}
+	}
+	return JournalEntry{}, false, nil
+}
+
+// Get every transaction that shares a sender and nonce with the given one, including it, oldest first
+func (j *Journal) GetGroup(entry JournalEntry) ([]JournalEntry, error) {
+	entries, err := j.Entries()
+	if err != nil {
+		return nil, err
+	}
+	group := []JournalEntry{}
+	for _, other := range entries {
+		if other.From == entry.From && other.Nonce == entry.Nonce {
+			group = append(group, other)
+		}
+	}
+	return group, nil
+}
+
+// Record a transaction that was just sent
+func (j *Journal) Record(tx *types.Transaction) error {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("error getting sender of transaction %s: %w", tx.Hash().Hex(), err)
+	}
+	now := time.Now()
+	entry := JournalEntry{
+		Hash:           tx.Hash(),
+		Kind:           TxKind_Original,
+		Method:         GetMethodName(tx.Data()),
+		From:           from,
+		To:             tx.To(),
+		Nonce:          tx.Nonce(),
+		Value:          tx.Value(),
+		Data:           tx.Data(),
+		GasLimit:       tx.Gas(),
+		MaxFee:         tx.GasFeeCap(),
+		MaxPriorityFee: tx.GasTipCap(),
+		Status:         TxStatus_Pending,
+		SubmittedAt:    now,
+		UpdatedAt:      now,
+	}
+
+	return j.modify(func(entries []JournalEntry) ([]JournalEntry, error) {
+		for _, existing := range entries {
+			if existing.Hash == entry.Hash {
+				return entries, nil
+			}
+		}
+		return append(entries, entry), nil
+	})
+}
+
+// Mark a recorded transaction as a speed-up or cancellation of an earlier one
+func (j *Journal) LinkReplacement(original common.Hash, replacement common.Hash, kind TxKind) error {
+	return j.modify(func(entries []JournalEntry) ([]JournalEntry, error) {
+		var method string
+		found := false
+		for _, entry := range entries {
+			if entry.Hash == original {
+				method = entry.Method
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("transaction %s is not in the journal", original.Hex())
+		}
+		for i := range entries {
+			if entries[i].Hash == replacement {
+				entries[i].Kind = kind
+				entries[i].Replaces = &original
+				if kind == TxKind_SpeedUp {
+					entries[i].Method = method
+				}
+				return entries, nil
+			}
+		}
+		return nil, fmt.Errorf("transaction %s is not in the journal", replacement.Hex())
+	})
+}
+
+// Check on every pending transaction and record the ones that have been mined, replaced or dropped
+func (j *Journal) Update(ec stader.ExecutionClient) error {
+	return j.modify(func(entries []JournalEntry) ([]JournalEntry, error) {
+		now := time.Now()
+
+		// Look for receipts first, so replacements can be matched up with the transaction that was mined
+		for i := range entries {
+			if entries[i].Status != TxStatus_Pending {
+				continue
+			}
+			receipt, err := ec.TransactionReceipt(context.Background(), entries[i].Hash)
+			if errors.Is(err, ethereum.NotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error getting receipt for transaction %s: %w", entries[i].Hash.Hex(), err)
+			}
+			entries[i].Status = TxStatus_Mined
+			if receipt.Status == types.ReceiptStatusFailed {
+				entries[i].Status = TxStatus_Failed
+			}
+			entries[i].BlockNumber = receipt.BlockNumber.Uint64()
+			entries[i].UpdatedAt = now
+		}
+
+		// Anything left whose nonce has been used was replaced, either by one of ours or by something sent elsewhere
+		nonces := map[common.Address]uint64{}
+		for i := range entries {
+			if entries[i].Status != TxStatus_Pending {
+				continue
+			}
+			nonce, exists := nonces[entries[i].From]
+			if !exists {
+				var err error
+				nonce, err = ec.NonceAt(context.Background(), entries[i].From, nil)
+				if err != nil {
+					return nil, fmt.Errorf("error getting nonce for %s: %w", entries[i].From.Hex(), err)
+				}
+				nonces[entries[i].From] = nonce
+			}
+			if entries[i].Nonce >= nonce {
+				continue
+			}
+			entries[i].Status = TxStatus_Dropped
+			for _, other := range entries {
+				if other.From == entries[i].From && other.Nonce == entries[i].Nonce && (other.Status == TxStatus_Mined || other.Status == TxStatus_Failed) {
+					replacedBy := other.Hash
+					entries[i].Status = TxStatus_Replaced
+					entries[i].ReplacedBy = &replacedBy
+				}
+			}
+			entries[i].UpdatedAt = now
+		}
+
+		return entries, nil
+	})
+}
+
+// Load the journal, then save the changes made by the given function
+func (j *Journal) modify(change func(entries []JournalEntry) ([]JournalEntry, error)) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	unlock, err := j.lockFile()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := j.load()
+	if err != nil {
+		return err
+	}
+	entries, err = change(entries)
+	if err != nil {
+		return err
+	}
+	return j.save(prune(entries))
+}
+
+// Take the lock file shared with the other processes that use the journal, waiting for them to finish first
+func (j *Journal) lockFile() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return nil, fmt.Errorf("error creating transaction journal folder: %w", err)
+	}
+	lockPath := j.path + ".lock"
+	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening transaction journal lock %s: %w", lockPath, err)
+	}
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("error locking transaction journal %s: %w", lockPath, err)
+	}
+	return func() {
+		unlockFile(file)
+		file.Close()
+	}, nil
+}
+
+// Load the journal from disk
+func (j *Journal) load() ([]JournalEntry, error) {
+	bytes, err := ioutil.ReadFile(j.path)
+	if os.IsNotExist(err) {
+		return []JournalEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading transaction journal %s: %w", j.path, err)
+	}
+	entries := []JournalEntry{}
+	if err := json.Unmarshal(bytes, &entries); err != nil {
+		return nil, fmt.Errorf("error decoding transaction journal %s: %w", j.path, err)
+	}
+	return entries, nil
+}
+
+// Save the journal to disk, replacing the old file in one step so readers never see a partial write
+func (j *Journal) save(entries []JournalEntry) error {
+	bytes, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding transaction journal: %w", err)
+	}
+	tmpPath := j.path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, bytes, 0600); err != nil {
+		return fmt.Errorf("error writing transaction journal %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		return fmt.Errorf("error saving transaction journal %s: %w", j.path, err)
+	}
+	return nil
+}
+
+// Drop the oldest finished transactions once the journal is full
+func prune(entries []JournalEntry) []JournalEntry {
+	sort.SliceStable(entries, func(i, k int) bool {
+		return entries[i].SubmittedAt.Before(entries[k].SubmittedAt)
+	})
+	pruned := []JournalEntry{}
+	excess := len(entries) - maxJournalEntries
+	for _, entry := range entries {
+		if excess > 0 && entry.Status != TxStatus_Pending {
+			excess--
+			continue
+		}
+		pruned = append(pruned, entry)
+	}
+	return pruned
+}
@@ -0,0 +1,188 @@
+package txmanager
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stader-labs/stader-node/stader-lib/stader"
+)
+
+// An execution client that knows about a fixed set of receipts and nonces
+type fakeClient struct {
+	stader.ExecutionClient
+	receipts map[common.Hash]*types.Receipt
+	nonce    uint64
+	baseFee  *big.Int
+	tip      *big.Int
+}
+
+func (c *fakeClient) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	receipt, exists := c.receipts[hash]
+	if !exists {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func (c *fakeClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return c.nonce, nil
+}
+
+func (c *fakeClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return c.tip, nil
+}
+
+func (c *fakeClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: c.baseFee}, nil
+}
+
+func TestJournal(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(5)
+	signer := types.LatestSignerForChainID(chainID)
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	journal := NewJournal(filepath.Join(t.TempDir(), "tx-journal.json"))
+	sign := func(tx *types.Transaction) *types.Transaction {
+		signed, err := types.SignTx(tx, signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := journal.Record(signed); err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	// Two transactions; the first gets sped up, the second gets cancelled and is dropped
+	first := sign(types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 7, GasTipCap: big.NewInt(1e9), GasFeeCap: big.NewInt(20e9), Gas: 100000, To: &to, Value: big.NewInt(0)}))
+	second := sign(types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 8, GasTipCap: big.NewInt(1e9), GasFeeCap: big.NewInt(20e9), Gas: 100000, To: &to, Value: big.NewInt(0), Data: []byte{1, 2, 3, 4}}))
+
+	entry, exists, err := journal.Get(first.Hash())
+	if err != nil || !exists {
+		t.Fatalf("expected the first transaction to be in the journal: %v", err)
+	}
+	if entry.From != from || entry.Nonce != 7 || entry.Method != "transfer" || entry.Status != TxStatus_Pending {
+		t.Errorf("unexpected journal entry: %+v", entry)
+	}
+	if entry, _, _ := journal.Get(second.Hash()); entry.Method != "0x01020304" {
+		t.Errorf("expected an unknown method to be shown as its selector, got %s", entry.Method)
+	}
+
+	// The speed-up outbids the original and the network
+	client := &fakeClient{
+		receipts: map[common.Hash]*types.Receipt{},
+		nonce:    7,
+		baseFee:  big.NewInt(5e9),
+		tip:      big.NewInt(2e9),
+	}
+	maxFee, maxPriorityFee, err := GetReplacementFees(client, entry, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maxPriorityFee.Cmp(big.NewInt(2e9)) != 0 {
+		t.Errorf("expected the network's priority fee of 2 gwei, got %s", maxPriorityFee)
+	}
+	if maxFee.Cmp(big.NewInt(22.4e9)) != 0 {
+		t.Errorf("expected a max fee of 22.4 gwei, got %s", maxFee)
+	}
+	speedUp := sign(CreateReplacement(entry, TxKind_SpeedUp, chainID, maxFee, maxPriorityFee))
+	if err := journal.LinkReplacement(first.Hash(), speedUp.Hash(), TxKind_SpeedUp); err != nil {
+		t.Fatal(err)
+	}
+	secondEntry, _, _ := journal.Get(second.Hash())
+	cancel := sign(CreateReplacement(secondEntry, TxKind_Cancel, chainID, maxFee, maxPriorityFee))
+	if cancel.Gas() != cancelGasLimit || *cancel.To() != from || len(cancel.Data()) != 0 {
+		t.Errorf("expected the cancellation to be an empty transfer to the sender")
+	}
+	if err := journal.LinkReplacement(second.Hash(), cancel.Hash(), TxKind_Cancel); err != nil {
+		t.Fatal(err)
+	}
+
+	group, err := journal.GetGroup(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(group) != 2 || group[1].Kind != TxKind_SpeedUp || *group[1].Replaces != first.Hash() || group[1].Method != "transfer" {
+		t.Errorf("unexpected group for nonce 7: %+v", group)
+	}
+
+	// The speed-up is mined, and nonce 8 is used by something outside the journal
+	client.receipts[speedUp.Hash()] = &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(100)}
+	client.nonce = 9
+	if err := journal.Update(client); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[common.Hash]TxStatus{
+		first.Hash():   TxStatus_Replaced,
+		speedUp.Hash(): TxStatus_Mined,
+		second.Hash():  TxStatus_Dropped,
+		cancel.Hash():  TxStatus_Dropped,
+	}
+	for hash, status := range expected {
+		entry, _, _ := journal.Get(hash)
+		if entry.Status != status {
+			t.Errorf("expected %s to be %s, got %s", hash.Hex(), status, entry.Status)
+		}
+	}
+	if entry, _, _ := journal.Get(first.Hash()); entry.ReplacedBy == nil || *entry.ReplacedBy != speedUp.Hash() {
+		t.Errorf("expected the original to be replaced by the speed-up")
+	}
+}
+
+func TestJournalSharedBetweenProcesses(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainID := big.NewInt(5)
+	signer := types.LatestSignerForChainID(chainID)
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	// Separate journals on the same file only share the lock file, like the daemon and API processes do
+	path := filepath.Join(t.TempDir(), "tx-journal.json")
+	journals := []*Journal{NewJournal(path), NewJournal(path)}
+	const perJournal = 25
+	errs := make(chan error, len(journals)*perJournal)
+	var wg sync.WaitGroup
+	for i, journal := range journals {
+		wg.Add(1)
+		go func(i int, journal *Journal) {
+			defer wg.Done()
+			for n := 0; n < perJournal; n++ {
+				tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: uint64(i*perJournal + n), GasTipCap: big.NewInt(1e9), GasFeeCap: big.NewInt(20e9), Gas: 21000, To: &to, Value: big.NewInt(0)}), signer, key)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if err := journal.Record(tx); err != nil {
+					errs <- err
+				}
+			}
+		}(i, journal)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	entries, err := NewJournal(path).Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(journals)*perJournal {
+		t.Errorf("expected %d transactions in the journal, got %d", len(journals)*perJournal, len(entries))
+	}
+}
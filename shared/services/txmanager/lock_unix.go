@@ -0,0 +1,24 @@
+//go:build !windows
+// +build !windows
+
+package txmanager
+
+import (
+	"os"
+	"syscall"
+)
+
+// Block until no other process holds the lock on the file
+func lockFile(file *os.File) error {
+	for {
+		err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+// Release the lock on the file
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,94 @@
+package txmanager
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stader-labs/stader-node/stader-lib/contracts"
+)
+
+// The contracts the node sends transactions to
+var knownContracts = []string{
+	contracts.Erc20MetaData.ABI,
+	contracts.NodeElRewardVaultMetaData.ABI,
+	contracts.OperatorRewardsCollectorMetaData.ABI,
+	contracts.PermissionlessNodeRegistryMetaData.ABI,
+	contracts.SdCollateralMetaData.ABI,
+	contracts.SocializingPoolMetaData.ABI,
+	contracts.ValidatorWithdrawVaultMetaData.ABI,
+}
+
+// The contracts whose events the node's transactions can emit, in addition to the ones it sends transactions to
+var knownEventContracts = []string{
+	contracts.PermissionlessPoolMetaData.ABI,
+	contracts.PoolUtilsMetaData.ABI,
+	contracts.StakePoolManagerMetaData.ABI,
+	contracts.VaultFactoryMetaData.ABI,
+}
+
+var methods map[[4]byte]abi.Method
+var events map[common.Hash]abi.Event
+var loadAbis sync.Once
+
+// Get the name of the contract method a transaction calls, or "transfer" for plain ETH transfers
+func GetMethodName(data []byte) string {
+	if len(data) == 0 {
+		return "transfer"
+	}
+	if len(data) < 4 {
+		return hexutil.Encode(data)
+	}
+	if method := GetMethod(data); method != nil {
+		return method.RawName
+	}
+	return hexutil.Encode(data[:4])
+}
+
+// Get the contract method a transaction's data calls, or nil if it isn't a known method
+func GetMethod(data []byte) *abi.Method {
+	if len(data) < 4 {
+		return nil
+	}
+	loadAbis.Do(loadKnownAbis)
+
+	var selector [4]byte
+	copy(selector[:], data[:4])
+	if method, exists := methods[selector]; exists {
+		return &method
+	}
+	return nil
+}
+
+// Get the event a log's first topic identifies, or nil if it isn't a known event
+func GetEvent(topic common.Hash) *abi.Event {
+	loadAbis.Do(loadKnownAbis)
+	if event, exists := events[topic]; exists {
+		return &event
+	}
+	return nil
+}
+
+// Index the methods and events of the known contracts
+func loadKnownAbis() {
+	methods = map[[4]byte]abi.Method{}
+	events = map[common.Hash]abi.Event{}
+	for i, contractAbi := range append(append([]string{}, knownContracts...), knownEventContracts...) {
+		parsed, err := abi.JSON(strings.NewReader(contractAbi))
+		if err != nil {
+			continue
+		}
+		if i < len(knownContracts) {
+			for _, method := range parsed.Methods {
+				var selector [4]byte
+				copy(selector[:], method.ID)
+				methods[selector] = method
+			}
+		}
+		for _, event := range parsed.Events {
+			events[event.ID] = event
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package txmanager
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stader-labs/stader-node/stader-lib/stader"
+)
+
+// Execution clients only accept a replacement if both of its fees are at least 10% higher than the original's.
+// A little more is added so the replacement isn't rejected because of rounding.
+const ReplacementFeeBumpPercent int64 = 12
+
+// The gas limit of a plain ETH transfer, used for cancellations
+const cancelGasLimit uint64 = 21000
+
+// Get the fees for a replacement of a pending transaction. They're the highest of the bumped fees of the original,
+// the current network fees, and the fees the user asked for (which may be nil).
+func GetReplacementFees(ec stader.ExecutionClient, entry JournalEntry, maxFee *big.Int, maxPriorityFee *big.Int) (*big.Int, *big.Int, error) {
+
+	// Bump the original's fees
+	newMaxFee := bumpFee(entry.MaxFee)
+	newMaxPriorityFee := bumpFee(entry.MaxPriorityFee)
+
+	// Keep up with the network if fees have gone up since the original was sent
+	suggestedPriorityFee, err := ec.SuggestGasTipCap(context.Background())
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting suggested priority fee: %w", err)
+	}
+	header, err := ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting latest block: %w", err)
+	}
+	newMaxPriorityFee = maxBig(newMaxPriorityFee, suggestedPriorityFee, maxPriorityFee)
+	if header.BaseFee != nil {
+		suggestedMaxFee := new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), newMaxPriorityFee)
+		newMaxFee = maxBig(newMaxFee, suggestedMaxFee, maxFee)
+	} else {
+		newMaxFee = maxBig(newMaxFee, newMaxPriorityFee, maxFee)
+	}
+
+	return newMaxFee, newMaxPriorityFee, nil
+
+}
+
+// Create an unsigned replacement for a pending transaction. Speed-ups resend the same call; cancellations send
+// nothing to the sender's own address so the nonce is used up.
+func CreateReplacement(entry JournalEntry, kind TxKind, chainID *big.Int, maxFee *big.Int, maxPriorityFee *big.Int) *types.Transaction {
+	if kind == TxKind_Cancel {
+		to := entry.From
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     entry.Nonce,
+			GasTipCap: maxPriorityFee,
+			GasFeeCap: maxFee,
+			Gas:       cancelGasLimit,
+			To:        &to,
+			Value:     big.NewInt(0),
+		})
+	}
+
+	value := entry.Value
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     entry.Nonce,
+		GasTipCap: maxPriorityFee,
+		GasFeeCap: maxFee,
+		Gas:       entry.GasLimit,
+		To:        entry.To,
+		Value:     value,
+		Data:      entry.Data,
+	})
+}
+
+// Raise a fee by the replacement bump, rounding up
+func bumpFee(fee *big.Int) *big.Int {
+	if fee == nil {
+		return big.NewInt(0)
+	}
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+ReplacementFeeBumpPercent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// Get the largest of the given values, ignoring nils
+func maxBig(values ...*big.Int) *big.Int {
+	largest := big.NewInt(0)
+	for _, value := range values {
+		if value != nil && value.Cmp(largest) > 0 {
+			largest = value
+		}
+	}
+	return new(big.Int).Set(largest)
+}
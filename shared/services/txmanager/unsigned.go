@@ -0,0 +1,138 @@
+package txmanager
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stader-labs/stader-node/stader-lib/utils/eth"
+)
+
+// The version of the unsigned transaction format
+const UnsignedTxVersion = 1
+
+// A portable, unsigned transaction for the node account, exported so it can be signed on another machine
+type UnsignedTx struct {
+	Version        int             `json:"version"`
+	ChainID        *hexutil.Big    `json:"chainId"`
+	From           common.Address  `json:"from"`
+	To             *common.Address `json:"to"`
+	Nonce          hexutil.Uint64  `json:"nonce"`
+	Value          *hexutil.Big    `json:"value"`
+	Data           hexutil.Bytes   `json:"data"`
+	GasLimit       hexutil.Uint64  `json:"gasLimit"`
+	MaxFee         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFee *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Description    string          `json:"description"`
+}
+
+// A transaction signed from an UnsignedTx, ready to be broadcast
+type SignedTx struct {
+	UnsignedTx UnsignedTx    `json:"unsignedTx"`
+	Hash       common.Hash   `json:"hash"`
+	RawTx      hexutil.Bytes `json:"rawTx"`
+}
+
+// Export a transaction built by a node account transactor
+func NewUnsignedTx(chainID *big.Int, from common.Address, tx *types.Transaction) UnsignedTx {
+	return UnsignedTx{
+		Version:        UnsignedTxVersion,
+		ChainID:        (*hexutil.Big)(chainID),
+		From:           from,
+		To:             tx.To(),
+		Nonce:          hexutil.Uint64(tx.Nonce()),
+		Value:          (*hexutil.Big)(tx.Value()),
+		Data:           tx.Data(),
+		GasLimit:       hexutil.Uint64(tx.Gas()),
+		MaxFee:         (*hexutil.Big)(tx.GasFeeCap()),
+		MaxPriorityFee: (*hexutil.Big)(tx.GasTipCap()),
+		Description:    DescribeTransaction(tx.To(), tx.Value(), tx.Data()),
+	}
+}
+
+// Rebuild the transaction to sign
+func (u UnsignedTx) Transaction() (*types.Transaction, error) {
+	if u.Version != UnsignedTxVersion {
+		return nil, fmt.Errorf("unsupported unsigned transaction version %d", u.Version)
+	}
+	if u.ChainID == nil || u.Value == nil || u.MaxFee == nil || u.MaxPriorityFee == nil {
+		return nil, fmt.Errorf("the unsigned transaction is missing its chain ID, value or fees")
+	}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   u.ChainID.ToInt(),
+		Nonce:     uint64(u.Nonce),
+		GasTipCap: u.MaxPriorityFee.ToInt(),
+		GasFeeCap: u.MaxFee.ToInt(),
+		Gas:       uint64(u.GasLimit),
+		To:        u.To,
+		Value:     u.Value.ToInt(),
+		Data:      u.Data,
+	}), nil
+}
+
+// Describe what a transaction does, decoding the call if it's to a known contract method
+func DescribeTransaction(to *common.Address, value *big.Int, data []byte) string {
+	target := "a new contract"
+	if to != nil {
+		target = to.Hex()
+	}
+	if len(data) == 0 {
+		return fmt.Sprintf("Send %.6f ETH to %s", eth.WeiToEth(value), target)
+	}
+
+	call := hexutil.Encode(data[:4])
+	if method := GetMethod(data); method != nil {
+		call = method.RawName + "(...)"
+		if values, err := method.Inputs.Unpack(data[4:]); err == nil {
+			arguments := make([]string, len(values))
+			for i, value := range values {
+				arguments[i] = fmt.Sprintf("%s=%s", method.Inputs[i].Name, FormatValue(value))
+			}
+			call = fmt.Sprintf("%s(%s)", method.RawName, strings.Join(arguments, ", "))
+		}
+	}
+	description := fmt.Sprintf("Call %s on %s", call, target)
+	if value != nil && value.Sign() > 0 {
+		description += fmt.Sprintf(", sending %.6f ETH", eth.WeiToEth(value))
+	}
+	return description
+}
+
+// Format a decoded ABI value for display
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	}
+
+	// Fixed-size byte arrays and lists
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Array:
+		if reflected.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, reflected.Len())
+			reflect.Copy(reflect.ValueOf(bytes), reflected)
+			return hexutil.Encode(bytes)
+		}
+		fallthrough
+	case reflect.Slice:
+		elements := make([]string, reflected.Len())
+		for i := range elements {
+			elements[i] = FormatValue(reflected.Index(i).Interface())
+		}
+		return fmt.Sprintf("[%s]", strings.Join(elements, ", "))
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,61 @@
+package txmanager
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stader-labs/stader-node/stader-lib/contracts"
+)
+
+func TestUnsignedTx(t *testing.T) {
+	erc20Abi, err := abi.JSON(strings.NewReader(contracts.Erc20MetaData.ABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	spender := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	token := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	data, err := erc20Abi.Pack("approve", spender, big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(5), Nonce: 7, GasTipCap: big.NewInt(1e9), GasFeeCap: big.NewInt(30e9), Gas: 75000, To: &token, Value: big.NewInt(0), Data: data})
+
+	// The envelope survives a round trip through JSON and rebuilds the same transaction
+	unsignedTx := NewUnsignedTx(big.NewInt(5), from, tx)
+	expectedDescription := "Call approve(_spender=" + spender.Hex() + ", _value=100) on " + token.Hex()
+	if unsignedTx.Description != expectedDescription {
+		t.Errorf("unexpected description %q", unsignedTx.Description)
+	}
+	bytes, err := json.Marshal(unsignedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parsed UnsignedTx
+	if err := json.Unmarshal(bytes, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	rebuilt, err := parsed.Transaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rebuilt.Hash() != tx.Hash() {
+		t.Errorf("rebuilt transaction %s doesn't match the original %s", rebuilt.Hash().Hex(), tx.Hash().Hex())
+	}
+
+	// Unknown versions are rejected
+	parsed.Version = UnsignedTxVersion + 1
+	if _, err := parsed.Transaction(); err == nil {
+		t.Error("expected an error for an unsupported version")
+	}
+
+	// Plain transfers
+	if description := DescribeTransaction(&spender, big.NewInt(2e18), nil); description != "Send 2.000000 ETH to "+spender.Hex() {
+		t.Errorf("unexpected transfer description %q", description)
+	}
+}
@@ -0,0 +1,166 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// How long to wait for the signer; Clef may be waiting for someone to approve the request
+const externalSignerTimeout = 5 * time.Minute
+
+// The node account's key is held by an external signer and can't be read
+var ErrExternalSigner = errors.New("The node account's key is held by an external signer")
+
+// A node account signer that holds the key in a separate process, reached over Clef's external API
+type ExternalSigner struct {
+	url     string
+	address common.Address
+	client  *rpc.Client
+}
+
+// The transaction arguments of account_signTransaction
+type externalSignerTxArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Value                hexutil.Big     `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Input                hexutil.Bytes   `json:"input"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+// The result of account_signTransaction
+type externalSignerTxResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// Connect to an external signer. If address is the zero address, the signer must hold exactly one account.
+func NewExternalSigner(url string, address common.Address) (*ExternalSigner, error) {
+
+	client, err := rpc.Dial(url)
+	if err != nil {
+		return nil, fmt.Errorf("Could not connect to the external signer at %s: %w", url, err)
+	}
+	signer := &ExternalSigner{
+		url:     url,
+		address: address,
+		client:  client,
+	}
+
+	// Find the node account
+	if address == (common.Address{}) {
+		var addresses []common.Address
+		if err := signer.call(&addresses, "account_list"); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("Could not list the external signer's accounts: %w", err)
+		}
+		if len(addresses) != 1 {
+			client.Close()
+			return nil, fmt.Errorf("The external signer holds %d accounts; set the External Signer Address to choose the node account", len(addresses))
+		}
+		signer.address = addresses[0]
+	}
+
+	return signer, nil
+
+}
+
+// Get the address of the node account
+func (s *ExternalSigner) Address() common.Address {
+	return s.address
+}
+
+// Get the signer's URL
+func (s *ExternalSigner) URL() string {
+	return s.url
+}
+
+// Have the signer sign a transaction for the node account
+func (s *ExternalSigner) SignTransaction(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+
+	// Request the signature
+	args := externalSignerTxArgs{
+		From:                 s.address,
+		To:                   tx.To(),
+		Gas:                  hexutil.Uint64(tx.Gas()),
+		MaxFeePerGas:         (*hexutil.Big)(tx.GasFeeCap()),
+		MaxPriorityFeePerGas: (*hexutil.Big)(tx.GasTipCap()),
+		Value:                hexutil.Big(*tx.Value()),
+		Nonce:                hexutil.Uint64(tx.Nonce()),
+		Input:                tx.Data(),
+		ChainID:              (*hexutil.Big)(chainID),
+	}
+	var result externalSignerTxResult
+	if err := s.call(&result, "account_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("The external signer did not sign the transaction: %w", err)
+	}
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(result.Raw); err != nil {
+		return nil, fmt.Errorf("Could not decode the transaction signed by the external signer: %w", err)
+	}
+
+	// Make sure the signer signed what was asked, with the node account
+	signer := types.NewLondonSigner(chainID)
+	if signer.Hash(signedTx) != signer.Hash(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     tx.Nonce(),
+		GasTipCap: tx.GasTipCap(),
+		GasFeeCap: tx.GasFeeCap(),
+		Gas:       tx.Gas(),
+		To:        tx.To(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	})) {
+		return nil, errors.New("The external signer signed a different transaction than the one requested")
+	}
+	sender, err := types.Sender(signer, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("Could not recover the sender of the transaction signed by the external signer: %w", err)
+	}
+	if sender != s.address {
+		return nil, fmt.Errorf("The external signer signed with %s instead of the node account %s", sender.Hex(), s.address.Hex())
+	}
+	return signedTx, nil
+
+}
+
+// Have the signer sign a message for the node account, with the Ethereum signed message prefix
+func (s *ExternalSigner) SignText(message []byte) ([]byte, error) {
+	var signature hexutil.Bytes
+	if err := s.call(&signature, "account_signData", "text/plain", s.address, hexutil.Bytes(message)); err != nil {
+		return nil, fmt.Errorf("The external signer did not sign the message: %w", err)
+	}
+	return signature, nil
+}
+
+// Have the signer sign an EIP-712 typed data document for the node account
+func (s *ExternalSigner) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	var signature hexutil.Bytes
+	if err := s.call(&signature, "account_signTypedData", s.address, typedData); err != nil {
+		return nil, fmt.Errorf("The external signer did not sign the typed data: %w", err)
+	}
+	return signature, nil
+}
+
+// Close the connection to the signer
+func (s *ExternalSigner) Close() {
+	s.client.Close()
+}
+
+// Call a method on the signer
+func (s *ExternalSigner) call(result interface{}, method string, args ...interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), externalSignerTimeout)
+	defer cancel()
+	return s.client.CallContext(ctx, result, method, args...)
+}
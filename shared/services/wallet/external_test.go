@@ -0,0 +1,147 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/stader-labs/stader-node/shared/services/passwords"
+)
+
+// A Clef-style signer that signs everything with one key
+type stubSigner struct {
+	key     *ecdsa.PrivateKey
+	chainID *big.Int
+	tamper  bool
+}
+
+func (s *stubSigner) List() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(s.key.PublicKey)}
+}
+
+func (s *stubSigner) SignTransaction(args externalSignerTxArgs, methodSelector *string) (*externalSignerTxResult, error) {
+	if args.ChainID == nil || args.ChainID.ToInt().Cmp(s.chainID) != 0 {
+		return nil, errors.New("wrong chain ID")
+	}
+	nonce := uint64(args.Nonce)
+	if s.tamper {
+		nonce++
+	}
+	tx, err := types.SignNewTx(s.key, types.NewLondonSigner(s.chainID), &types.DynamicFeeTx{
+		ChainID:   s.chainID,
+		Nonce:     nonce,
+		GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+		GasFeeCap: args.MaxFeePerGas.ToInt(),
+		Gas:       uint64(args.Gas),
+		To:        args.To,
+		Value:     args.Value.ToInt(),
+		Data:      args.Input,
+	})
+	if err != nil {
+		return nil, err
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &externalSignerTxResult{Raw: raw}, nil
+}
+
+func (s *stubSigner) SignData(contentType string, address common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	if contentType != "text/plain" {
+		return nil, errors.New("unsupported content type")
+	}
+	signature, err := crypto.Sign(accounts.TextHash(data), s.key)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+func TestExternalSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	stub := &stubSigner{key: key, chainID: big.NewInt(5)}
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", stub); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	// The node account is the signer's only account
+	signer, err := NewExternalSigner(httpServer.URL, common.Address{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer signer.Close()
+	dir := t.TempDir()
+	w := openTestWallet(t, dir, passwords.NewPasswordManager(filepath.Join(dir, "password")))
+	w.SetExternalSigner(signer)
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Address != address {
+		t.Errorf("expected node account %s, got %s", address.Hex(), account.Address.Hex())
+	}
+	if _, err := w.GetNodePrivateKeyBytes(); !errors.Is(err, ErrExternalSigner) {
+		t.Errorf("expected the private key to be unavailable, got %v", err)
+	}
+
+	// Transactions are signed by the signer
+	transactor, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(5), Nonce: 3, GasTipCap: big.NewInt(1e9), GasFeeCap: big.NewInt(30e9), Gas: 21000, To: &to, Value: big.NewInt(1)})
+	signedTx, err := transactor.Signer(transactor.From, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender, err := types.Sender(types.NewLondonSigner(big.NewInt(5)), signedTx); err != nil || sender != address {
+		t.Errorf("unexpected sender %s (%v)", sender.Hex(), err)
+	}
+	txBytes, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Sign(txBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	// A signer that changes the transaction is rejected
+	stub.tamper = true
+	if _, err := transactor.Signer(transactor.From, tx); err == nil {
+		t.Error("expected a tampered transaction to be rejected")
+	}
+
+	// Messages are signed with the Ethereum signed message prefix
+	signature, err := w.SignMessage("hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature[crypto.RecoveryIDOffset] -= 27
+	publicKey, err := crypto.SigToPub(accounts.TextHash([]byte("hello")), signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if crypto.PubkeyToAddress(*publicKey) != address {
+		t.Error("the message was not signed by the node account")
+	}
+}
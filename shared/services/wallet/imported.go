@@ -0,0 +1,267 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+	hexutil "github.com/stader-labs/stader-node/shared/utils/hex"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+// Config
+const (
+	ImportedValidatorKeysFolder = "imported-validator-keys"
+	importedValidatorKeysMode   = 0700
+)
+
+// An EIP-2335 validator keystore, as written by staking-deposit-cli and other key generators
+type validatorKeystore struct {
+	Crypto      map[string]interface{} `json:"crypto"`
+	Description string                 `json:"description,omitempty"`
+	Version     uint                   `json:"version"`
+	UUID        uuid.UUID              `json:"uuid"`
+	Path        string                 `json:"path"`
+	Pubkey      string                 `json:"pubkey"`
+}
+
+// Import a validator key generated outside of the wallet from an EIP-2335 keystore.
+// The key is saved with the node password next to the wallet, and stored in the validator keystores.
+func (w *Wallet) ImportValidatorKey(keystoreJson []byte, password string) (ValidatorKey, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return ValidatorKey{}, w.errNotInitialized()
+	}
+
+	// Decode keystore
+	var keystore validatorKeystore
+	if err := json.Unmarshal(keystoreJson, &keystore); err != nil {
+		return ValidatorKey{}, fmt.Errorf("Could not decode keystore: %w", err)
+	}
+	if keystore.Version != 4 {
+		return ValidatorKey{}, fmt.Errorf("Unsupported keystore version %d, only EIP-2335 (version 4) keystores can be imported", keystore.Version)
+	}
+	if keystore.Crypto == nil {
+		return ValidatorKey{}, errors.New("The keystore has no crypto section")
+	}
+
+	// Decrypt key
+	key, err := decryptValidatorKey(keystore.Crypto, password)
+	if err != nil {
+		return ValidatorKey{}, err
+	}
+	pubkey := types.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	// Check the key matches the keystore's public key
+	if keystore.Pubkey != "" && !strings.EqualFold(hexutil.RemovePrefix(keystore.Pubkey), pubkey.Hex()) {
+		return ValidatorKey{}, fmt.Errorf("The keystore's key belongs to validator %s, not %s", pubkey.Hex(), keystore.Pubkey)
+	}
+
+	// Save key
+	if err := w.saveImportedValidatorKey(key, keystore.Path); err != nil {
+		return ValidatorKey{}, err
+	}
+
+	// Update keystores
+	if err := w.StoreValidatorKey(key, keystore.Path); err != nil {
+		return ValidatorKey{}, err
+	}
+
+	// Return
+	return ValidatorKey{
+		PublicKey:      pubkey,
+		PrivateKey:     key,
+		DerivationPath: keystore.Path,
+	}, nil
+
+}
+
+// Check if a validator key was imported into the wallet
+func (w *Wallet) IsImportedValidatorKey(pubkey types.ValidatorPubkey) bool {
+	_, err := os.Stat(w.getImportedValidatorKeyPath(pubkey))
+	return err == nil
+}
+
+// Get an imported validator key by public key
+func (w *Wallet) GetImportedValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, w.errNotInitialized()
+	}
+
+	key, _, err := w.loadImportedValidatorKey(pubkey)
+	return key, err
+
+}
+
+// Get all of the validator keys imported into the wallet
+func (w *Wallet) GetImportedValidatorKeys() ([]ValidatorKey, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, w.errNotInitialized()
+	}
+
+	// Get the key files
+	files, err := ioutil.ReadDir(w.getImportedValidatorKeysDir())
+	if os.IsNotExist(err) {
+		return []ValidatorKey{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Could not read the imported validator keys: %w", err)
+	}
+
+	validatorKeys := []ValidatorKey{}
+	for _, file := range files {
+		pubkeyHex := strings.TrimSuffix(file.Name(), ".json")
+		if file.IsDir() || pubkeyHex == file.Name() {
+			continue
+		}
+		pubkey, err := types.HexToValidatorPubkey(pubkeyHex)
+		if err != nil {
+			continue
+		}
+		key, path, err := w.loadImportedValidatorKey(pubkey)
+		if err != nil {
+			return nil, err
+		}
+		validatorKeys = append(validatorKeys, ValidatorKey{
+			PublicKey:      pubkey,
+			PrivateKey:     key,
+			DerivationPath: path,
+		})
+	}
+
+	return validatorKeys, nil
+
+}
+
+// Save an imported validator key, encrypted with the node password
+func (w *Wallet) saveImportedValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+
+	// Get the node password
+	password, err := w.pm.GetPassword()
+	if err != nil {
+		return fmt.Errorf("Could not get node password: %w", err)
+	}
+
+	// Write it to disk
+	return w.writeImportedValidatorKey(key, derivationPath, password, ioutil.WriteFile)
+
+}
+
+// Encrypt an imported validator key with a password and write it
+func (w *Wallet) writeImportedValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string, password string, writeFile keystore.FileWriter) error {
+
+	// Encrypt key
+	encryptedKey, err := w.encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt validator key: %w", err)
+	}
+	pubkey := types.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	keystoreBytes, err := json.Marshal(validatorKeystore{
+		Crypto:      encryptedKey,
+		Description: "stader-node imported key",
+		Version:     w.encryptor.Version(),
+		UUID:        uuid.New(),
+		Path:        derivationPath,
+		Pubkey:      pubkey.Hex(),
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode validator key: %w", err)
+	}
+
+	// Write it to disk
+	if err := os.MkdirAll(w.getImportedValidatorKeysDir(), importedValidatorKeysMode); err != nil {
+		return fmt.Errorf("Could not create imported validator keys folder: %w", err)
+	}
+	if err := writeFile(w.getImportedValidatorKeyPath(pubkey), keystoreBytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write validator key to disk: %w", err)
+	}
+
+	// Cache key
+	w.importedValidatorKeys[pubkey.Hex()] = key
+
+	// Return
+	return nil
+
+}
+
+// Load an imported validator key and its derivation path
+func (w *Wallet) loadImportedValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, string, error) {
+
+	// Read the key file
+	keystoreBytes, err := ioutil.ReadFile(w.getImportedValidatorKeyPath(pubkey))
+	if os.IsNotExist(err) {
+		return nil, "", fmt.Errorf("Validator %s key not found", pubkey.Hex())
+	} else if err != nil {
+		return nil, "", fmt.Errorf("Could not read validator %s key: %w", pubkey.Hex(), err)
+	}
+	var keystore validatorKeystore
+	if err := json.Unmarshal(keystoreBytes, &keystore); err != nil {
+		return nil, "", fmt.Errorf("Could not decode validator %s key: %w", pubkey.Hex(), err)
+	}
+
+	// Check for cached validator key
+	if key, ok := w.importedValidatorKeys[pubkey.Hex()]; ok {
+		return key, keystore.Path, nil
+	}
+
+	// Decrypt it with the node password
+	password, err := w.pm.GetPassword()
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not get node password: %w", err)
+	}
+	key, err := decryptValidatorKey(keystore.Crypto, password)
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not load validator %s key: %w", pubkey.Hex(), err)
+	}
+
+	// Cache validator key
+	w.importedValidatorKeys[pubkey.Hex()] = key
+
+	// Return
+	return key, keystore.Path, nil
+
+}
+
+// Get the folder imported validator keys are saved in
+func (w *Wallet) getImportedValidatorKeysDir() string {
+	return filepath.Join(filepath.Dir(w.walletPath), ImportedValidatorKeysFolder)
+}
+
+// Get the path an imported validator key is saved at
+func (w *Wallet) getImportedValidatorKeyPath(pubkey types.ValidatorPubkey) string {
+	return filepath.Join(w.getImportedValidatorKeysDir(), pubkey.Hex()+".json")
+}
+
+// Decrypt a validator key from the crypto section of an EIP-2335 keystore
+func decryptValidatorKey(crypto map[string]interface{}, password string) (*eth2types.BLSPrivateKey, error) {
+
+	// Initialize BLS support
+	if err := initializeBLS(); err != nil {
+		return nil, fmt.Errorf("Could not initialize BLS library: %w", err)
+	}
+
+	// Decrypt key
+	keyBytes, err := eth2ks.New().Decrypt(crypto, password)
+	if err != nil {
+		return nil, fmt.Errorf("Could not decrypt keystore, check the password: %w", err)
+	}
+	key, err := eth2types.BLSPrivateKeyFromBytes(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("The keystore does not hold a valid validator key: %w", err)
+	}
+	return key, nil
+
+}
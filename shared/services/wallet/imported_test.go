@@ -0,0 +1,100 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+func TestImportValidatorKey(t *testing.T) {
+	w, dir, pm := newTestWallet(t)
+	if err := w.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A key generated outside of the wallet
+	if err := initializeBLS(); err != nil {
+		t.Fatal(err)
+	}
+	key, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkey := types.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	encryptor := eth2ks.New(eth2ks.WithCipher("pbkdf2"))
+	crypto, err := encryptor.Encrypt(key.Marshal(), "keystore-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keystoreJson, err := json.Marshal(validatorKeystore{
+		Crypto:  crypto,
+		Version: encryptor.Version(),
+		UUID:    uuid.New(),
+		Path:    "m/12381/3600/0/0/0",
+		Pubkey:  hex.EncodeToString(pubkey.Bytes()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The wrong password is rejected
+	if _, err := w.ImportValidatorKey(keystoreJson, "wrong-password"); err == nil {
+		t.Error("expected a wrong password to be rejected")
+	}
+	if w.IsImportedValidatorKey(pubkey) {
+		t.Error("a key that failed to import was saved")
+	}
+
+	// The key is imported and found by its public key
+	validatorKey, err := w.ImportValidatorKey(keystoreJson, "keystore-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if validatorKey.PublicKey != pubkey || validatorKey.DerivationPath != "m/12381/3600/0/0/0" {
+		t.Errorf("unexpected imported key %s at %s", validatorKey.PublicKey.Hex(), validatorKey.DerivationPath)
+	}
+	if !w.IsImportedValidatorKey(pubkey) {
+		t.Error("the key was not saved")
+	}
+
+	// It's loaded from disk by a new wallet
+	w = openTestWallet(t, dir, pm)
+	foundKey, err := w.GetValidatorKeyByPubkey(pubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if types.BytesToValidatorPubkey(foundKey.PublicKey().Marshal()) != pubkey {
+		t.Error("found the wrong key")
+	}
+	importedKeys, err := w.GetImportedValidatorKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(importedKeys) != 1 || importedKeys[0].PublicKey != pubkey {
+		t.Errorf("unexpected imported keys %v", importedKeys)
+	}
+
+	// A keystore whose public key doesn't match its key is rejected
+	otherKey, err := eth2types.GenerateBLSPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keystoreJson, err = json.Marshal(validatorKeystore{
+		Crypto:  crypto,
+		Version: encryptor.Version(),
+		UUID:    uuid.New(),
+		Pubkey:  hex.EncodeToString(otherKey.PublicKey().Marshal()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.ImportValidatorKey(keystoreJson, "keystore-password"); err == nil {
+		t.Error("expected a mismatched public key to be rejected")
+	}
+}
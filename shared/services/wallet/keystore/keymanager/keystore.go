@@ -0,0 +1,157 @@
+package keymanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/stader-labs/stader-node/shared/services/keymanager"
+	keystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
+)
+
+// Keystore that loads validator keys into the Validator client through its key manager API instead of saving keystore files
+type Keystore struct {
+	client       *keymanager.Client
+	keystorePath string
+	encryptor    *eth2ks.Encryptor
+}
+
+// Encrypted validator key store, in EIP-2335 format
+type validatorKey struct {
+	Crypto      map[string]interface{}      `json:"crypto"`
+	Description string                      `json:"description"`
+	Version     uint                        `json:"version"`
+	UUID        uuid.UUID                   `json:"uuid"`
+	Path        string                      `json:"path"`
+	Pubkey      stadertypes.ValidatorPubkey `json:"pubkey"`
+}
+
+// Create new key manager keystore. A slashing protection history waiting to be imported under keystorePath, such as one
+// restored from a backup, is sent along with every key so the Validator client knows what the key signed before.
+func NewKeystore(client *keymanager.Client, keystorePath string) *Keystore {
+	return &Keystore{
+		client:       client,
+		keystorePath: keystorePath,
+		encryptor:    eth2ks.New(eth2ks.WithCipher("scrypt")),
+	}
+}
+
+// Get the keystore directory; keys are held by the Validator client
+func (ks *Keystore) GetKeystoreDir() string {
+	return ""
+}
+
+// Store a validator key
+func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+
+	// Get validator pubkey
+	pubkey := stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	// Create a new password
+	password, err := keystore.GenerateRandomPassword()
+	if err != nil {
+		return fmt.Errorf("Could not generate random password: %w", err)
+	}
+
+	// Encrypt key
+	encryptedKey, err := ks.encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt validator key: %w", err)
+	}
+
+	// Create key store
+	keyStore := validatorKey{
+		Crypto:      encryptedKey,
+		Description: "stader-node",
+		Version:     ks.encryptor.Version(),
+		UUID:        uuid.New(),
+		Path:        derivationPath,
+		Pubkey:      pubkey,
+	}
+
+	// Encode key store
+	keyStoreBytes, err := json.Marshal(keyStore)
+	if err != nil {
+		return fmt.Errorf("Could not encode validator key: %w", err)
+	}
+
+	// Get the slashing protection history waiting to be imported
+	slashingProtection, err := ks.getSlashingProtection()
+	if err != nil {
+		return err
+	}
+
+	// Import it
+	statuses, err := ks.client.ImportKeystores([]string{string(keyStoreBytes)}, []string{password}, slashingProtection)
+	if err != nil {
+		return err
+	}
+	switch statuses[0].Status {
+	case keymanager.StatusImported, keymanager.StatusDuplicate:
+	default:
+		return fmt.Errorf("The Validator client could not import validator key %s: %s %s", pubkey.Hex(), statuses[0].Status, statuses[0].Message)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Delete a validator key
+func (ks *Keystore) DeleteValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+	_, err := ks.DeleteValidatorKeys([]stadertypes.ValidatorPubkey{pubkey})
+	return err
+}
+
+// Get the public keys of the validators loaded into the Validator client
+func (ks *Keystore) GetValidatorPubkeys() ([]stadertypes.ValidatorPubkey, error) {
+	keys, err := ks.client.ListKeystores()
+	if err != nil {
+		return nil, err
+	}
+	pubkeys := make([]stadertypes.ValidatorPubkey, len(keys))
+	for i, key := range keys {
+		pubkeys[i] = key.Pubkey
+	}
+	return pubkeys, nil
+}
+
+// Delete validator keys from the Validator client, returning their EIP-3076 slashing protection history.
+// Keys the Validator client doesn't have are skipped. The history is returned even if some keys could not be deleted,
+// since the others are already gone.
+func (ks *Keystore) DeleteValidatorKeys(pubkeys []stadertypes.ValidatorPubkey) ([]byte, error) {
+	statuses, slashingProtection, err := ks.client.DeleteKeystores(pubkeys)
+	if err != nil {
+		return nil, err
+	}
+	for i, status := range statuses {
+		switch status.Status {
+		case keymanager.StatusDeleted, keymanager.StatusNotActive, keymanager.StatusNotFound:
+		default:
+			return []byte(slashingProtection), fmt.Errorf("The Validator client could not delete validator key %s: %s %s", pubkeys[i].Hex(), status.Status, status.Message)
+		}
+	}
+	return []byte(slashingProtection), nil
+}
+
+// Read the slashing protection history waiting to be imported, if there is one
+func (ks *Keystore) getSlashingProtection() (string, error) {
+	if ks.keystorePath == "" {
+		return "", nil
+	}
+	slashingProtection, err := ioutil.ReadFile(filepath.Join(ks.keystorePath, validator.SlashingProtectionImportFile))
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("Could not read the slashing protection history: %w", err)
+	}
+	return string(slashingProtection), nil
+}
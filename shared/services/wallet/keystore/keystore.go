@@ -22,6 +22,8 @@ package keystore
 import (
 	"github.com/sethvargo/go-password/password"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/stader-labs/stader-node/stader-lib/types"
 )
 
 // Generates a random password
@@ -39,5 +41,29 @@ func GenerateRandomPassword() (string, error) {
 // Validator keystore interface
 type Keystore interface {
 	StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error
+	DeleteValidatorKey(pubkey types.ValidatorPubkey) error
 	GetKeystoreDir() string
 }
+
+// Validator keystore whose key files can be written into a Stage, so they change together with the wallet
+type StagingKeystore interface {
+	Keystore
+	StageValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string, stage *Stage) error
+}
+
+// Validator keystore that encrypts all of its keys with a single random password, which can be replaced.
+// The new password and the re-encrypted keys are written into a Stage.
+type SharedPasswordKeystore interface {
+	Keystore
+	ChangePassword(stage *Stage) error
+}
+
+// Validator keystore that loads keys into a running Validator client, which doesn't need to be restarted to use them
+type LiveKeystore interface {
+	Keystore
+	GetValidatorPubkeys() ([]types.ValidatorPubkey, error)
+
+	// Delete keys from the Validator client and return their slashing protection history, which must be returned
+	// along with any error once the Validator client has deleted some of them
+	DeleteValidatorKeys(pubkeys []types.ValidatorPubkey) ([]byte, error)
+}
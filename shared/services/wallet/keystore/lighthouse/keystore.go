@@ -30,6 +30,7 @@ import (
 	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	"gopkg.in/yaml.v2"
 
 	"github.com/stader-labs/stader-node/shared/services/passwords"
 	keystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore"
@@ -38,12 +39,13 @@ import (
 
 // Config
 const (
-	KeystoreDir   = "lighthouse"
-	SecretsDir    = "secrets"
-	ValidatorsDir = "validators"
-	KeyFileName   = "voting-keystore.json"
-	DirMode       = 0770
-	FileMode      = 0640
+	KeystoreDir         = "lighthouse"
+	SecretsDir          = "secrets"
+	ValidatorsDir       = "validators"
+	KeyFileName         = "voting-keystore.json"
+	DefinitionsFileName = "validator_definitions.yml"
+	DirMode             = 0770
+	FileMode            = 0640
 )
 
 // Lighthouse keystore
@@ -78,6 +80,16 @@ func (ks *Keystore) GetKeystoreDir() string {
 
 // Store a validator key
 func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+	return ks.writeValidatorKey(key, derivationPath, ioutil.WriteFile)
+}
+
+// Write a validator key into a stage
+func (ks *Keystore) StageValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string, stage *keystore.Stage) error {
+	return ks.writeValidatorKey(key, derivationPath, stage.WriteFile)
+}
+
+// Encrypt a validator key with a new random secret and write both
+func (ks *Keystore) writeValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string, writeFile keystore.FileWriter) error {
 
 	// Get validator pubkey
 	pubkey := stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
@@ -118,7 +130,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write secret to disk
-	if err := ioutil.WriteFile(secretFilePath, []byte(password), FileMode); err != nil {
+	if err := writeFile(secretFilePath, []byte(password), FileMode); err != nil {
 		return fmt.Errorf("Could not write validator secret to disk: %w", err)
 	}
 
@@ -131,7 +143,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write key store to disk
-	if err := ioutil.WriteFile(keyFilePath, keyStoreBytes, FileMode); err != nil {
+	if err := writeFile(keyFilePath, keyStoreBytes, FileMode); err != nil {
 		return fmt.Errorf("Could not write validator key to disk: %w", err)
 	}
 
@@ -139,3 +151,68 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	return nil
 
 }
+
+// Delete a validator key
+func (ks *Keystore) DeleteValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+
+	// Delete the secret
+	secretFilePath := filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex()))
+	if err := os.RemoveAll(secretFilePath); err != nil {
+		return fmt.Errorf("Could not delete validator secret: %w", err)
+	}
+
+	// Delete the key folder
+	keyDirPath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex()))
+	if err := os.RemoveAll(keyDirPath); err != nil {
+		return fmt.Errorf("Could not delete validator key: %w", err)
+	}
+
+	// Remove it from the validator definitions
+	return RemoveValidatorDefinition(filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, DefinitionsFileName), pubkey)
+
+}
+
+// Remove a validator's entry from Lighthouse's validator definitions, which Lighthouse fails to start with if its key is missing
+func RemoveValidatorDefinition(definitionsPath string, pubkey stadertypes.ValidatorPubkey) error {
+
+	// Load the existing definitions
+	definitionsBytes, err := ioutil.ReadFile(definitionsPath)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Could not read Lighthouse validator definitions: %w", err)
+	}
+	definitions := []yaml.MapSlice{}
+	if err := yaml.Unmarshal(definitionsBytes, &definitions); err != nil {
+		return fmt.Errorf("Could not decode Lighthouse validator definitions: %w", err)
+	}
+
+	// Remove the validator's entry
+	votingPublicKey := hexutil.AddPrefix(pubkey.Hex())
+	remaining := []yaml.MapSlice{}
+	for _, definition := range definitions {
+		matches := false
+		for _, item := range definition {
+			if item.Key == "voting_public_key" && item.Value == votingPublicKey {
+				matches = true
+			}
+		}
+		if !matches {
+			remaining = append(remaining, definition)
+		}
+	}
+	if len(remaining) == len(definitions) {
+		return nil
+	}
+
+	// Save the definitions
+	definitionsBytes, err = yaml.Marshal(remaining)
+	if err != nil {
+		return fmt.Errorf("Could not encode Lighthouse validator definitions: %w", err)
+	}
+	if err := ioutil.WriteFile(definitionsPath, definitionsBytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write Lighthouse validator definitions: %w", err)
+	}
+	return nil
+
+}
@@ -59,6 +59,16 @@ func (ks *Keystore) GetKeystoreDir() string {
 
 // Store a validator key
 func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+	return ks.writeValidatorKey(key, derivationPath, ioutil.WriteFile)
+}
+
+// Write a validator key into a stage
+func (ks *Keystore) StageValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string, stage *keystore.Stage) error {
+	return ks.writeValidatorKey(key, derivationPath, stage.WriteFile)
+}
+
+// Encrypt a validator key with a new random secret and write both
+func (ks *Keystore) writeValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string, writeFile keystore.FileWriter) error {
 
 	// Get validator pubkey
 	pubkey := stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
@@ -99,7 +109,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write secret to disk
-	if err := ioutil.WriteFile(secretFilePath, []byte(password), FileMode); err != nil {
+	if err := writeFile(secretFilePath, []byte(password), FileMode); err != nil {
 		return fmt.Errorf("Could not write validator secret to disk: %w", err)
 	}
 
@@ -112,7 +122,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write key store to disk
-	if err := ioutil.WriteFile(keyFilePath, keyStoreBytes, FileMode); err != nil {
+	if err := writeFile(keyFilePath, keyStoreBytes, FileMode); err != nil {
 		return fmt.Errorf("Could not write validator key to disk: %w", err)
 	}
 
@@ -121,6 +131,26 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 
 }
 
+// Delete a validator key
+func (ks *Keystore) DeleteValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+
+	// Delete the secret
+	secretFilePath := filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex()))
+	if err := os.RemoveAll(secretFilePath); err != nil {
+		return fmt.Errorf("Could not delete validator secret: %w", err)
+	}
+
+	// Delete the key folder
+	keyDirPath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex()))
+	if err := os.RemoveAll(keyDirPath); err != nil {
+		return fmt.Errorf("Could not delete validator key: %w", err)
+	}
+
+	// Return
+	return nil
+
+}
+
 // Load a private key
 func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
 
@@ -30,6 +30,7 @@ import (
 
 	"github.com/google/uuid"
 	staderkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 
@@ -109,19 +110,93 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	ks.as.PrivateKeys = append(ks.as.PrivateKeys, key.Marshal())
 	ks.as.PublicKeys = append(ks.as.PublicKeys, key.PublicKey().Marshal())
 
-	// Encode account store
-	asBytes, err := json.Marshal(ks.as)
+	// Save the account store
+	return ks.saveAccountStore()
+
+}
+
+// Delete a validator key
+func (ks *Keystore) DeleteValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+
+	// Initialize the account store
+	if err := ks.initialize(); err != nil {
+		return err
+	}
+
+	// Remove validator key from account store
+	privateKeys := [][]byte{}
+	publicKeys := [][]byte{}
+	for ki := 0; ki < len(ks.as.PrivateKeys); ki++ {
+		if bytes.Equal(pubkey.Bytes(), ks.as.PublicKeys[ki]) {
+			continue
+		}
+		privateKeys = append(privateKeys, ks.as.PrivateKeys[ki])
+		publicKeys = append(publicKeys, ks.as.PublicKeys[ki])
+	}
+	if len(publicKeys) == len(ks.as.PublicKeys) {
+		return nil
+	}
+	ks.as.PrivateKeys = privateKeys
+	ks.as.PublicKeys = publicKeys
+
+	// Save the account store
+	return ks.saveAccountStore()
+
+}
+
+// Replace the random password the account store is encrypted with, writing both into a stage
+func (ks *Keystore) ChangePassword(stage *staderkeystore.Stage) error {
+
+	// Initialize the account store
+	if err := ks.initialize(); err != nil {
+		return err
+	}
+
+	// Create a new password
+	password, err := staderkeystore.GenerateRandomPassword()
 	if err != nil {
-		return fmt.Errorf("Could not encode validator account store: %w", err)
+		return fmt.Errorf("Could not generate random password: %w", err)
+	}
+
+	// Re-encrypt the account store with it
+	if err := ks.writeAccountStore(password, stage.WriteFile); err != nil {
+		return err
+	}
+
+	// Write it
+	passwordFilePath := filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystorePasswordFileName)
+	if err := stage.WriteFile(passwordFilePath, []byte(password), FileMode); err != nil {
+		return fmt.Errorf("Error writing account password file: %w", err)
 	}
 
+	// Return
+	return nil
+
+}
+
+// Encrypt the account store with the account password and write it to disk
+func (ks *Keystore) saveAccountStore() error {
+
 	// Get the keystore account password
 	passwordFilePath := filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystorePasswordFileName)
 	passwordBytes, err := ioutil.ReadFile(passwordFilePath)
 	if err != nil {
 		return fmt.Errorf("Error reading account password file: %w", err)
 	}
-	password := string(passwordBytes)
+
+	// Write the account store
+	return ks.writeAccountStore(string(passwordBytes), ioutil.WriteFile)
+
+}
+
+// Encrypt the account store with a password and write it
+func (ks *Keystore) writeAccountStore(password string, writeFile staderkeystore.FileWriter) error {
+
+	// Encode account store
+	asBytes, err := json.Marshal(ks.as)
+	if err != nil {
+		return fmt.Errorf("Could not encode validator account store: %w", err)
+	}
 
 	// Encrypt account store
 	asEncrypted, err := ks.encryptor.Encrypt(asBytes, password)
@@ -153,7 +228,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write keystore to disk
-	if err := ioutil.WriteFile(keystoreFilePath, ksBytes, FileMode); err != nil {
+	if err := writeFile(keystoreFilePath, ksBytes, FileMode); err != nil {
 		return fmt.Errorf("Could not write keystore to disk: %w", err)
 	}
 
@@ -171,7 +246,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write wallet config to disk
-	if err := ioutil.WriteFile(configFilePath, configBytes, FileMode); err != nil {
+	if err := writeFile(configFilePath, configBytes, FileMode); err != nil {
 		return fmt.Errorf("Could not write wallet config to disk: %w", err)
 	}
 
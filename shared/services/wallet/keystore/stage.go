@@ -0,0 +1,148 @@
+package keystore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Config
+const (
+	stagedFileSuffix = ".staged"
+	journalFileMode  = 0600
+)
+
+// Writes a file, either straight to disk (ioutil.WriteFile) or into a Stage
+type FileWriter func(path string, data []byte, mode os.FileMode) error
+
+// Files that are written next to their final paths and put in place together.
+// The final paths are listed in a journal before any file is moved, so a change interrupted by a crash is finished by
+// ResumeStage instead of leaving some files old and some new.
+type Stage struct {
+	journalPath string
+	paths       []string
+	staged      map[string]bool
+}
+
+// The journal of a stage that is being committed
+type stageJournal struct {
+	Paths []string `json:"paths"`
+}
+
+// Create a new stage with its journal at journalPath
+func NewStage(journalPath string) *Stage {
+	return &Stage{
+		journalPath: journalPath,
+		paths:       []string{},
+		staged:      map[string]bool{},
+	}
+}
+
+// Write a file next to its final path. Files are put in place in the order they are first written.
+func (s *Stage) WriteFile(path string, data []byte, mode os.FileMode) error {
+	if err := writeFileSynced(path+stagedFileSuffix, data, mode); err != nil {
+		return err
+	}
+	if !s.staged[path] {
+		s.staged[path] = true
+		s.paths = append(s.paths, path)
+	}
+	return nil
+}
+
+// Remove the staged files without putting them in place
+func (s *Stage) Discard() {
+	for _, path := range s.paths {
+		_ = os.Remove(path + stagedFileSuffix)
+	}
+	s.paths = []string{}
+	s.staged = map[string]bool{}
+}
+
+// Put the staged files in place
+func (s *Stage) Commit() error {
+	journalBytes, err := json.Marshal(stageJournal{Paths: s.paths})
+	if err != nil {
+		return fmt.Errorf("Could not encode the journal: %w", err)
+	}
+	if err := writeFileSynced(s.journalPath+stagedFileSuffix, journalBytes, journalFileMode); err != nil {
+		return fmt.Errorf("Could not write the journal: %w", err)
+	}
+	if err := os.Rename(s.journalPath+stagedFileSuffix, s.journalPath); err != nil {
+		return fmt.Errorf("Could not write the journal: %w", err)
+	}
+	return applyJournal(s.journalPath, s.paths)
+}
+
+// Finish putting the files of an interrupted stage in place, if there is one
+func ResumeStage(journalPath string) error {
+	journalBytes, err := ioutil.ReadFile(journalPath)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Could not read the journal of an interrupted change: %w", err)
+	}
+	var journal stageJournal
+	if err := json.Unmarshal(journalBytes, &journal); err != nil {
+		return fmt.Errorf("Could not decode the journal of an interrupted change: %w", err)
+	}
+	return applyJournal(journalPath, journal.Paths)
+}
+
+// Move the staged files over their final paths and remove the journal; files that were already moved are skipped
+func applyJournal(journalPath string, paths []string) error {
+	dirs := map[string]bool{}
+	for _, path := range paths {
+		if _, err := os.Stat(path + stagedFileSuffix); os.IsNotExist(err) {
+			continue
+		}
+		if err := os.Rename(path+stagedFileSuffix, path); err != nil {
+			return fmt.Errorf("Could not move %s into place: %w", path, err)
+		}
+		dirs[filepath.Dir(path)] = true
+	}
+	for dir := range dirs {
+		if err := syncDir(dir); err != nil {
+			return err
+		}
+	}
+	if err := os.Remove(journalPath); err != nil {
+		return fmt.Errorf("Could not remove the journal: %w", err)
+	}
+	return nil
+}
+
+// Write a file and flush it to disk, creating its folder if needed
+func writeFileSynced(path string, data []byte, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Flush a folder's entries to disk
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	if err := dir.Sync(); err != nil {
+		return fmt.Errorf("Could not flush %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package keystore
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStage(t *testing.T) {
+	dir := t.TempDir()
+	journalPath := filepath.Join(dir, "journal")
+	walletPath := filepath.Join(dir, "wallet")
+	passwordPath := filepath.Join(dir, "password")
+	for _, path := range []string{walletPath, passwordPath} {
+		if err := ioutil.WriteFile(path, []byte("old"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	readFile := func(path string) string {
+		data, _ := ioutil.ReadFile(path)
+		return string(data)
+	}
+
+	// Staged files don't replace anything until they are committed
+	stage := NewStage(journalPath)
+	if err := stage.WriteFile(walletPath, []byte("new"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := stage.WriteFile(passwordPath, []byte("new"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if readFile(walletPath) != "old" || readFile(passwordPath) != "old" {
+		t.Fatal("staged files replaced the live ones before the commit")
+	}
+	stage.Discard()
+	if _, err := os.Stat(walletPath + stagedFileSuffix); !os.IsNotExist(err) {
+		t.Error("discarding the stage left its files behind")
+	}
+
+	// A commit interrupted after the first rename is finished from the journal
+	stage = NewStage(journalPath)
+	stage.WriteFile(walletPath, []byte("new"), 0600)
+	stage.WriteFile(passwordPath, []byte("new"), 0600)
+	journalBytes, _ := json.Marshal(stageJournal{Paths: stage.paths})
+	if err := ioutil.WriteFile(journalPath, journalBytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(walletPath+stagedFileSuffix, walletPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := ResumeStage(journalPath); err != nil {
+		t.Fatal(err)
+	}
+	if readFile(walletPath) != "new" || readFile(passwordPath) != "new" {
+		t.Error("resuming the stage did not put every file in place")
+	}
+	if _, err := os.Stat(journalPath); !os.IsNotExist(err) {
+		t.Error("resuming the stage left the journal behind")
+	}
+
+	// Without a journal there is nothing to resume
+	if err := ResumeStage(journalPath); err != nil {
+		t.Error(err)
+	}
+}
@@ -77,6 +77,16 @@ func (ks *Keystore) GetKeystoreDir() string {
 
 // Store a validator key
 func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+	return ks.writeValidatorKey(key, derivationPath, ioutil.WriteFile)
+}
+
+// Write a validator key into a stage
+func (ks *Keystore) StageValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string, stage *keystore.Stage) error {
+	return ks.writeValidatorKey(key, derivationPath, stage.WriteFile)
+}
+
+// Encrypt a validator key with a new random secret and write both
+func (ks *Keystore) writeValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string, writeFile keystore.FileWriter) error {
 
 	// Get validator pubkey
 	pubkey := stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
@@ -117,7 +127,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write secret to disk
-	if err := ioutil.WriteFile(secretFilePath, []byte(password), FileMode); err != nil {
+	if err := writeFile(secretFilePath, []byte(password), FileMode); err != nil {
 		return fmt.Errorf("Could not write validator secret to disk: %w", err)
 	}
 
@@ -130,7 +140,7 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	}
 
 	// Write key store to disk
-	if err := ioutil.WriteFile(keyFilePath, keyStoreBytes, FileMode); err != nil {
+	if err := writeFile(keyFilePath, keyStoreBytes, FileMode); err != nil {
 		return fmt.Errorf("Could not write validator key to disk: %w", err)
 	}
 
@@ -138,3 +148,23 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 	return nil
 
 }
+
+// Delete a validator key
+func (ks *Keystore) DeleteValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+
+	// Delete the secret
+	secretFilePath := filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex())+".txt")
+	if err := os.RemoveAll(secretFilePath); err != nil {
+		return fmt.Errorf("Could not delete validator secret: %w", err)
+	}
+
+	// Delete the key file
+	keyFilePath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex())+".json")
+	if err := os.RemoveAll(keyFilePath); err != nil {
+		return fmt.Errorf("Could not delete validator key: %w", err)
+	}
+
+	// Return
+	return nil
+
+}
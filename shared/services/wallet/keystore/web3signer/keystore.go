@@ -0,0 +1,209 @@
+package web3signer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	"gopkg.in/yaml.v2"
+
+	keystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+	lhkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/lighthouse"
+	"github.com/stader-labs/stader-node/shared/services/web3signer"
+	hexutil "github.com/stader-labs/stader-node/shared/utils/hex"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
+)
+
+// Config
+const (
+	LighthouseDefinitionsPath = "lighthouse/validators/validator_definitions.yml"
+	DirMode                   = 0770
+	FileMode                  = 0640
+)
+
+// Keystore that imports validator keys into a Web3Signer instead of saving them for the Validator client
+type Keystore struct {
+	client       *web3signer.Client
+	keystorePath string
+	encryptor    *eth2ks.Encryptor
+}
+
+// Encrypted validator key store, in EIP-2335 format
+type validatorKey struct {
+	Crypto      map[string]interface{}      `json:"crypto"`
+	Description string                      `json:"description"`
+	Version     uint                        `json:"version"`
+	UUID        uuid.UUID                   `json:"uuid"`
+	Path        string                      `json:"path"`
+	Pubkey      stadertypes.ValidatorPubkey `json:"pubkey"`
+}
+
+// A remote signer entry in Lighthouse's validator definitions
+type lighthouseDefinition struct {
+	Enabled         bool   `yaml:"enabled"`
+	VotingPublicKey string `yaml:"voting_public_key"`
+	Type            string `yaml:"type"`
+	Url             string `yaml:"url"`
+}
+
+// Create new Web3Signer keystore. Keys are also registered in Lighthouse's validator definitions under keystorePath,
+// since Lighthouse can't discover the keys a Web3Signer holds by itself.
+func NewKeystore(client *web3signer.Client, keystorePath string) *Keystore {
+	return &Keystore{
+		client:       client,
+		keystorePath: keystorePath,
+		encryptor:    eth2ks.New(eth2ks.WithCipher("scrypt")),
+	}
+}
+
+// Get the keystore directory; keys aren't stored on this machine
+func (ks *Keystore) GetKeystoreDir() string {
+	return ""
+}
+
+// Store a validator key
+func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+
+	// Get validator pubkey
+	pubkey := stadertypes.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	// Create a new password
+	password, err := keystore.GenerateRandomPassword()
+	if err != nil {
+		return fmt.Errorf("Could not generate random password: %w", err)
+	}
+
+	// Encrypt key
+	encryptedKey, err := ks.encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt validator key: %w", err)
+	}
+
+	// Create key store
+	keyStore := validatorKey{
+		Crypto:      encryptedKey,
+		Description: "stader-node",
+		Version:     ks.encryptor.Version(),
+		UUID:        uuid.New(),
+		Path:        derivationPath,
+		Pubkey:      pubkey,
+	}
+
+	// Encode key store
+	keyStoreBytes, err := json.Marshal(keyStore)
+	if err != nil {
+		return fmt.Errorf("Could not encode validator key: %w", err)
+	}
+
+	// Import it
+	statuses, err := ks.client.ImportKeystores([]string{string(keyStoreBytes)}, []string{password})
+	if err != nil {
+		return err
+	}
+	if len(statuses) != 1 {
+		return fmt.Errorf("Web3Signer returned %d import results for 1 keystore", len(statuses))
+	}
+	switch statuses[0].Status {
+	case "imported", "duplicate":
+	default:
+		return fmt.Errorf("Web3Signer could not import validator key %s: %s %s", pubkey.Hex(), statuses[0].Status, statuses[0].Message)
+	}
+
+	// Register it with Lighthouse
+	return ks.addLighthouseDefinition(pubkey)
+
+}
+
+// Delete a validator key
+func (ks *Keystore) DeleteValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+
+	// Delete it from the Web3Signer
+	statuses, slashingProtection, err := ks.client.DeleteKeystores([]stadertypes.ValidatorPubkey{pubkey})
+	if err != nil {
+		return err
+	}
+
+	// Save the slashing protection history it returns before anything else can fail
+	if slashingProtection != "" {
+		historyPath := filepath.Join(ks.keystorePath, validator.GetRemovedSlashingProtectionFile("web3signer-"+pubkey.Hex(), time.Now()))
+		if err := ioutil.WriteFile(historyPath, []byte(slashingProtection), FileMode); err != nil {
+			return fmt.Errorf("Web3Signer deleted validator key %s, but its slashing protection history could not be saved: %w\n%s", pubkey.Hex(), err, slashingProtection)
+		}
+	}
+	if len(statuses) != 1 {
+		return fmt.Errorf("Web3Signer returned %d delete results for 1 keystore", len(statuses))
+	}
+	switch statuses[0].Status {
+	case "deleted", "not_active", "not_found":
+	default:
+		return fmt.Errorf("Web3Signer could not delete validator key %s: %s %s", pubkey.Hex(), statuses[0].Status, statuses[0].Message)
+	}
+
+	// Unregister it from Lighthouse
+	return lhkeystore.RemoveValidatorDefinition(filepath.Join(ks.keystorePath, LighthouseDefinitionsPath), pubkey)
+
+}
+
+// Add a remote signer entry for a validator to Lighthouse's validator definitions, if it doesn't have one
+func (ks *Keystore) addLighthouseDefinition(pubkey stadertypes.ValidatorPubkey) error {
+
+	// Load the existing definitions
+	definitionsPath := filepath.Join(ks.keystorePath, LighthouseDefinitionsPath)
+	definitions := []yaml.MapSlice{}
+	definitionsBytes, err := ioutil.ReadFile(definitionsPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Could not read Lighthouse validator definitions: %w", err)
+	}
+	if err == nil {
+		if err := yaml.Unmarshal(definitionsBytes, &definitions); err != nil {
+			return fmt.Errorf("Could not decode Lighthouse validator definitions: %w", err)
+		}
+	}
+
+	// Check for an existing entry
+	votingPublicKey := hexutil.AddPrefix(pubkey.Hex())
+	for _, definition := range definitions {
+		for _, item := range definition {
+			if item.Key == "voting_public_key" && item.Value == votingPublicKey {
+				return nil
+			}
+		}
+	}
+
+	// Add the entry
+	entryBytes, err := yaml.Marshal(lighthouseDefinition{
+		Enabled:         true,
+		VotingPublicKey: votingPublicKey,
+		Type:            "web3signer",
+		Url:             ks.client.URL(),
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode Lighthouse validator definition: %w", err)
+	}
+	var entry yaml.MapSlice
+	if err := yaml.Unmarshal(entryBytes, &entry); err != nil {
+		return fmt.Errorf("Could not encode Lighthouse validator definition: %w", err)
+	}
+	definitions = append(definitions, entry)
+
+	// Save the definitions
+	definitionsBytes, err = yaml.Marshal(definitions)
+	if err != nil {
+		return fmt.Errorf("Could not encode Lighthouse validator definitions: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(definitionsPath), DirMode); err != nil {
+		return fmt.Errorf("Could not create Lighthouse validator folder: %w", err)
+	}
+	if err := ioutil.WriteFile(definitionsPath, definitionsBytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write Lighthouse validator definitions: %w", err)
+	}
+	return nil
+
+}
@@ -29,15 +29,38 @@ import (
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // Get the node account
 func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
+	// The external signer knows the node account
+	if w.externalSigner != nil {
+		return accounts.Account{
+			Address: w.externalSigner.Address(),
+			URL: accounts.URL{
+				Scheme: "extapi",
+				Path:   w.externalSigner.URL(),
+			},
+		}, nil
+	}
+
+	// A watch-only wallet only knows the node account's address
+	if w.IsWatchOnly() {
+		return accounts.Account{
+			Address: *w.ws.WatchOnlyAddress,
+			URL: accounts.URL{
+				Scheme: "watch",
+			},
+		}, nil
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
-		return accounts.Account{}, errors.New("Wallet is not initialized")
+		return accounts.Account{}, w.errNotInitialized()
 	}
 
 	// Get private key
@@ -67,31 +90,71 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 // Get a transactor for the node account
 func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
-	// Check wallet is initialized
-	if !w.IsInitialized() {
-		return nil, errors.New("Wallet is not initialized")
-	}
+	// Create the transactor
+	var transactor *bind.TransactOpts
+	var err error
+	if w.txInterceptor != nil {
+		// The transaction is handed off instead of signed, so only the node account's address is needed.
+		// This works on watch-only wallets and never loads the node key.
+		nodeAccount, err := w.GetNodeAccount()
+		if err != nil {
+			return nil, err
+		}
+		transactor = &bind.TransactOpts{
+			From: nodeAccount.Address,
+			Signer: func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return nil, w.txInterceptor(from, tx)
+			},
+		}
+	} else if w.externalSigner != nil {
+		transactor = &bind.TransactOpts{
+			From: w.externalSigner.Address(),
+			Signer: func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				if from != w.externalSigner.Address() {
+					return nil, bind.ErrNotAuthorized
+				}
+				return w.externalSigner.SignTransaction(tx, w.chainID)
+			},
+		}
+	} else {
+		// Check wallet is initialized
+		if !w.IsInitialized() {
+			return nil, w.errNotInitialized()
+		}
 
-	// Get private key
-	privateKey, _, err := w.getNodePrivateKey()
-	if err != nil {
-		return nil, err
-	}
+		// Get private key
+		privateKey, _, err := w.getNodePrivateKey()
+		if err != nil {
+			return nil, err
+		}
 
-	// Create & return transactor
-	transactor, err := bind.NewKeyedTransactorWithChainID(privateKey, w.chainID)
+		transactor, err = bind.NewKeyedTransactorWithChainID(privateKey, w.chainID)
+		if err != nil {
+			return nil, err
+		}
+	}
 	transactor.GasFeeCap = w.maxFee
 	transactor.GasTipCap = w.maxPriorityFee
+	if w.maxFee == nil && w.feeEstimator != nil {
+		transactor.GasFeeCap, transactor.GasTipCap, err = w.feeEstimator(w.maxPriorityFee)
+		if err != nil {
+			return nil, fmt.Errorf("Could not estimate transaction fees: %w", err)
+		}
+	}
 	transactor.GasLimit = w.gasLimit
 	transactor.Context = context.Background()
-	return transactor, err
+	return transactor, nil
 
 }
 
 func (w *Wallet) GetNodePrivateKey() (*ecdsa.PrivateKey, error) {
+	if w.externalSigner != nil {
+		return nil, ErrExternalSigner
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
-		return nil, errors.New("Wallet is not initialized")
+		return nil, w.errNotInitialized()
 	}
 
 	// Get private key
@@ -105,10 +168,13 @@ func (w *Wallet) GetNodePrivateKey() (*ecdsa.PrivateKey, error) {
 
 // Get the node account private key bytes
 func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
+	if w.externalSigner != nil {
+		return nil, ErrExternalSigner
+	}
 
 	// Check wallet is initialized
 	if !w.IsInitialized() {
-		return nil, errors.New("Wallet is not initialized")
+		return nil, w.errNotInitialized()
 	}
 
 	// Get private key
@@ -130,6 +196,11 @@ func (w *Wallet) getNodePrivateKey() (*ecdsa.PrivateKey, string, error) {
 		return w.nodeKey, w.nodeKeyPath, nil
 	}
 
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, "", w.errNotInitialized()
+	}
+
 	// Get derived key
 	derivedKey, path, err := w.getNodeDerivedKey(w.ws.WalletIndex)
 	if err != nil {
@@ -0,0 +1,126 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/stader-labs/stader-node/shared/services/passwords"
+	"github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+)
+
+// Change the node password. The wallet store and the imported validator keys are re-encrypted with the new password,
+// and every validator key in the local client keystores is re-encrypted with a new random secret.
+// Every file is written next to the one it replaces before any of them is put in place, so if anything can't be
+// rewritten nothing changes, and a change interrupted by a crash is finished when the wallet is next opened.
+func (w *Wallet) ChangePassword(currentPassword string, newPassword string) error {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return w.errNotInitialized()
+	}
+
+	// Check the password can be changed by the node
+	if w.pm.IsReadOnly() {
+		return errors.New("The node password is kept outside of the node and can't be changed by it")
+	}
+
+	// Check the passwords
+	password, err := w.pm.GetPassword()
+	if err != nil {
+		return fmt.Errorf("Could not get wallet password: %w", err)
+	}
+	if currentPassword != password {
+		return errors.New("The current password is incorrect")
+	}
+	if newPassword == currentPassword {
+		return errors.New("The new password is the same as the current password")
+	}
+	if err := w.pm.CheckNewPassword(newPassword); err != nil {
+		return err
+	}
+
+	// Get every validator key while they can still be decrypted with the current password
+	validatorKeys, err := w.GetValidatorKeys(0, w.ws.NextAccount)
+	if err != nil {
+		return err
+	}
+	importedKeys, err := w.GetImportedValidatorKeys()
+	if err != nil {
+		return err
+	}
+
+	// Write every re-encrypted file next to the one it replaces, then put them all in place with the password last
+	stage := keystore.NewStage(w.getPasswordChangeJournalPath())
+	crypto := w.ws.Crypto
+	if err := w.stagePasswordChange(stage, newPassword, validatorKeys, importedKeys); err != nil {
+		stage.Discard()
+		w.ws.Crypto = crypto
+		return fmt.Errorf("Could not change the password, nothing was changed: %w", err)
+	}
+	if err := stage.Commit(); err != nil {
+		return fmt.Errorf("Could not finish changing the password; it will be finished when the wallet is next opened: %w", err)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Write the wallet, validator keys and password re-encrypted with a new password into a stage
+func (w *Wallet) stagePasswordChange(stage *keystore.Stage, newPassword string, validatorKeys []ValidatorKey, importedKeys []ValidatorKey) error {
+
+	// Re-encrypt the local client keystores with new secrets
+	allKeys := append(append([]ValidatorKey{}, validatorKeys...), importedKeys...)
+	for name := range w.keystores {
+		ks := w.keystores[name]
+		if ks.GetKeystoreDir() == "" {
+			continue
+		}
+		if sharedPasswordKeystore, ok := ks.(keystore.SharedPasswordKeystore); ok {
+			if err := sharedPasswordKeystore.ChangePassword(stage); err != nil {
+				return fmt.Errorf("could not change the password of the %s keystore: %w", name, err)
+			}
+			continue
+		}
+		stagingKeystore, ok := ks.(keystore.StagingKeystore)
+		if !ok {
+			return fmt.Errorf("the %s keystore can't be rewritten along with the wallet", name)
+		}
+		for _, key := range allKeys {
+			if err := stagingKeystore.StageValidatorKey(key.PrivateKey, key.DerivationPath, stage); err != nil {
+				return fmt.Errorf("could not store validator key %s in %s keystore: %w", key.PublicKey.Hex(), name, err)
+			}
+		}
+	}
+
+	// Re-encrypt the imported validator keys
+	for _, key := range importedKeys {
+		if err := w.writeImportedValidatorKey(key.PrivateKey, key.DerivationPath, newPassword, stage.WriteFile); err != nil {
+			return err
+		}
+	}
+
+	// Re-encrypt the wallet seed
+	encryptedSeed, err := w.encryptor.Encrypt(w.seed, newPassword)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt wallet seed: %w", err)
+	}
+	w.ws.Crypto = encryptedSeed
+	if err := w.writeStoreTo(stage.WriteFile); err != nil {
+		return err
+	}
+
+	// Replace the password last
+	if err := stage.WriteFile(w.pm.GetPasswordPath(), []byte(newPassword), passwords.FileMode); err != nil {
+		return fmt.Errorf("Could not write password to disk: %w", err)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the path of the journal an interrupted password change is finished from
+func (w *Wallet) getPasswordChangeJournalPath() string {
+	return w.walletPath + ".password-change"
+}
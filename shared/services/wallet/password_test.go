@@ -0,0 +1,107 @@
+package wallet
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+	lodestarkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/lodestar"
+	hexutil "github.com/stader-labs/stader-node/shared/utils/hex"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+// A keystore that can't store keys
+type failingKeystore struct {
+	dir string
+}
+
+func (ks *failingKeystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+	return errors.New("disk full")
+}
+
+func (ks *failingKeystore) StageValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string, stage *keystore.Stage) error {
+	return errors.New("disk full")
+}
+
+func (ks *failingKeystore) DeleteValidatorKey(pubkey types.ValidatorPubkey) error {
+	return nil
+}
+
+func (ks *failingKeystore) GetKeystoreDir() string {
+	return ks.dir
+}
+
+func TestChangePassword(t *testing.T) {
+	w, dir, pm := newTestWallet(t)
+	walletPath := filepath.Join(dir, "wallet")
+	if err := w.Save(); err != nil {
+		t.Fatal(err)
+	}
+	lodestar := lodestarkeystore.NewKeystore(filepath.Join(dir, "validators"), pm)
+	w.AddKeystore("lodestar", lodestar)
+	key, err := w.CreateValidatorKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Save(); err != nil {
+		t.Fatal(err)
+	}
+	pubkey := types.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	secretPath := filepath.Join(dir, "validators", lodestarkeystore.KeystoreDir, lodestarkeystore.SecretsDir, hexutil.AddPrefix(pubkey.Hex()))
+	oldSecret, err := ioutil.ReadFile(secretPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The current password must be right
+	if err := w.ChangePassword("wrong-node-password", "new-node-password"); err == nil {
+		t.Error("expected a wrong current password to be rejected")
+	}
+
+	// A failing keystore leaves every file unchanged
+	walletBytes, err := ioutil.ReadFile(walletPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.AddKeystore("failing", &failingKeystore{dir: filepath.Join(dir, "failing")})
+	if err := w.ChangePassword("node-password", "new-node-password"); err == nil {
+		t.Fatal("expected the failing keystore to stop the password change")
+	}
+	if password, _ := pm.GetPassword(); password != "node-password" {
+		t.Errorf("the password was changed, got %s", password)
+	}
+	if restored, _ := ioutil.ReadFile(walletPath); string(restored) != string(walletBytes) {
+		t.Error("the wallet was changed")
+	}
+	if restored, _ := ioutil.ReadFile(secretPath); string(restored) != string(oldSecret) {
+		t.Error("the lodestar keystore was changed")
+	}
+	if loadedKey, err := lodestar.LoadValidatorKey(pubkey); err != nil || loadedKey == nil {
+		t.Errorf("the lodestar key can't be loaded after the failed change: %v", err)
+	}
+
+	// Without it, the password is changed and everything is re-encrypted
+	delete(w.keystores, "failing")
+	if err := w.ChangePassword("node-password", "new-node-password"); err != nil {
+		t.Fatal(err)
+	}
+	if password, _ := pm.GetPassword(); password != "new-node-password" {
+		t.Errorf("the password was not changed, got %s", password)
+	}
+	if newSecret, _ := ioutil.ReadFile(secretPath); string(newSecret) == string(oldSecret) {
+		t.Error("the lodestar keystore secret was not rotated")
+	}
+	if loadedKey, err := lodestar.LoadValidatorKey(pubkey); err != nil || loadedKey == nil || string(loadedKey.Marshal()) != string(key.Marshal()) {
+		t.Errorf("the lodestar key can't be loaded after the password change: %v", err)
+	}
+
+	// The wallet opens with the new password
+	w = openTestWallet(t, dir, pm)
+	if !w.IsInitialized() {
+		t.Error("the wallet could not be decrypted with the new password")
+	}
+}
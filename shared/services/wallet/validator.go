@@ -21,11 +21,11 @@ package wallet
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"os"
 	"sync"
 
+	"github.com/stader-labs/stader-node/shared/services/wallet/keystore"
 	"github.com/stader-labs/stader-node/stader-lib/types"
 	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
@@ -51,7 +51,7 @@ func (w *Wallet) GetValidatorKeyCount() (uint, error) {
 
 	// Check wallet is initialized
 	if !w.IsInitialized() {
-		return 0, errors.New("Wallet is not initialized")
+		return 0, w.errNotInitialized()
 	}
 
 	// Return validator key count
@@ -64,7 +64,7 @@ func (w *Wallet) GetValidatorKeyAt(index uint) (*eth2types.BLSPrivateKey, error)
 
 	// Check wallet is initialized
 	if !w.IsInitialized() {
-		return nil, errors.New("Wallet is not initialized")
+		return nil, w.errNotInitialized()
 	}
 
 	// Return validator key
@@ -78,7 +78,7 @@ func (w *Wallet) GetValidatorKeyByPubkey(pubkey stadertypes.ValidatorPubkey) (*e
 
 	// Check wallet is initialized
 	if !w.IsInitialized() {
-		return nil, errors.New("Wallet is not initialized")
+		return nil, w.errNotInitialized()
 	}
 
 	// Get pubkey hex string
@@ -93,6 +93,12 @@ func (w *Wallet) GetValidatorKeyByPubkey(pubkey stadertypes.ValidatorPubkey) (*e
 		}
 	}
 
+	// Check for an imported validator key
+	if w.IsImportedValidatorKey(pubkey) {
+		key, _, err := w.loadImportedValidatorKey(pubkey)
+		return key, err
+	}
+
 	// Find matching validator key
 	var index uint
 	var validatorKey *eth2types.BLSPrivateKey
@@ -121,7 +127,7 @@ func (w *Wallet) GetValidatorKeyByPubkey(pubkey stadertypes.ValidatorPubkey) (*e
 func (w *Wallet) CreateValidatorKeyFromIndex(index uint) (*eth2types.BLSPrivateKey, error) {
 	// Check wallet is initialized
 	if !w.IsInitialized() {
-		return nil, errors.New("Wallet is not initialized")
+		return nil, w.errNotInitialized()
 	}
 
 	// Get validator key
@@ -139,7 +145,7 @@ func (w *Wallet) CreateValidatorKey() (*eth2types.BLSPrivateKey, error) {
 
 	// Check wallet is initialized
 	if !w.IsInitialized() {
-		return nil, errors.New("Wallet is not initialized")
+		return nil, w.errNotInitialized()
 	}
 
 	// Get & increment account index
@@ -177,6 +183,73 @@ func (w *Wallet) StoreValidatorKey(key *eth2types.BLSPrivateKey, path string) er
 
 }
 
+// Remove a validator key from the keystores and the wallet, so it's no longer validated with. Keys derived from the
+// seed can't be taken out of it, so they're recorded as removed and kept out of the keystores from then on.
+func (w *Wallet) RemoveValidatorKey(pubkey stadertypes.ValidatorPubkey) error {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return w.errNotInitialized()
+	}
+
+	// Delete it from the keystores
+	for name := range w.keystores {
+		if err := w.keystores[name].DeleteValidatorKey(pubkey); err != nil {
+			return fmt.Errorf("could not delete validator key %s from %s keystore: %w", pubkey.Hex(), name, err)
+		}
+	}
+
+	// Delete an imported key
+	if w.IsImportedValidatorKey(pubkey) {
+		if err := os.Remove(w.getImportedValidatorKeyPath(pubkey)); err != nil {
+			return fmt.Errorf("Could not delete imported validator key %s: %w", pubkey.Hex(), err)
+		}
+		delete(w.importedValidatorKeys, pubkey.Hex())
+	}
+
+	// Record it as removed
+	delete(w.validatorKeyIndices, pubkey.Hex())
+	if !w.IsRemovedValidatorKey(pubkey) {
+		w.ws.RemovedValidatorKeys = append(w.ws.RemovedValidatorKeys, pubkey.Hex())
+	}
+	return w.Save()
+
+}
+
+// Delete validator keys from the keystores that load keys into the running Validator client, returning the EIP-3076
+// slashing protection history the Validator client had for them. Any history is returned along with an error, since
+// some keys may already be deleted.
+func (w *Wallet) DeleteLiveValidatorKeys(pubkeys []stadertypes.ValidatorPubkey) ([]byte, error) {
+	var slashingProtection []byte
+	for name := range w.keystores {
+		liveKeystore, ok := w.keystores[name].(keystore.LiveKeystore)
+		if !ok {
+			continue
+		}
+		history, err := liveKeystore.DeleteValidatorKeys(pubkeys)
+		if len(history) > 0 {
+			slashingProtection = history
+		}
+		if err != nil {
+			return slashingProtection, fmt.Errorf("could not delete validator keys from %s keystore: %w", name, err)
+		}
+	}
+	return slashingProtection, nil
+}
+
+// Check if a validator key was removed from the wallet
+func (w *Wallet) IsRemovedValidatorKey(pubkey stadertypes.ValidatorPubkey) bool {
+	if w.ws == nil {
+		return false
+	}
+	for _, removed := range w.ws.RemovedValidatorKeys {
+		if removed == pubkey.Hex() {
+			return true
+		}
+	}
+	return false
+}
+
 // Deletes all of the keystore directories and persistent VC storage
 func (w *Wallet) DeleteValidatorStores() error {
 
@@ -197,7 +270,7 @@ func (w *Wallet) GetNextValidatorKey() (*eth2types.BLSPrivateKey, error) {
 
 	// Check wallet is initialized
 	if !w.IsInitialized() {
-		return nil, errors.New("Wallet is not initialized")
+		return nil, w.errNotInitialized()
 	}
 
 	// Get account index
@@ -214,12 +287,12 @@ func (w *Wallet) GetNextValidatorKey() (*eth2types.BLSPrivateKey, error) {
 
 }
 
-// Recover a set of validator keys by their public key
+// Recover a set of validator keys by their public key. Keys that were removed are skipped.
 func (w *Wallet) GetValidatorKeys(startIndex uint, length uint) ([]ValidatorKey, error) {
 
 	// Check wallet is initialized
 	if !w.IsInitialized() {
-		return nil, errors.New("Wallet is not initialized")
+		return nil, w.errNotInitialized()
 	}
 
 	validatorKeys := make([]ValidatorKey, 0, length)
@@ -234,6 +307,9 @@ func (w *Wallet) GetValidatorKeys(startIndex uint, length uint) ([]ValidatorKey,
 			DerivationPath: path,
 			WalletIndex:    index,
 		}
+		if w.IsRemovedValidatorKey(validatorKey.PublicKey) {
+			continue
+		}
 		validatorKeys = append(validatorKeys, validatorKey)
 	}
 
@@ -241,11 +317,17 @@ func (w *Wallet) GetValidatorKeys(startIndex uint, length uint) ([]ValidatorKey,
 
 }
 
-// Save a validator key
+// Save a validator key. Keys derived with a path other than the default one can't be found by index later,
+// so they're saved with the imported keys instead.
 func (w *Wallet) SaveValidatorKey(key ValidatorKey) error {
 
-	// Update account index
-	if key.WalletIndex >= w.ws.NextAccount {
+	// Save keys at other paths with the imported keys
+	if key.DerivationPath != fmt.Sprintf(ValidatorKeyPath, key.WalletIndex) {
+		if err := w.saveImportedValidatorKey(key.PrivateKey, key.DerivationPath); err != nil {
+			return err
+		}
+	} else if key.WalletIndex >= w.ws.NextAccount {
+		// Update account index
 		w.ws.NextAccount = key.WalletIndex + 1
 	}
 
@@ -267,7 +349,7 @@ func (w *Wallet) RecoverValidatorKey(pubkey stadertypes.ValidatorPubkey, startIn
 
 	// Check wallet is initialized
 	if !w.IsInitialized() {
-		return 0, errors.New("Wallet is not initialized")
+		return 0, w.errNotInitialized()
 	}
 
 	// Find matching validator key
@@ -313,7 +395,7 @@ func (w *Wallet) TestRecoverValidatorKey(pubkey stadertypes.ValidatorPubkey, sta
 
 	// Check wallet is initialized
 	if !w.IsInitialized() {
-		return 0, errors.New("Wallet is not initialized")
+		return 0, w.errNotInitialized()
 	}
 
 	// Find matching validator key
@@ -340,10 +422,20 @@ func (w *Wallet) TestRecoverValidatorKey(pubkey stadertypes.ValidatorPubkey, sta
 
 // Create a new validator key
 func (w *Wallet) RebuildLodestarValidatorKeys() error {
+	// Keys loaded live through the key manager API don't need rebuilding
+	if w.LoadsKeysLive() {
+		return nil
+	}
+
 	keys, err := w.GetValidatorKeys(0, w.ws.NextAccount)
 	if err != nil {
 		return err
 	}
+	importedKeys, err := w.GetImportedValidatorKeys()
+	if err != nil {
+		return err
+	}
+	keys = append(keys, importedKeys...)
 
 	lodestarStore, ok := w.keystores["lodestar"]
 	if !ok {
@@ -358,6 +450,37 @@ func (w *Wallet) RebuildLodestarValidatorKeys() error {
 	return nil
 }
 
+// Derive the validator key at an index of a derivation path format such as ValidatorKeyPath.
+// The key isn't cached, so this is safe to call concurrently.
+func (w *Wallet) DeriveValidatorKey(pathFormat string, index uint) (ValidatorKey, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return ValidatorKey{}, w.errNotInitialized()
+	}
+
+	// Initialize BLS support
+	if err := initializeBLS(); err != nil {
+		return ValidatorKey{}, fmt.Errorf("Could not initialize BLS library: %w", err)
+	}
+
+	// Get private key
+	derivationPath := fmt.Sprintf(pathFormat, index)
+	privateKey, err := eth2util.PrivateKeyFromSeedAndPath(w.seed, derivationPath)
+	if err != nil {
+		return ValidatorKey{}, fmt.Errorf("Could not get validator private key at %s: %w", derivationPath, err)
+	}
+
+	// Return
+	return ValidatorKey{
+		PublicKey:      types.BytesToValidatorPubkey(privateKey.PublicKey().Marshal()),
+		PrivateKey:     privateKey,
+		DerivationPath: derivationPath,
+		WalletIndex:    index,
+	}, nil
+
+}
+
 // Get a validator private key by index
 func (w *Wallet) getValidatorPrivateKey(index uint) (*eth2types.BLSPrivateKey, string, error) {
 
@@ -0,0 +1,58 @@
+package wallet
+
+import (
+	"path/filepath"
+	"testing"
+
+	lodestarkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/lodestar"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+func TestRemoveValidatorKey(t *testing.T) {
+	w, dir, pm := newTestWallet(t)
+	lodestar := lodestarkeystore.NewKeystore(filepath.Join(dir, "validators"), pm)
+	w.AddKeystore("lodestar", lodestar)
+	removedKey, err := w.CreateValidatorKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keptKey, err := w.CreateValidatorKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Save(); err != nil {
+		t.Fatal(err)
+	}
+	removed := types.BytesToValidatorPubkey(removedKey.PublicKey().Marshal())
+	kept := types.BytesToValidatorPubkey(keptKey.PublicKey().Marshal())
+
+	if err := w.RemoveValidatorKey(removed); err != nil {
+		t.Fatal(err)
+	}
+	if key, err := lodestar.LoadValidatorKey(removed); err != nil || key != nil {
+		t.Errorf("the removed key is still in the lodestar keystore: %v", err)
+	}
+	if key, err := lodestar.LoadValidatorKey(kept); err != nil || key == nil {
+		t.Errorf("the other key was removed from the lodestar keystore: %v", err)
+	}
+
+	// The removal survives a reload, and the removed key isn't stored again
+	w = openTestWallet(t, dir, pm)
+	w.AddKeystore("lodestar", lodestar)
+	if !w.IsRemovedValidatorKey(removed) || w.IsRemovedValidatorKey(kept) {
+		t.Error("the removed key was not recorded")
+	}
+	keys, err := w.GetValidatorKeys(0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0].PublicKey != kept {
+		t.Errorf("expected only the kept key, got %d keys", len(keys))
+	}
+	if err := w.RebuildLodestarValidatorKeys(); err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := lodestar.LoadValidatorKey(removed); key != nil {
+		t.Error("the removed key was stored again by the rebuild")
+	}
+}
@@ -21,6 +21,7 @@ package wallet
 
 import (
 	"crypto/ecdsa"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -31,8 +32,10 @@ import (
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/google/uuid"
 	"github.com/tyler-smith/go-bip39"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
@@ -40,6 +43,7 @@ import (
 
 	"github.com/stader-labs/stader-node/shared/services/passwords"
 	"github.com/stader-labs/stader-node/shared/services/wallet/keystore"
+	"github.com/stader-labs/stader-node/shared/utils/signature"
 )
 
 // Config
@@ -72,8 +76,9 @@ type Wallet struct {
 	nodeKeyPath string
 
 	// Validator key caches
-	validatorKeys       map[uint]*eth2types.BLSPrivateKey
-	validatorKeyIndices map[string]uint
+	validatorKeys         map[uint]*eth2types.BLSPrivateKey
+	validatorKeyIndices   map[string]uint
+	importedValidatorKeys map[string]*eth2types.BLSPrivateKey
 
 	// Keystores
 	keystores map[string]keystore.Keystore
@@ -82,8 +87,24 @@ type Wallet struct {
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
+
+	// Suggests fees when no max fee was configured
+	feeEstimator FeeEstimator
+
+	// Handles transactions in place of signing them
+	txInterceptor TxInterceptor
+
+	// Signs for the node account in place of the derived key
+	externalSigner *ExternalSigner
 }
 
+// Suggests a max fee and priority fee for a transaction. The requested priority fee is used if it isn't nil.
+type FeeEstimator func(maxPriorityFee *big.Int) (*big.Int, *big.Int, error)
+
+// Handles an unsigned transaction instead of letting the transactor sign it, such as simulating it for a dry run.
+// The returned error stops the transaction from being sent.
+type TxInterceptor func(from common.Address, tx *types.Transaction) error
+
 // Encrypted wallet store
 type walletStore struct {
 	Crypto         map[string]interface{} `json:"crypto"`
@@ -93,6 +114,12 @@ type walletStore struct {
 	DerivationPath string                 `json:"derivationPath,omitempty"`
 	WalletIndex    uint                   `json:"walletIndex,omitempty"`
 	NextAccount    uint                   `json:"next_account"`
+
+	// The operator address of a watch-only wallet, which has no seed
+	WatchOnlyAddress *common.Address `json:"watchOnlyAddress,omitempty"`
+
+	// Validator keys derived from the seed that were removed, which aren't stored in the keystores again
+	RemovedValidatorKeys []string `json:"removedValidatorKeys,omitempty"`
 }
 
 // Create new wallet
@@ -100,19 +127,23 @@ func NewWallet(walletPath string, chainId uint, maxFee *big.Int, maxPriorityFee
 
 	// Initialize wallet
 	w := &Wallet{
-		walletPath:          walletPath,
-		pm:                  passwordManager,
-		encryptor:           eth2ks.New(),
-		chainID:             big.NewInt(int64(chainId)),
-		validatorKeys:       map[uint]*eth2types.BLSPrivateKey{},
-		validatorKeyIndices: map[string]uint{},
-		keystores:           map[string]keystore.Keystore{},
-		maxFee:              maxFee,
-		maxPriorityFee:      maxPriorityFee,
-		gasLimit:            gasLimit,
+		walletPath:            walletPath,
+		pm:                    passwordManager,
+		encryptor:             eth2ks.New(),
+		chainID:               big.NewInt(int64(chainId)),
+		validatorKeys:         map[uint]*eth2types.BLSPrivateKey{},
+		validatorKeyIndices:   map[string]uint{},
+		importedValidatorKeys: map[string]*eth2types.BLSPrivateKey{},
+		keystores:             map[string]keystore.Keystore{},
+		maxFee:                maxFee,
+		maxPriorityFee:        maxPriorityFee,
+		gasLimit:              gasLimit,
 	}
 
 	// Load & decrypt wallet store
+	if err := keystore.ResumeStage(w.getPasswordChangeJournalPath()); err != nil {
+		return nil, fmt.Errorf("Could not finish an interrupted password change: %w", err)
+	}
 	if _, err := w.loadStore(); err != nil {
 		return nil, err
 	}
@@ -122,6 +153,26 @@ func NewWallet(walletPath string, chainId uint, maxFee *big.Int, maxPriorityFee
 
 }
 
+// Set the estimator used for transactions when no max fee was configured
+func (w *Wallet) SetFeeEstimator(feeEstimator FeeEstimator) {
+	w.feeEstimator = feeEstimator
+}
+
+// Set the interceptor the node account's transactors hand transactions to instead of signing them
+func (w *Wallet) SetTxInterceptor(txInterceptor TxInterceptor) {
+	w.txInterceptor = txInterceptor
+}
+
+// Set the external signer that holds the node account's key
+func (w *Wallet) SetExternalSigner(externalSigner *ExternalSigner) {
+	w.externalSigner = externalSigner
+}
+
+// Check if the node account's key is held by an external signer
+func (w *Wallet) HasExternalSigner() bool {
+	return w.externalSigner != nil
+}
+
 // Gets the wallet's chain ID
 func (w *Wallet) GetChainID() *big.Int {
 	copy := big.NewInt(0).Set(w.chainID)
@@ -133,6 +184,20 @@ func (w *Wallet) AddKeystore(name string, ks keystore.Keystore) {
 	w.keystores[name] = ks
 }
 
+// Check whether the wallet's keystores load validator keys into the running Validator client, so it doesn't need to be
+// restarted when keys are added
+func (w *Wallet) LoadsKeysLive() bool {
+	if len(w.keystores) == 0 {
+		return false
+	}
+	for _, ks := range w.keystores {
+		if _, ok := ks.(keystore.LiveKeystore); !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // Check if the wallet has been initialized
 func (w *Wallet) IsInitialized() bool {
 	return (w.ws != nil && w.seed != nil && w.mk != nil)
@@ -151,7 +216,7 @@ func (w *Wallet) String() (string, error) {
 
 	// Check wallet is initialized
 	if !w.IsInitialized() {
-		return "", errors.New("Wallet is not initialized")
+		return "", w.errNotInitialized()
 	}
 
 	// Encode wallet store
@@ -251,27 +316,35 @@ func (w *Wallet) TestRecovery(derivationPath string, walletIndex uint, mnemonic
 
 }
 
-// Save the wallet store to disk
-func (w *Wallet) Save() error {
+// Check whether a mnemonic is the one the wallet was created from
+func (w *Wallet) MatchesMnemonic(mnemonic string) (bool, error) {
 
 	// Check wallet is initialized
 	if !w.IsInitialized() {
-		return errors.New("Wallet is not initialized")
+		return false, w.errNotInitialized()
 	}
 
-	// Encode wallet store
-	wsBytes, err := json.Marshal(w.ws)
-	if err != nil {
-		return fmt.Errorf("Could not encode wallet: %w", err)
+	// Check mnemonic
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return false, nil
 	}
 
-	// Write wallet store to disk
-	if err := ioutil.WriteFile(w.walletPath, wsBytes, FileMode); err != nil {
-		return fmt.Errorf("Could not write wallet to disk: %w", err)
+	// Compare the seeds
+	seed := bip39.NewSeed(mnemonic, "")
+	return subtle.ConstantTimeCompare(seed, w.seed) == 1, nil
+
+}
+
+// Save the wallet store to disk
+func (w *Wallet) Save() error {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return w.errNotInitialized()
 	}
 
-	// Return
-	return nil
+	// Write wallet store to disk
+	return w.writeStore()
 
 }
 
@@ -294,22 +367,30 @@ func (w *Wallet) Delete() error {
 
 // Signs a serialized TX using the wallet's private key
 func (w *Wallet) Sign(serializedTx []byte) ([]byte, error) {
-	// Get private key
-	privateKey, _, err := w.getNodePrivateKey()
-	if err != nil {
-		return nil, err
-	}
-
 	tx := types.Transaction{}
-	err = tx.UnmarshalBinary(serializedTx)
+	err := tx.UnmarshalBinary(serializedTx)
 	if err != nil {
 		return nil, fmt.Errorf("Error unmarshalling TX: %w", err)
 	}
 
-	signer := types.NewLondonSigner(w.chainID)
-	signedTx, err := types.SignTx(&tx, signer, privateKey)
-	if err != nil {
-		return nil, fmt.Errorf("Error signing TX: %w", err)
+	var signedTx *types.Transaction
+	if w.externalSigner != nil {
+		signedTx, err = w.externalSigner.SignTransaction(&tx, w.chainID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Get private key
+		privateKey, _, err := w.getNodePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		signer := types.NewLondonSigner(w.chainID)
+		signedTx, err = types.SignTx(&tx, signer, privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("Error signing TX: %w", err)
+		}
 	}
 
 	signedData, err := signedTx.MarshalBinary()
@@ -322,6 +403,10 @@ func (w *Wallet) Sign(serializedTx []byte) ([]byte, error) {
 
 // Signs an arbitrary message using the wallet's private key
 func (w *Wallet) SignMessage(message string) ([]byte, error) {
+	if w.externalSigner != nil {
+		return w.externalSigner.SignText([]byte(message))
+	}
+
 	// Get the wallet's private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
@@ -339,6 +424,32 @@ func (w *Wallet) SignMessage(message string) ([]byte, error) {
 	return signedMessage, nil
 }
 
+// Signs an EIP-712 typed data document using the wallet's private key
+func (w *Wallet) SignTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	if w.externalSigner != nil {
+		return w.externalSigner.SignTypedData(typedData)
+	}
+
+	// Get the wallet's private key
+	privateKey, _, err := w.getNodePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	typedDataHash, err := signature.HashTypedData(typedData)
+	if err != nil {
+		return nil, err
+	}
+	signedData, err := crypto.Sign(typedDataHash, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error signing typed data: %w", err)
+	}
+
+	// fix the ECDSA 'v' as for messages
+	signedData[crypto.RecoveryIDOffset] += 27
+	return signedData, nil
+}
+
 // Reloads wallet from disk
 func (w *Wallet) Reload() error {
 	_, err := w.loadStore()
@@ -360,6 +471,13 @@ func (w *Wallet) loadStore() (bool, error) {
 		return false, fmt.Errorf("Could not decode wallet: %w", err)
 	}
 
+	// Watch-only wallets have no seed to decrypt
+	if w.ws.WatchOnlyAddress != nil {
+		w.seed = nil
+		w.mk = nil
+		return false, nil
+	}
+
 	// Upgrade legacy wallets to include derivation paths
 	if w.ws.DerivationPath == "" {
 		w.ws.DerivationPath = DefaultNodeKeyPath
@@ -388,6 +506,30 @@ func (w *Wallet) loadStore() (bool, error) {
 
 }
 
+// Encode the wallet store and write it to disk
+func (w *Wallet) writeStore() error {
+	return w.writeStoreTo(ioutil.WriteFile)
+}
+
+// Encode the wallet store and write it
+func (w *Wallet) writeStoreTo(writeFile keystore.FileWriter) error {
+
+	// Encode wallet store
+	wsBytes, err := json.Marshal(w.ws)
+	if err != nil {
+		return fmt.Errorf("Could not encode wallet: %w", err)
+	}
+
+	// Write wallet store to disk
+	if err := writeFile(w.walletPath, wsBytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write wallet to disk: %w", err)
+	}
+
+	// Return
+	return nil
+
+}
+
 // Initialize the encrypted wallet store from a mnemonic
 func (w *Wallet) initializeStore(derivationPath string, walletIndex uint, mnemonic string) error {
 
@@ -0,0 +1,32 @@
+package wallet
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/stader-labs/stader-node/shared/services/passwords"
+)
+
+// Create a wallet with a node key in a temporary directory, returning the directory and the password manager so it can be reopened
+func newTestWallet(t *testing.T) (*Wallet, string, *passwords.PasswordManager) {
+	dir := t.TempDir()
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	if err := pm.SetPassword("node-password"); err != nil {
+		t.Fatal(err)
+	}
+	w := openTestWallet(t, dir, pm)
+	if _, err := w.Initialize(DefaultNodeKeyPath, 0); err != nil {
+		t.Fatal(err)
+	}
+	return w, dir, pm
+}
+
+// Open the wallet in a test directory
+func openTestWallet(t *testing.T, dir string, pm *passwords.PasswordManager) *Wallet {
+	w, err := NewWallet(filepath.Join(dir, "wallet"), 5, big.NewInt(30e9), big.NewInt(1e9), 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
@@ -0,0 +1,44 @@
+package wallet
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+// Returned when something needs a key from a wallet that only knows the operator address
+var ErrWatchOnly = errors.New("The node wallet is watch-only: it only knows the operator address and can't sign. Please run 'stader-cli wallet recover' on a node that needs to sign.")
+
+// Initialize the wallet with only an operator address, for nodes that monitor an operator without holding its keys.
+// A watch-only wallet has no password and can't sign; it can be replaced by recovering the full wallet.
+func (w *Wallet) InitializeWatchOnly(address common.Address) error {
+
+	// Check wallet is not initialized
+	if w.IsInitialized() || w.IsWatchOnly() {
+		return errors.New("Wallet is already initialized")
+	}
+
+	// Create & save the wallet store
+	w.ws = &walletStore{
+		Name:             w.encryptor.Name(),
+		Version:          w.encryptor.Version(),
+		UUID:             uuid.New(),
+		WatchOnlyAddress: &address,
+	}
+	return w.writeStore()
+
+}
+
+// Check if the wallet only knows the operator address
+func (w *Wallet) IsWatchOnly() bool {
+	return w.ws != nil && w.ws.WatchOnlyAddress != nil && !w.IsInitialized()
+}
+
+// Get the error for something that needs an initialized wallet
+func (w *Wallet) errNotInitialized() error {
+	if w.IsWatchOnly() {
+		return ErrWatchOnly
+	}
+	return errors.New("Wallet is not initialized")
+}
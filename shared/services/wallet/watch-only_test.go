@@ -0,0 +1,98 @@
+package wallet
+
+import (
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/stader-labs/stader-node/shared/services/passwords"
+)
+
+func TestWatchOnlyWallet(t *testing.T) {
+	dir := t.TempDir()
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	address := common.HexToAddress("0x00000000000000000000000000000000000beef0")
+
+	w := openTestWallet(t, dir, pm)
+	if err := w.InitializeWatchOnly(address); err != nil {
+		t.Fatal(err)
+	}
+
+	// It loads without a password and only knows the address
+	w = openTestWallet(t, dir, pm)
+	if !w.IsWatchOnly() || w.IsInitialized() {
+		t.Fatal("expected the wallet to be watch-only")
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nodeAccount.Address != address {
+		t.Errorf("expected node account %s, got %s", address.Hex(), nodeAccount.Address.Hex())
+	}
+	if err := w.InitializeWatchOnly(address); err == nil {
+		t.Error("expected a second watch-only initialization to be rejected")
+	}
+
+	// Anything that needs a key fails clearly
+	if _, err := w.GetNodeAccountTransactor(); !errors.Is(err, ErrWatchOnly) {
+		t.Errorf("expected the transactor to fail with ErrWatchOnly, got %v", err)
+	}
+	if _, err := w.SignMessage("hello"); !errors.Is(err, ErrWatchOnly) {
+		t.Errorf("expected signing to fail with ErrWatchOnly, got %v", err)
+	}
+	if _, err := w.GetValidatorKeyAt(0); !errors.Is(err, ErrWatchOnly) {
+		t.Errorf("expected getting a validator key to fail with ErrWatchOnly, got %v", err)
+	}
+
+	// Recovering the full wallet replaces it
+	if err := pm.SetPassword("node-password"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Recover(DefaultNodeKeyPath, 0, "test test test test test test test test test test test junk"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Save(); err != nil {
+		t.Fatal(err)
+	}
+	w = openTestWallet(t, dir, pm)
+	if w.IsWatchOnly() || !w.IsInitialized() {
+		t.Error("expected the recovered wallet to replace the watch-only one")
+	}
+}
+
+func TestWatchOnlyExportUnsignedTx(t *testing.T) {
+	dir := t.TempDir()
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	address := common.HexToAddress("0x00000000000000000000000000000000000beef0")
+	w := openTestWallet(t, dir, pm)
+	if err := w.InitializeWatchOnly(address); err != nil {
+		t.Fatal(err)
+	}
+
+	// The interceptor gets the unsigned transaction from the operator address
+	errExported := errors.New("exported")
+	var exportedFrom common.Address
+	w.SetTxInterceptor(func(from common.Address, tx *types.Transaction) error {
+		exportedFrom = from
+		return errExported
+	})
+	transactor, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		t.Fatalf("expected a transactor for exporting from a watch-only wallet, got %v", err)
+	}
+	if transactor.From != address {
+		t.Errorf("expected the transactor to be from %s, got %s", address.Hex(), transactor.From.Hex())
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(5), Gas: 21000, To: &address})
+	if _, err := transactor.Signer(address, tx); !errors.Is(err, errExported) {
+		t.Errorf("expected the transaction to be handed to the interceptor, got %v", err)
+	}
+	if exportedFrom != address {
+		t.Errorf("expected the transaction to be exported from %s, got %s", address.Hex(), exportedFrom.Hex())
+	}
+}
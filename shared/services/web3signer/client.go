@@ -0,0 +1,252 @@
+package web3signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	hexutils "github.com/stader-labs/stader-node/shared/utils/hex"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+// Config
+const (
+	RequestTimeout = 30 * time.Second
+
+	publicKeysPath = "/api/v1/eth2/publicKeys"
+	signPath       = "/api/v1/eth2/sign/%s"
+	keystoresPath  = "/eth/v1/keystores"
+)
+
+// A Web3Signer that holds validator keys and signs with them
+type Client struct {
+	url    string
+	client *http.Client
+}
+
+// The fork a message is signed for
+type ForkInfo struct {
+	PreviousVersion       []byte
+	CurrentVersion        []byte
+	Epoch                 uint64
+	GenesisValidatorsRoot []byte
+}
+
+// The result of importing a keystore
+type ImportStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// Request types
+type forkInfoRequest struct {
+	Fork struct {
+		PreviousVersion hexutil.Bytes `json:"previous_version"`
+		CurrentVersion  hexutil.Bytes `json:"current_version"`
+		Epoch           string        `json:"epoch"`
+	} `json:"fork"`
+	GenesisValidatorsRoot hexutil.Bytes `json:"genesis_validators_root"`
+}
+type voluntaryExitRequest struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+}
+type depositRequest struct {
+	Pubkey                hexutil.Bytes `json:"pubkey"`
+	WithdrawalCredentials hexutil.Bytes `json:"withdrawal_credentials"`
+	Amount                string        `json:"amount"`
+	GenesisForkVersion    hexutil.Bytes `json:"genesis_fork_version"`
+}
+type signRequest struct {
+	Type          string                `json:"type"`
+	ForkInfo      *forkInfoRequest      `json:"fork_info,omitempty"`
+	SigningRoot   hexutil.Bytes         `json:"signingRoot"`
+	VoluntaryExit *voluntaryExitRequest `json:"voluntary_exit,omitempty"`
+	Deposit       *depositRequest       `json:"deposit,omitempty"`
+}
+type importKeystoresRequest struct {
+	Keystores []string `json:"keystores"`
+	Passwords []string `json:"passwords"`
+}
+type deleteKeystoresRequest struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+
+// Response types
+type signResponse struct {
+	Signature hexutil.Bytes `json:"signature"`
+}
+type importKeystoresResponse struct {
+	Data []ImportStatus `json:"data"`
+}
+type deleteKeystoresResponse struct {
+	Data               []ImportStatus `json:"data"`
+	SlashingProtection string         `json:"slashing_protection"`
+}
+
+// Create a new client for the Web3Signer at the URL
+func NewClient(url string) *Client {
+	return &Client{
+		url:    strings.TrimSuffix(url, "/"),
+		client: &http.Client{Timeout: RequestTimeout},
+	}
+}
+
+// Get the Web3Signer's URL
+func (c *Client) URL() string {
+	return c.url
+}
+
+// Get the public keys of the validators the Web3Signer holds
+func (c *Client) GetPublicKeys() ([]types.ValidatorPubkey, error) {
+	responseBody, err := c.request(http.MethodGet, publicKeysPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get the Web3Signer's public keys: %w", err)
+	}
+	var pubkeyStrings []string
+	if err := json.Unmarshal(responseBody, &pubkeyStrings); err != nil {
+		return nil, fmt.Errorf("Could not decode the Web3Signer's public keys: %w", err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, len(pubkeyStrings))
+	for i, pubkeyString := range pubkeyStrings {
+		pubkey, err := types.HexToValidatorPubkey(hexutils.RemovePrefix(pubkeyString))
+		if err != nil {
+			return nil, fmt.Errorf("The Web3Signer returned an invalid public key %s: %w", pubkeyString, err)
+		}
+		pubkeys[i] = pubkey
+	}
+	return pubkeys, nil
+}
+
+// Import EIP-2335 keystores into the Web3Signer through its key manager API
+func (c *Client) ImportKeystores(keystores []string, passwords []string) ([]ImportStatus, error) {
+	responseBody, err := c.request(http.MethodPost, keystoresPath, importKeystoresRequest{
+		Keystores: keystores,
+		Passwords: passwords,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not import keystores into the Web3Signer: %w", err)
+	}
+	var response importKeystoresResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode the Web3Signer's import response: %w", err)
+	}
+	return response.Data, nil
+}
+
+// Delete validator keys from the Web3Signer through its key manager API, returning their EIP-3076 slashing protection history
+func (c *Client) DeleteKeystores(pubkeys []types.ValidatorPubkey) ([]ImportStatus, string, error) {
+	pubkeyStrings := make([]string, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		pubkeyStrings[i] = hexutil.Encode(pubkey.Bytes())
+	}
+	responseBody, err := c.request(http.MethodDelete, keystoresPath, deleteKeystoresRequest{
+		Pubkeys: pubkeyStrings,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not delete keystores from the Web3Signer: %w", err)
+	}
+	var response deleteKeystoresResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, "", fmt.Errorf("Could not decode the Web3Signer's delete response: %w", err)
+	}
+	return response.Data, response.SlashingProtection, nil
+}
+
+// Sign a voluntary exit for a validator
+func (c *Client) SignVoluntaryExit(pubkey types.ValidatorPubkey, signingRoot [32]byte, epoch uint64, validatorIndex uint64, fork ForkInfo) (types.ValidatorSignature, error) {
+	forkInfo := &forkInfoRequest{GenesisValidatorsRoot: fork.GenesisValidatorsRoot}
+	forkInfo.Fork.PreviousVersion = fork.PreviousVersion
+	forkInfo.Fork.CurrentVersion = fork.CurrentVersion
+	forkInfo.Fork.Epoch = fmt.Sprint(fork.Epoch)
+	return c.sign(pubkey, signRequest{
+		Type:        "VOLUNTARY_EXIT",
+		ForkInfo:    forkInfo,
+		SigningRoot: signingRoot[:],
+		VoluntaryExit: &voluntaryExitRequest{
+			Epoch:          fmt.Sprint(epoch),
+			ValidatorIndex: fmt.Sprint(validatorIndex),
+		},
+	})
+}
+
+// Sign a deposit for a validator
+func (c *Client) SignDeposit(pubkey types.ValidatorPubkey, signingRoot [32]byte, withdrawalCredentials []byte, amount uint64, genesisForkVersion []byte) (types.ValidatorSignature, error) {
+	return c.sign(pubkey, signRequest{
+		Type:        "DEPOSIT",
+		SigningRoot: signingRoot[:],
+		Deposit: &depositRequest{
+			Pubkey:                pubkey.Bytes(),
+			WithdrawalCredentials: withdrawalCredentials,
+			Amount:                fmt.Sprint(amount),
+			GenesisForkVersion:    genesisForkVersion,
+		},
+	})
+}
+
+// Sign a message with a validator's key
+func (c *Client) sign(pubkey types.ValidatorPubkey, request signRequest) (types.ValidatorSignature, error) {
+	responseBody, err := c.request(http.MethodPost, fmt.Sprintf(signPath, hexutil.Encode(pubkey.Bytes())), request)
+	if err != nil {
+		return types.ValidatorSignature{}, fmt.Errorf("The Web3Signer could not sign the %s message for validator %s: %w", strings.ToLower(request.Type), pubkey.Hex(), err)
+	}
+
+	// Web3Signer answers with the bare signature unless asked for JSON, so accept both
+	var signature hexutil.Bytes
+	trimmed := bytes.TrimSpace(responseBody)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		var response signResponse
+		if err := json.Unmarshal(trimmed, &response); err != nil {
+			return types.ValidatorSignature{}, fmt.Errorf("Could not decode the Web3Signer's signature: %w", err)
+		}
+		signature = response.Signature
+	} else if signature, err = hexutil.Decode(string(trimmed)); err != nil {
+		return types.ValidatorSignature{}, fmt.Errorf("Could not decode the Web3Signer's signature: %w", err)
+	}
+	if len(signature) != types.ValidatorSignatureLength {
+		return types.ValidatorSignature{}, fmt.Errorf("The Web3Signer returned a signature of %d bytes", len(signature))
+	}
+	return types.BytesToValidatorSignature(signature), nil
+}
+
+// Make a request to the Web3Signer and return the response body
+func (c *Client) request(method string, path string, body interface{}) ([]byte, error) {
+
+	var requestBody *bytes.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("Could not encode request: %w", err)
+		}
+		requestBody = bytes.NewReader(bodyBytes)
+	} else {
+		requestBody = bytes.NewReader(nil)
+	}
+
+	request, err := http.NewRequest(method, c.url+path, requestBody)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, fmt.Errorf("HTTP status %d: %s", response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	return responseBody, nil
+
+}
@@ -0,0 +1,117 @@
+package web3signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+func TestClient(t *testing.T) {
+	pubkey := types.BytesToValidatorPubkey(bytes.Repeat([]byte{0x11}, types.ValidatorPubkeyLength))
+	signature := bytes.Repeat([]byte{0x22}, types.ValidatorSignatureLength)
+	var lastRequest signRequest
+	slashingProtection := `{"metadata":{"interchange_format_version":"5"},"data":[]}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == publicKeysPath:
+			json.NewEncoder(w).Encode([]string{hexutil.Encode(pubkey.Bytes())})
+		case r.Method == http.MethodPost && r.URL.Path == keystoresPath:
+			var request importKeystoresRequest
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil || len(request.Keystores) != len(request.Passwords) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(importKeystoresResponse{Data: []ImportStatus{{Status: "imported"}}})
+		case r.Method == http.MethodDelete && r.URL.Path == keystoresPath:
+			json.NewEncoder(w).Encode(deleteKeystoresResponse{Data: []ImportStatus{{Status: "deleted"}}, SlashingProtection: slashingProtection})
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/api/v1/eth2/sign/"):
+			if r.URL.Path != "/api/v1/eth2/sign/"+hexutil.Encode(pubkey.Bytes()) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if err := json.NewDecoder(r.Body).Decode(&lastRequest); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			// Answer exits with JSON and deposits with the bare signature
+			if lastRequest.Type == "VOLUNTARY_EXIT" {
+				json.NewEncoder(w).Encode(signResponse{Signature: signature})
+			} else {
+				w.Write([]byte(hexutil.Encode(signature)))
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL + "/")
+
+	// Public keys
+	pubkeys, err := client.GetPublicKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pubkeys) != 1 || pubkeys[0] != pubkey {
+		t.Errorf("unexpected public keys %v", pubkeys)
+	}
+
+	// Import
+	statuses, err := client.ImportKeystores([]string{"{}"}, []string{"password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0].Status != "imported" {
+		t.Errorf("unexpected import statuses %v", statuses)
+	}
+
+	// Delete, getting the slashing protection history back
+	statuses, exported, err := client.DeleteKeystores([]types.ValidatorPubkey{pubkey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0].Status != "deleted" || exported != slashingProtection {
+		t.Errorf("unexpected delete result %v %q", statuses, exported)
+	}
+
+	// Voluntary exit
+	exitSignature, err := client.SignVoluntaryExit(pubkey, [32]byte{1}, 100, 7, ForkInfo{
+		PreviousVersion:       []byte{3, 0, 0, 0},
+		CurrentVersion:        []byte{3, 0, 0, 0},
+		GenesisValidatorsRoot: make([]byte, 32),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(exitSignature.Bytes(), signature) {
+		t.Error("unexpected exit signature")
+	}
+	if lastRequest.VoluntaryExit == nil || lastRequest.VoluntaryExit.Epoch != "100" || lastRequest.VoluntaryExit.ValidatorIndex != "7" || lastRequest.ForkInfo == nil {
+		t.Errorf("unexpected exit request %+v", lastRequest)
+	}
+
+	// Deposit
+	depositSignature, err := client.SignDeposit(pubkey, [32]byte{2}, make([]byte, 32), 1000000000, []byte{0, 0, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(depositSignature.Bytes(), signature) {
+		t.Error("unexpected deposit signature")
+	}
+	if lastRequest.Deposit == nil || lastRequest.Deposit.Amount != "1000000000" || !bytes.Equal(lastRequest.Deposit.Pubkey, pubkey.Bytes()) {
+		t.Errorf("unexpected deposit request %+v", lastRequest)
+	}
+
+	// Unknown keys are errors
+	other := types.BytesToValidatorPubkey(bytes.Repeat([]byte{0x33}, types.ValidatorPubkeyLength))
+	if _, err := client.SignDeposit(other, [32]byte{}, nil, 0, nil); err == nil {
+		t.Error("expected signing with an unknown key to fail")
+	}
+}
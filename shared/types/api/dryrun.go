@@ -0,0 +1,67 @@
+package api
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// A decoded method or event argument
+type DryRunArgument struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// The change in an account's ETH or token balance
+type DryRunBalanceChange struct {
+	Address common.Address  `json:"address"`
+	Token   *common.Address `json:"token"`
+	Symbol  string          `json:"symbol"`
+	Change  *big.Int        `json:"change"`
+}
+
+// An event the transaction would emit
+type DryRunEvent struct {
+	Address   common.Address   `json:"address"`
+	Name      string           `json:"name"`
+	Arguments []DryRunArgument `json:"arguments"`
+}
+
+// The result of simulating a transaction instead of signing and sending it
+type DryRunSimulation struct {
+	From           common.Address        `json:"from"`
+	To             *common.Address       `json:"to"`
+	Nonce          uint64                `json:"nonce"`
+	Value          *big.Int              `json:"value"`
+	Data           hexutil.Bytes         `json:"data"`
+	Method         string                `json:"method"`
+	Arguments      []DryRunArgument      `json:"arguments"`
+	GasLimit       uint64                `json:"gasLimit"`
+	PredictedGas   uint64                `json:"predictedGas"`
+	MaxFee         *big.Int              `json:"maxFee"`
+	MaxPriorityFee *big.Int              `json:"maxPriorityFee"`
+	BlockNumber    uint64                `json:"blockNumber"`
+	Reverted       bool                  `json:"reverted"`
+	RevertReason   string                `json:"revertReason"`
+	BalanceChanges []DryRunBalanceChange `json:"balanceChanges"`
+	Events         []DryRunEvent         `json:"events"`
+	TraceError     string                `json:"traceError"`
+}
+
+// The response of a write command run with --dry-run
+type DryRunResponse struct {
+	Status string            `json:"status"`
+	Error  string            `json:"error"`
+	DryRun *DryRunSimulation `json:"dryRun"`
+}
+
+// Returned in place of a transaction when it was simulated instead of sent, so commands stop after the simulation
+type DryRunError struct {
+	Simulation DryRunSimulation
+}
+
+func (e *DryRunError) Error() string {
+	return "dry run: the transaction was simulated but not signed or sent"
+}
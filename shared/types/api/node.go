@@ -1,9 +1,11 @@
 package api
 
 import (
+	"encoding/json"
 	"math/big"
 	"time"
 
+	"github.com/stader-labs/stader-node/shared/types/eth2"
 	stader_backend "github.com/stader-labs/stader-node/shared/types/stader-backend"
 
 	"github.com/stader-labs/stader-node/shared/utils/stdr"
@@ -191,9 +193,70 @@ type CanExitValidatorResponse struct {
 }
 
 type ExitValidatorResponse struct {
-	BeaconChainUrl string `json:"beaconChainUrl"`
-	Status         string `json:"status"`
-	Error          string `json:"error"`
+	BeaconChainUrl     string                   `json:"beaconChainUrl"`
+	WithdrawalEstimate *stdr.WithdrawalEstimate `json:"withdrawalEstimate"`
+	Status             string                   `json:"status"`
+	Error              string                   `json:"error"`
+}
+
+type KeystoreImport struct {
+	Keystore json.RawMessage `json:"keystore"`
+	Password string          `json:"password"`
+}
+type ImportedKeystore struct {
+	ValidatorPubKey types.ValidatorPubkey `json:"validatorPubKey"`
+	ValidatorExists bool                  `json:"validatorExists"`
+}
+type ImportKeystoresResponse struct {
+	Status       string             `json:"status"`
+	Error        string             `json:"error"`
+	ImportedKeys []ImportedKeystore `json:"importedKeys"`
+}
+
+type ValidatorKeyRemoval struct {
+	Pubkey            types.ValidatorPubkey `json:"pubkey"`
+	BeaconStatus      string                `json:"beaconStatus"`
+	WithdrawableEpoch uint64                `json:"withdrawableEpoch"`
+	ValidatorNotFound bool                  `json:"validatorNotFound"`
+	NotWithdrawable   bool                  `json:"notWithdrawable"`
+	Removable         bool                  `json:"removable"`
+}
+type CanRemoveValidatorKeysResponse struct {
+	Status       string                `json:"status"`
+	Error        string                `json:"error"`
+	CurrentEpoch uint64                `json:"currentEpoch"`
+	Keys         []ValidatorKeyRemoval `json:"keys"`
+}
+type RemoveValidatorKeysResponse struct {
+	Status                  string                  `json:"status"`
+	Error                   string                  `json:"error"`
+	RemovedKeys             []types.ValidatorPubkey `json:"removedKeys"`
+	SlashingProtection      string                  `json:"slashingProtection"`
+	SlashingProtectionFile  string                  `json:"slashingProtectionFile"`
+	SlashingProtectionSaved bool                    `json:"slashingProtectionSaved"`
+}
+
+type ExportDepositDataResponse struct {
+	Status         string                      `json:"status"`
+	Error          string                      `json:"error"`
+	NetworkName    string                      `json:"networkName"`
+	PreDepositData []eth2.LaunchpadDepositData `json:"preDepositData"`
+	DepositData    []eth2.LaunchpadDepositData `json:"depositData"`
+	MissingKeys    []types.ValidatorPubkey     `json:"missingKeys"`
+}
+
+type DepositDataVerification struct {
+	Pubkey               string         `json:"pubkey"`
+	Amount               uint64         `json:"amount"`
+	Registered           bool           `json:"registered"`
+	WithdrawVaultAddress common.Address `json:"withdrawVaultAddress"`
+	Valid                bool           `json:"valid"`
+	Error                string         `json:"error"`
+}
+type VerifyDepositDataResponse struct {
+	Status  string                    `json:"status"`
+	Error   string                    `json:"error"`
+	Results []DepositDataVerification `json:"results"`
 }
 
 type CanUpdateSocializeElResponse struct {
@@ -386,12 +449,59 @@ type SetRewardAddress struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type NodeReportResponse struct {
+	Status    string             `json:"status"`
+	Error     string             `json:"error"`
+	Snapshots []OperatorSnapshot `json:"snapshots"`
+}
+
+// The operator's position at a point in time. Amounts are in ETH, or SD for SD amounts.
+type OperatorSnapshot struct {
+	Time                                 time.Time           `json:"time"`
+	Slot                                 uint64              `json:"slot"`
+	BlockNumber                          uint64              `json:"blockNumber"`
+	SdCollateral                         float64             `json:"sdCollateral"`
+	SdCollateralInEth                    float64             `json:"sdCollateralInEth"`
+	EthCollateral                        float64             `json:"ethCollateral"`
+	ActiveValidators                     int64               `json:"activeValidators"`
+	StaderQueuedValidators               int64               `json:"staderQueuedValidators"`
+	BeaconChainQueuedValidators          int64               `json:"beaconChainQueuedValidators"`
+	ExitingValidators                    int64               `json:"exitingValidators"`
+	WithdrawnValidators                  int64               `json:"withdrawnValidators"`
+	SlashedValidators                    int64               `json:"slashedValidators"`
+	FundsSettledValidators               int64               `json:"fundsSettledValidators"`
+	CumulativePenalty                    float64             `json:"cumulativePenalty"`
+	UnclaimedClRewards                   float64             `json:"unclaimedClRewards"`
+	UnclaimedNonSocializingPoolElRewards float64             `json:"unclaimedNonSocializingPoolElRewards"`
+	UnclaimedSocializingPoolElRewards    float64             `json:"unclaimedSocializingPoolElRewards"`
+	UnclaimedSocializingPoolSdRewards    float64             `json:"unclaimedSocializingPoolSdRewards"`
+	Validators                           []ValidatorSnapshot `json:"validators"`
+}
+
+// A validator's position at a point in time
+type ValidatorSnapshot struct {
+	Pubkey               types.ValidatorPubkey `json:"pubkey"`
+	Status               string                `json:"status"`
+	BeaconBalance        float64               `json:"beaconBalance"`
+	WithdrawVaultAddress common.Address        `json:"withdrawVaultAddress"`
+	WithdrawVaultBalance float64               `json:"withdrawVaultBalance"`
+	Penalty              float64               `json:"penalty"`
+}
+
 type NodeSignResponse struct {
 	Status     string `json:"status"`
 	Error      string `json:"error"`
 	SignedData string `json:"signedData"`
 }
 
+type VerifySignatureResponse struct {
+	Status          string         `json:"status"`
+	Error           string         `json:"error"`
+	Signer          common.Address `json:"signer"`
+	ExpectedAddress common.Address `json:"expectedAddress"`
+	Matches         bool           `json:"matches"`
+}
+
 type CanClaimRewards struct {
 	Status    string         `json:"status"`
 	Error     string         `json:"error"`
@@ -19,7 +19,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 */
 package api
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
 
 type TerminateDataFolderResponse struct {
 	Status        string `json:"status"`
@@ -42,11 +47,26 @@ type ClientStatus struct {
 	Error        string  `json:"error"`
 }
 
+// This is a wrapper for the health report of a single endpoint in a client pool
+type ClientEndpointStatus struct {
+	Url        string       `json:"url"`
+	Priority   int          `json:"priority"`
+	Status     ClientStatus `json:"status"`
+	Head       uint64       `json:"head"`
+	HeadLag    uint64       `json:"headLag"`
+	LatencyMs  int64        `json:"latencyMs"`
+	ErrorRate  float64      `json:"errorRate"`
+	Score      float64      `json:"score"`
+	IsHealthy  bool         `json:"isHealthy"`
+	IsSelected bool         `json:"isSelected"`
+}
+
 // This is a wrapper for the manager's overall status report
 type ClientManagerStatus struct {
-	PrimaryClientStatus  ClientStatus `json:"primaryEcStatus"`
-	FallbackEnabled      bool         `json:"fallbackEnabled"`
-	FallbackClientStatus ClientStatus `json:"fallbackEcStatus"`
+	PrimaryClientStatus  ClientStatus           `json:"primaryEcStatus"`
+	FallbackEnabled      bool                   `json:"fallbackEnabled"`
+	FallbackClientStatus ClientStatus           `json:"fallbackEcStatus"`
+	Endpoints            []ClientEndpointStatus `json:"endpoints"`
 }
 
 type ClientStatusResponse struct {
@@ -55,3 +75,38 @@ type ClientStatusResponse struct {
 	EcManagerStatus ClientManagerStatus `json:"ecManagerStatus"`
 	BcManagerStatus ClientManagerStatus `json:"bcManagerStatus"`
 }
+
+// Suggested fees for one speed tier
+type FeeTier struct {
+	MaxFee         *big.Int `json:"maxFee"`
+	MaxPriorityFee *big.Int `json:"maxPriorityFee"`
+	Time           string   `json:"time"`
+}
+
+type FeeEstimateResponse struct {
+	Status   string   `json:"status"`
+	Error    string   `json:"error"`
+	BaseFee  *big.Int `json:"baseFee"`
+	Slow     FeeTier  `json:"slow"`
+	Standard FeeTier  `json:"standard"`
+	Fast     FeeTier  `json:"fast"`
+}
+
+type ServiceBackupResponse struct {
+	Status      string         `json:"status"`
+	Error       string         `json:"error"`
+	File        string         `json:"file"`
+	NodeAddress common.Address `json:"nodeAddress"`
+	FileCount   int            `json:"fileCount"`
+	Warnings    []string       `json:"warnings"`
+}
+
+type ServiceRestoreResponse struct {
+	Status                     string         `json:"status"`
+	Error                      string         `json:"error"`
+	Network                    string         `json:"network"`
+	NodeAddress                common.Address `json:"nodeAddress"`
+	CreatedAt                  time.Time      `json:"createdAt"`
+	FileCount                  int            `json:"fileCount"`
+	SlashingProtectionRestored bool           `json:"slashingProtectionRestored"`
+}
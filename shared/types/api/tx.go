@@ -0,0 +1,63 @@
+package api
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
+)
+
+type TxListResponse struct {
+	Status       string                   `json:"status"`
+	Error        string                   `json:"error"`
+	Transactions []txmanager.JournalEntry `json:"transactions"`
+}
+
+type TxStatusResponse struct {
+	Status      string                 `json:"status"`
+	Error       string                 `json:"error"`
+	Transaction txmanager.JournalEntry `json:"transaction"`
+	// Every transaction with the same nonce, including this one and its replacements
+	Group []txmanager.JournalEntry `json:"group"`
+}
+
+type CanReplaceTxResponse struct {
+	Status         string                 `json:"status"`
+	Error          string                 `json:"error"`
+	CanReplace     bool                   `json:"canReplace"`
+	NotPending     bool                   `json:"notPending"`
+	Transaction    txmanager.JournalEntry `json:"transaction"`
+	MaxFee         *big.Int               `json:"maxFee"`
+	MaxPriorityFee *big.Int               `json:"maxPriorityFee"`
+	GasLimit       uint64                 `json:"gasLimit"`
+}
+
+type ReplaceTxResponse struct {
+	Status         string      `json:"status"`
+	Error          string      `json:"error"`
+	TxHash         common.Hash `json:"txHash"`
+	MaxFee         *big.Int    `json:"maxFee"`
+	MaxPriorityFee *big.Int    `json:"maxPriorityFee"`
+}
+
+type BroadcastTxResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+// The response of a write command run with --export-unsigned-tx
+type UnsignedTxResponse struct {
+	Status     string                `json:"status"`
+	Error      string                `json:"error"`
+	UnsignedTx *txmanager.UnsignedTx `json:"unsignedTx"`
+}
+
+// Returned in place of a transaction when it was exported for offline signing instead of sent
+type UnsignedTxError struct {
+	UnsignedTx txmanager.UnsignedTx
+}
+
+func (e *UnsignedTxError) Error() string {
+	return "the transaction was exported for offline signing instead of being signed and sent"
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
 	"github.com/stader-labs/stader-node/stader-lib/stader"
 	"github.com/stader-labs/stader-node/stader-lib/types"
 )
@@ -43,16 +44,28 @@ type WalletStatusResponse struct {
 	Error             string         `json:"error"`
 	PasswordSet       bool           `json:"passwordSet"`
 	WalletInitialized bool           `json:"walletInitialized"`
+	WatchOnly         bool           `json:"watchOnly"`
 	AccountAddress    common.Address `json:"accountAddress"`
 	CurrentNonce      *big.Int       `json:"currentNonce"`
 	PendingNonce      *big.Int       `json:"pendingNonce"`
 }
 
+type WatchWalletResponse struct {
+	Status         string         `json:"status"`
+	Error          string         `json:"error"`
+	AccountAddress common.Address `json:"accountAddress"`
+}
+
 type SetPasswordResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
 
+type ChangePasswordResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
 type InitWalletResponse struct {
 	Status         string         `json:"status"`
 	Error          string         `json:"error"`
@@ -61,11 +74,40 @@ type InitWalletResponse struct {
 }
 
 type RecoverWalletResponse struct {
-	Status         string                  `json:"status"`
-	Error          string                  `json:"error"`
-	AccountAddress common.Address          `json:"accountAddress"`
-	ValidatorKeys  []types.ValidatorPubkey `json:"validatorKeys"`
-	OperatorExists bool                    `json:"operatorExists"`
+	Status         string                      `json:"status"`
+	Error          string                      `json:"error"`
+	AccountAddress common.Address              `json:"accountAddress"`
+	ValidatorKeys  []types.ValidatorPubkey     `json:"validatorKeys"`
+	OperatorExists bool                        `json:"operatorExists"`
+	RecoveryReport *ValidatorKeyRecoveryReport `json:"recoveryReport"`
+}
+
+type RecoverValidatorKeysResponse struct {
+	Status         string                      `json:"status"`
+	Error          string                      `json:"error"`
+	OperatorExists bool                        `json:"operatorExists"`
+	RecoveryReport *ValidatorKeyRecoveryReport `json:"recoveryReport"`
+}
+
+// The outcome of searching a wallet for the validator keys registered with an operator
+type ValidatorKeyRecoveryReport struct {
+	StartIndex      uint                    `json:"startIndex"`
+	EndIndex        uint                    `json:"endIndex"`
+	DerivationPaths []string                `json:"derivationPaths"`
+	RecoveredKeys   []RecoveredValidatorKey `json:"recoveredKeys"`
+	MissingKeys     []MissingValidatorKey   `json:"missingKeys"`
+}
+
+type RecoveredValidatorKey struct {
+	Pubkey         types.ValidatorPubkey `json:"pubkey"`
+	DerivationPath string                `json:"derivationPath"`
+	Imported       bool                  `json:"imported"`
+	ContractStatus string                `json:"contractStatus"`
+}
+
+type MissingValidatorKey struct {
+	Pubkey         types.ValidatorPubkey `json:"pubkey"`
+	ContractStatus string                `json:"contractStatus"`
 }
 
 type RebuildValidatorKeysResponse struct {
@@ -97,6 +139,12 @@ type ExportWalletResponse struct {
 	AccountPrivateKey string `json:"accountPrivateKey"`
 }
 
+type VerifyMnemonicResponse struct {
+	Status  string `json:"status"`
+	Error   string `json:"error"`
+	Matches bool   `json:"matches"`
+}
+
 type SetEnsNameResponse struct {
 	Status  string         `json:"status"`
 	Error   string         `json:"error"`
@@ -117,3 +165,9 @@ type PurgeResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type SignTxResponse struct {
+	Status   string             `json:"status"`
+	Error    string             `json:"error"`
+	SignedTx txmanager.SignedTx `json:"signedTx"`
+}
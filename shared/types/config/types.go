@@ -29,6 +29,9 @@ type RewardsMode string
 type MevRelayID string
 type MevSelectionMode string
 type NimbusPruningMode string
+type FeeEstimator string
+type NodeSigner string
+type PasswordProvider string
 
 // Enum to describe which container(s) a parameter impacts, so the Stadernode knows which
 // ones to restart upon a settings change
@@ -132,6 +135,26 @@ const (
 	NimbusPruningMode_Prune   NimbusPruningMode = "prune"
 )
 
+// Enum to describe where fee suggestions come from
+const (
+	FeeEstimator_ExecutionClient FeeEstimator = "executionClient"
+	FeeEstimator_WebOracle       FeeEstimator = "webOracle"
+)
+
+// Enum to describe what signs for the node account
+const (
+	NodeSigner_Local NodeSigner = "local"
+	NodeSigner_Clef  NodeSigner = "clef"
+)
+
+// Enum to describe where the node password is kept
+const (
+	PasswordProvider_File              PasswordProvider = "file"
+	PasswordProvider_Env               PasswordProvider = "env"
+	PasswordProvider_Command           PasswordProvider = "command"
+	PasswordProvider_SystemdCredential PasswordProvider = "systemd-credential"
+)
+
 type Config interface {
 	GetConfigTitle() string
 	GetParameters() []*Parameter
@@ -0,0 +1,15 @@
+package eth2
+
+// A deposit in the deposit_data-*.json format written by the staking-deposit-cli and accepted by the launchpad.
+// Byte fields are hex encoded without a 0x prefix, and the amount is in gwei.
+type LaunchpadDepositData struct {
+	PublicKey             string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositMessageRoot    string `json:"deposit_message_root"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	ForkVersion           string `json:"fork_version"`
+	NetworkName           string `json:"network_name"`
+	DepositCliVersion     string `json:"deposit_cli_version"`
+}
@@ -32,6 +32,22 @@ import (
 // response must be a pointer to a struct type with Error and Status string fields
 func PrintResponse(response interface{}, responseError error) {
 
+	// Dry runs and unsigned exports stop at the first transaction, so respond with its simulation or export instead
+	switch response.(type) {
+	case *api.DryRunResponse, *api.UnsignedTxResponse:
+	default:
+		var dryRunErr *api.DryRunError
+		if errors.As(responseError, &dryRunErr) {
+			PrintResponse(&api.DryRunResponse{DryRun: &dryRunErr.Simulation}, responseError)
+			return
+		}
+		var unsignedTxErr *api.UnsignedTxError
+		if errors.As(responseError, &unsignedTxErr) {
+			PrintResponse(&api.UnsignedTxResponse{UnsignedTx: &unsignedTxErr.UnsignedTx}, responseError)
+			return
+		}
+	}
+
 	// Check response type
 	r := reflect.ValueOf(response)
 	if !(r.Kind() == reflect.Ptr && r.Type().Elem().Kind() == reflect.Struct) {
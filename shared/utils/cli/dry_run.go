@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/stader-lib/utils/eth"
+)
+
+// Print the simulation of a transaction that a dry run stopped at
+func PrintDryRunSimulation(simulation api.DryRunSimulation) {
+
+	fmt.Printf("%sDry run: this transaction was simulated against block %d. It was not signed or sent.%s\n\n", colorLightBlue, simulation.BlockNumber, colorReset)
+
+	to := "(contract creation)"
+	if simulation.To != nil {
+		to = simulation.To.Hex()
+	}
+	fmt.Printf("From:         %s\n", simulation.From.Hex())
+	fmt.Printf("To:           %s\n", to)
+	fmt.Printf("Method:       %s\n", simulation.Method)
+	for _, argument := range simulation.Arguments {
+		fmt.Printf("  %s (%s): %s\n", argument.Name, argument.Type, argument.Value)
+	}
+	fmt.Printf("Value:        %.6f ETH\n", eth.WeiToEth(simulation.Value))
+	fmt.Printf("Nonce:        %d\n", simulation.Nonce)
+	fmt.Printf("Gas limit:    %d\n", simulation.GasLimit)
+	if !simulation.Reverted {
+		fmt.Printf("Gas used:     %d (predicted)\n", simulation.PredictedGas)
+	}
+	fmt.Printf("Max fee:      %.2f gwei (%.2f gwei max priority fee)\n", eth.WeiToGwei(simulation.MaxFee), eth.WeiToGwei(simulation.MaxPriorityFee))
+	if len(simulation.Data) > 0 {
+		fmt.Printf("Calldata:     %s\n", simulation.Data.String())
+	}
+	fmt.Println()
+
+	if simulation.Reverted {
+		fmt.Printf("%sThe transaction would fail: %s%s\n", colorRed, simulation.RevertReason, colorReset)
+		return
+	}
+	fmt.Printf("%sThe transaction would succeed.%s\n\n", colorGreen, colorReset)
+
+	fmt.Println("Balance changes, including gas:")
+	for _, change := range simulation.BalanceChanges {
+		sign := ""
+		if change.Change.Sign() > 0 {
+			sign = "+"
+		}
+		fmt.Printf("  %s  %s%.6f %s\n", change.Address.Hex(), sign, eth.WeiToEth(change.Change), change.Symbol)
+	}
+	fmt.Println()
+
+	if simulation.TraceError != "" {
+		fmt.Printf("%sEvents and internal transfers aren't shown because the Execution client couldn't trace the transaction: %s%s\n", colorYellow, simulation.TraceError, colorReset)
+		return
+	}
+	if len(simulation.Events) == 0 {
+		fmt.Println("Events: none")
+		return
+	}
+	fmt.Println("Events:")
+	for _, event := range simulation.Events {
+		arguments := make([]string, len(event.Arguments))
+		for i, argument := range event.Arguments {
+			arguments[i] = fmt.Sprintf("%s=%s", argument.Name, argument.Value)
+		}
+		fmt.Printf("  %s  %s(%s)\n", event.Address.Hex(), event.Name, strings.Join(arguments, ", "))
+	}
+
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
+	"github.com/stader-labs/stader-node/stader-lib/utils/eth"
+)
+
+// Print the details of an unsigned transaction
+func PrintUnsignedTx(unsignedTx txmanager.UnsignedTx) {
+
+	to := "(contract creation)"
+	if unsignedTx.To != nil {
+		to = unsignedTx.To.Hex()
+	}
+	fmt.Printf("Chain ID:     %s\n", unsignedTx.ChainID.ToInt())
+	fmt.Printf("From:         %s\n", unsignedTx.From.Hex())
+	fmt.Printf("To:           %s\n", to)
+	fmt.Printf("Value:        %.6f ETH\n", eth.WeiToEth(unsignedTx.Value.ToInt()))
+	fmt.Printf("Nonce:        %d\n", unsignedTx.Nonce)
+	fmt.Printf("Gas limit:    %d\n", unsignedTx.GasLimit)
+	fmt.Printf("Max fee:      %.2f gwei (%.2f gwei max priority fee)\n", eth.WeiToGwei(unsignedTx.MaxFee.ToInt()), eth.WeiToGwei(unsignedTx.MaxPriorityFee.ToInt()))
+	fmt.Printf("Description:  %s\n", unsignedTx.Description)
+
+}
+
+// Save the transaction a command stopped at so it can be signed offline
+func SaveUnsignedTx(path string, unsignedTx txmanager.UnsignedTx) error {
+
+	bytes, err := json.MarshalIndent(unsignedTx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing unsigned transaction: %w", err)
+	}
+	if err := ioutil.WriteFile(path, bytes, 0600); err != nil {
+		return fmt.Errorf("error saving unsigned transaction to %s: %w", path, err)
+	}
+
+	fmt.Printf("%sThis transaction was exported to %s. It was not signed or sent.%s\n\n", colorLightBlue, path, colorReset)
+	PrintUnsignedTx(unsignedTx)
+	fmt.Println()
+	fmt.Println("To send it:")
+	fmt.Printf("  1. Copy %s to the machine that holds the node wallet and run `stader-cli wallet sign-tx %s` there.\n", path, path)
+	fmt.Println("  2. Copy the signed file back and run `stader-cli tx broadcast <signed file>` on this machine.")
+	fmt.Println()
+	fmt.Printf("%sCommands that send several transactions stop at the first one. Once it has been mined, run the command again to export the next.%s\n", colorYellow, colorReset)
+	return nil
+
+}
@@ -223,6 +223,28 @@ func ValidateWalletMnemonic(name, value string) (string, error) {
 	return value, nil
 }
 
+// Validate a validator key derivation path, which needs exactly one %d in place of the index
+func ValidateValidatorKeyPath(name, value string) (string, error) {
+	if !strings.HasPrefix(value, "m/") || strings.Count(value, "%") != 1 || strings.Count(value, "%d") != 1 {
+		return "", fmt.Errorf("invalid %s '%s' - it must start with 'm/' and contain exactly one %%d in place of the index, such as 'm/12381/3600/%%d/0/0'", name, value)
+	}
+	return value, nil
+}
+
+// Validate a mnemonic share scheme in the format 'M-of-N'
+func ValidateShareScheme(name, value string) (int, int, error) {
+	matches := regexp.MustCompile("^([1-9][0-9]?)-of-([1-9][0-9]?)$").FindStringSubmatch(value)
+	if matches == nil {
+		return 0, 0, fmt.Errorf("invalid %s '%s' - must be in the format 'M-of-N', such as '3-of-5'", name, value)
+	}
+	threshold, _ := strconv.Atoi(matches[1])
+	count, _ := strconv.Atoi(matches[2])
+	if threshold > count || count > 16 {
+		return 0, 0, fmt.Errorf("invalid %s '%s' - M can't be more than N, and N can't be more than 16", name, value)
+	}
+	return threshold, count, nil
+}
+
 // Validate a timezone location
 func ValidateTimezoneLocation(name, value string) (string, error) {
 	if !regexp.MustCompile("^([a-zA-Z_]{2,}\\/)+[a-zA-Z_]{2,}$").MatchString(value) {
@@ -263,3 +285,20 @@ func ValidatePubkey(name, value string) (types.ValidatorPubkey, error) {
 	}
 	return pubkey, nil
 }
+
+// Validate a comma-separated list of validator pubkeys, which may be empty
+func ValidatePubkeys(name, value string) ([]types.ValidatorPubkey, error) {
+	pubkeys := []types.ValidatorPubkey{}
+	for _, element := range strings.Split(value, ",") {
+		element = strings.TrimSpace(element)
+		if element == "" {
+			continue
+		}
+		pubkey, err := ValidatePubkey(name, element)
+		if err != nil {
+			return nil, err
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
@@ -0,0 +1,109 @@
+package eth2
+
+import (
+	"math"
+	"sort"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+)
+
+// The epoch used by the Beacon chain for "never"
+const FarFutureEpoch uint64 = math.MaxUint64
+
+// A snapshot of the Beacon chain's pending activation queue
+type ActivationQueue struct {
+	Config               beacon.Eth2Config
+	CurrentEpoch         uint64
+	FinalizedEpoch       uint64
+	ActiveValidatorCount uint64
+	ChurnLimit           uint64
+	Pending              []beacon.ValidatorStatus
+}
+
+// Get the Beacon chain's pending activation queue, in the order that validators will be activated
+func GetActivationQueue(bc beacon.Client) (*ActivationQueue, error) {
+
+	config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, err
+	}
+
+	// The committees for the current epoch cover every active validator
+	committees, err := bc.GetCommitteesForEpoch(nil)
+	if err != nil {
+		return nil, err
+	}
+	activeValidatorCount := uint64(0)
+	for _, committee := range committees {
+		activeValidatorCount += uint64(len(committee.Validators))
+	}
+
+	pending, err := bc.GetValidatorsByStatus([]beacon.ValidatorState{beacon.ValidatorState_PendingQueued}, nil)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		if pending[i].ActivationEligibilityEpoch != pending[j].ActivationEligibilityEpoch {
+			return pending[i].ActivationEligibilityEpoch < pending[j].ActivationEligibilityEpoch
+		}
+		return pending[i].Index < pending[j].Index
+	})
+
+	return &ActivationQueue{
+		Config:               config,
+		CurrentEpoch:         head.Epoch,
+		FinalizedEpoch:       head.FinalizedEpoch,
+		ActiveValidatorCount: activeValidatorCount,
+		ChurnLimit:           GetActivationChurnLimit(config, activeValidatorCount),
+		Pending:              pending,
+	}, nil
+
+}
+
+// Get the number of validators that can be activated per epoch
+func GetActivationChurnLimit(config beacon.Eth2Config, activeValidatorCount uint64) uint64 {
+	churnLimit := config.MinPerEpochChurnLimit
+	if config.ChurnLimitQuotient > 0 && activeValidatorCount/config.ChurnLimitQuotient > churnLimit {
+		churnLimit = activeValidatorCount / config.ChurnLimitQuotient
+	}
+	if config.MaxPerEpochActivationChurnLimit > 0 && churnLimit > config.MaxPerEpochActivationChurnLimit {
+		churnLimit = config.MaxPerEpochActivationChurnLimit
+	}
+	if churnLimit == 0 {
+		churnLimit = 1
+	}
+	return churnLimit
+}
+
+// Get a validator's position in the activation queue and the epoch it should be activated in.
+// Validators that haven't been queued yet are placed at the back of the queue.
+func (q *ActivationQueue) Estimate(validatorStatus beacon.ValidatorStatus) (uint64, uint64) {
+
+	// Already scheduled for activation
+	if validatorStatus.Exists && validatorStatus.ActivationEpoch != FarFutureEpoch {
+		return 0, validatorStatus.ActivationEpoch
+	}
+
+	position := uint64(len(q.Pending))
+	if validatorStatus.Status == beacon.ValidatorState_PendingQueued {
+		for i, pending := range q.Pending {
+			if pending.Index == validatorStatus.Index {
+				position = uint64(i)
+				break
+			}
+		}
+	}
+
+	// Validators only leave the queue once their eligibility epoch has been finalized
+	startEpoch := q.CurrentEpoch
+	if validatorStatus.Status == beacon.ValidatorState_PendingQueued && validatorStatus.ActivationEligibilityEpoch > q.FinalizedEpoch {
+		startEpoch += validatorStatus.ActivationEligibilityEpoch - q.FinalizedEpoch
+	}
+
+	return position, startEpoch + position/q.ChurnLimit + 1 + q.Config.MaxSeedLookahead
+
+}
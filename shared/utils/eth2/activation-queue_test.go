@@ -0,0 +1,117 @@
+package eth2_test
+
+import (
+	"testing"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/services/beacon/mock"
+	"github.com/stader-labs/stader-node/shared/utils/eth2"
+)
+
+func TestActivationQueue(t *testing.T) {
+	config := mock.DefaultConfig()
+	config.MaxPerEpochActivationChurnLimit = 0
+	headEpoch := uint64(1000)
+	server := mock.NewServer(config, headEpoch*config.SlotsPerEpoch)
+	defer server.Close()
+	bc := server.Client()
+
+	// Few enough active validators that the minimum churn limit applies
+	server.AddActiveValidators(64, 32e9)
+
+	// Queue validators out of index order; they're activated by eligibility epoch first
+	queued := make([]uint64, 10)
+	for i := range queued {
+		_, pubkey, err := mock.GenerateValidatorKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		queued[i] = server.AddValidator(mock.Validator{
+			Pubkey:                     pubkey,
+			EffectiveBalance:           32e9,
+			Status:                     beacon.ValidatorState_PendingQueued,
+			ActivationEligibilityEpoch: headEpoch - 10 - uint64(i),
+		})
+	}
+	_, newPubkey, err := mock.GenerateValidatorKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	newIndex := server.AddValidator(mock.Validator{
+		Pubkey: newPubkey,
+	})
+
+	queue, err := eth2.GetActivationQueue(bc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queue.ActiveValidatorCount != 64 {
+		t.Errorf("expected 64 active validators, got %d", queue.ActiveValidatorCount)
+	}
+	if queue.ChurnLimit != config.MinPerEpochChurnLimit {
+		t.Errorf("expected churn limit %d, got %d", config.MinPerEpochChurnLimit, queue.ChurnLimit)
+	}
+	if len(queue.Pending) != len(queued) {
+		t.Fatalf("expected %d pending validators, got %d", len(queued), len(queue.Pending))
+	}
+
+	// The last validator added became eligible first
+	first, _ := server.GetValidator(queued[len(queued)-1])
+	status, err := bc.GetValidatorStatus(first.Pubkey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	position, epoch := queue.Estimate(status)
+	if position != 0 {
+		t.Errorf("expected position 0, got %d", position)
+	}
+	if expected := headEpoch + 1 + config.MaxSeedLookahead; epoch != expected {
+		t.Errorf("expected activation epoch %d, got %d", expected, epoch)
+	}
+
+	// The first validator added is at the back, two epochs of churn later
+	last, _ := server.GetValidator(queued[0])
+	status, err = bc.GetValidatorStatus(last.Pubkey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	position, epoch = queue.Estimate(status)
+	if position != 9 {
+		t.Errorf("expected position 9, got %d", position)
+	}
+	if expected := headEpoch + 9/config.MinPerEpochChurnLimit + 1 + config.MaxSeedLookahead; epoch != expected {
+		t.Errorf("expected activation epoch %d, got %d", expected, epoch)
+	}
+
+	// Validators that aren't queued yet join the back of the queue
+	newValidator, _ := server.GetValidator(newIndex)
+	status, err = bc.GetValidatorStatus(newValidator.Pubkey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	position, _ = queue.Estimate(status)
+	if position != uint64(len(queued)) {
+		t.Errorf("expected position %d, got %d", len(queued), position)
+	}
+}
+
+func TestActivationChurnLimit(t *testing.T) {
+	config := beacon.Eth2Config{
+		MinPerEpochChurnLimit:           4,
+		ChurnLimitQuotient:              65536,
+		MaxPerEpochActivationChurnLimit: 8,
+	}
+	if limit := eth2.GetActivationChurnLimit(config, 100000); limit != 4 {
+		t.Errorf("expected the minimum churn limit of 4, got %d", limit)
+	}
+	if limit := eth2.GetActivationChurnLimit(config, 65536*6); limit != 6 {
+		t.Errorf("expected a churn limit of 6, got %d", limit)
+	}
+	if limit := eth2.GetActivationChurnLimit(config, 1000000); limit != 8 {
+		t.Errorf("expected the activation churn cap of 8, got %d", limit)
+	}
+	config.MaxPerEpochActivationChurnLimit = 0
+	if limit := eth2.GetActivationChurnLimit(config, 1000000); limit != 15 {
+		t.Errorf("expected an uncapped churn limit of 15, got %d", limit)
+	}
+}
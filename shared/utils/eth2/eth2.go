@@ -20,6 +20,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package eth2
 
 import (
+	"time"
+
 	"github.com/stader-labs/stader-node/shared/services/beacon"
 )
 
@@ -28,6 +30,11 @@ func EpochAt(config beacon.Eth2Config, time uint64) uint64 {
 	return config.GenesisEpoch + (time-config.GenesisTime)/config.SecondsPerEpoch
 }
 
+// Get the time at the start of an eth2 epoch
+func EpochTime(config beacon.Eth2Config, epoch uint64) time.Time {
+	return time.Unix(int64(config.GenesisTime+(epoch-config.GenesisEpoch)*config.SecondsPerEpoch), 0)
+}
+
 func IsValidatorWithdrawn(validatorStatus beacon.ValidatorStatus) bool {
 	switch validatorStatus.Status {
 	case beacon.ValidatorState_WithdrawalPossible:
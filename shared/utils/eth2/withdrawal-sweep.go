@@ -0,0 +1,208 @@
+package eth2
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+)
+
+// The number of slots to look back when measuring the speed of the withdrawal sweep
+const withdrawalSweepSampleSlots uint64 = 64
+
+// The sweep speed to assume if it can't be measured, matching MAX_WITHDRAWALS_PER_PAYLOAD on mainnet
+const defaultWithdrawalsPerSlot uint64 = 16
+
+// The number of earlier slots to try if a slot is missing a block or has no withdrawals
+const maxMissedSlots uint64 = 32
+
+// A snapshot of the Beacon chain's exit queue
+type ExitQueue struct {
+	Config         beacon.Eth2Config
+	CurrentEpoch   uint64
+	ChurnLimit     uint64
+	LastExitEpoch  uint64
+	LastExitChurn  uint64
+	ActiveCount    uint64
+	ValidatorCount uint64
+}
+
+// A snapshot of the Beacon chain's withdrawal sweep
+type WithdrawalSweep struct {
+	Config            beacon.Eth2Config
+	HeadSlot          uint64
+	LastSweptIndex    uint64
+	ValidatorCount    uint64
+	ValidatorsPerSlot float64
+}
+
+// Get the Beacon chain's exit queue
+func GetExitQueue(bc beacon.Client) (*ExitQueue, error) {
+
+	config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, err
+	}
+
+	// The committees for the current epoch cover every active validator
+	committees, err := bc.GetCommitteesForEpoch(nil)
+	if err != nil {
+		return nil, err
+	}
+	activeCount := uint64(0)
+	validatorCount := uint64(0)
+	for _, committee := range committees {
+		activeCount += uint64(len(committee.Validators))
+		for _, index := range committee.Validators {
+			if index+1 > validatorCount {
+				validatorCount = index + 1
+			}
+		}
+	}
+
+	// Find the latest epoch that exits have been scheduled for, and how full it is
+	exiting, err := bc.GetValidatorsByStatus([]beacon.ValidatorState{beacon.ValidatorState_ActiveExiting}, nil)
+	if err != nil {
+		return nil, err
+	}
+	queue := ExitQueue{
+		Config:         config,
+		CurrentEpoch:   head.Epoch,
+		ChurnLimit:     GetExitChurnLimit(config, activeCount),
+		ActiveCount:    activeCount,
+		ValidatorCount: validatorCount,
+	}
+	for _, validator := range exiting {
+		if validator.ExitEpoch > queue.LastExitEpoch {
+			queue.LastExitEpoch = validator.ExitEpoch
+			queue.LastExitChurn = 0
+		}
+		if validator.ExitEpoch == queue.LastExitEpoch {
+			queue.LastExitChurn++
+		}
+	}
+
+	return &queue, nil
+
+}
+
+// Get the number of validators that can exit per epoch
+func GetExitChurnLimit(config beacon.Eth2Config, activeValidatorCount uint64) uint64 {
+	churnLimit := config.MinPerEpochChurnLimit
+	if config.ChurnLimitQuotient > 0 && activeValidatorCount/config.ChurnLimitQuotient > churnLimit {
+		churnLimit = activeValidatorCount / config.ChurnLimitQuotient
+	}
+	if churnLimit == 0 {
+		churnLimit = 1
+	}
+	return churnLimit
+}
+
+// Get the exit epoch a validator would be assigned if its exit were processed now
+func (q *ExitQueue) EstimateExitEpoch() uint64 {
+	exitEpoch := q.CurrentEpoch + 1 + q.Config.MaxSeedLookahead
+	if q.LastExitEpoch < exitEpoch {
+		return exitEpoch
+	}
+	if q.LastExitChurn >= q.ChurnLimit {
+		return q.LastExitEpoch + 1
+	}
+	return q.LastExitEpoch
+}
+
+// Get the Beacon chain's withdrawal sweep, measuring its speed over recent blocks
+func GetWithdrawalSweep(bc beacon.Client, validatorCount uint64) (*WithdrawalSweep, error) {
+
+	config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+
+	syncStatus, err := bc.GetSyncStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the most recent block with withdrawals
+	headSlot, lastSweptIndex, found, err := getLastSweptIndex(bc, syncStatus.HeadSlot)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("no withdrawals found in the blocks leading up to slot %d", syncStatus.HeadSlot)
+	}
+	sweep := WithdrawalSweep{
+		Config:            config,
+		HeadSlot:          headSlot,
+		LastSweptIndex:    lastSweptIndex,
+		ValidatorCount:    validatorCount,
+		ValidatorsPerSlot: float64(defaultWithdrawalsPerSlot),
+	}
+	if config.MaxWithdrawalsPerPayload > 0 {
+		sweep.ValidatorsPerSlot = float64(config.MaxWithdrawalsPerPayload)
+	}
+	if sweep.LastSweptIndex+1 > sweep.ValidatorCount {
+		sweep.ValidatorCount = sweep.LastSweptIndex + 1
+	}
+	if headSlot <= withdrawalSweepSampleSlots {
+		return &sweep, nil
+	}
+
+	// Measure how quickly the sweep is moving through the validator set
+	sampleSlot, sampleIndex, found, err := getLastSweptIndex(bc, headSlot-withdrawalSweepSampleSlots)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		distance := (sweep.LastSweptIndex + sweep.ValidatorCount - sampleIndex) % sweep.ValidatorCount
+		if distance > 0 {
+			sweep.ValidatorsPerSlot = float64(distance) / float64(headSlot-sampleSlot)
+		}
+	}
+
+	return &sweep, nil
+
+}
+
+// Get the index of the last validator withdrawn from in the latest block with withdrawals at or before a slot
+func getLastSweptIndex(bc beacon.Client, slot uint64) (uint64, uint64, bool, error) {
+	for i := uint64(0); i < maxMissedSlots && i <= slot; i++ {
+		block, exists, err := bc.GetBeaconBlock(strconv.FormatUint(slot-i, 10))
+		if err != nil {
+			return 0, 0, false, err
+		}
+		if exists && len(block.Withdrawals) > 0 {
+			return block.Slot, block.Withdrawals[len(block.Withdrawals)-1].ValidatorIndex, true, nil
+		}
+	}
+	return 0, 0, false, nil
+}
+
+// Get the time that the sweep should pay out a validator's balance, given the epoch it becomes withdrawable in.
+// The withdrawable epoch must not be the far future epoch.
+func (s *WithdrawalSweep) EstimateWithdrawalTime(validatorIndex uint64, withdrawableEpoch uint64) time.Time {
+
+	secondsPerSlot := float64(s.Config.SecondsPerSlot)
+	headTime := s.Config.GenesisTime + s.HeadSlot*s.Config.SecondsPerSlot
+	withdrawableTime := s.Config.GenesisTime + (withdrawableEpoch-s.Config.GenesisEpoch)*s.Config.SecondsPerEpoch
+
+	// Find when the sweep next reaches the validator, then keep going around until it's withdrawable
+	validatorCount := s.ValidatorCount
+	if validatorIndex+1 > validatorCount {
+		validatorCount = validatorIndex + 1
+	}
+	distance := (validatorIndex + validatorCount - s.LastSweptIndex) % validatorCount
+	sweepTime := float64(headTime) + float64(distance)/s.ValidatorsPerSlot*secondsPerSlot
+	roundTime := float64(validatorCount) / s.ValidatorsPerSlot * secondsPerSlot
+	for sweepTime < float64(withdrawableTime) {
+		sweepTime += roundTime
+	}
+
+	return time.Unix(int64(sweepTime), 0)
+
+}
@@ -0,0 +1,122 @@
+package eth2_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/services/beacon/mock"
+	"github.com/stader-labs/stader-node/shared/utils/eth2"
+)
+
+func TestExitQueue(t *testing.T) {
+	config := mock.DefaultConfig()
+	headEpoch := uint64(1000)
+	server := mock.NewServer(config, headEpoch*config.SlotsPerEpoch)
+	defer server.Close()
+	bc := server.Client()
+
+	server.AddActiveValidators(1000, 32e9)
+	for i := 0; i < 2; i++ {
+		_, pubkey, err := mock.GenerateValidatorKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		server.AddValidator(mock.Validator{
+			Pubkey:           pubkey,
+			Balance:          32e9,
+			EffectiveBalance: 32e9,
+			Status:           beacon.ValidatorState_ActiveExiting,
+			ActivationEpoch:  100,
+			ExitEpoch:        headEpoch + 10,
+		})
+	}
+
+	queue, err := eth2.GetExitQueue(bc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queue.ActiveCount != 1002 {
+		t.Errorf("expected 1002 active validators, got %d", queue.ActiveCount)
+	}
+	if queue.ValidatorCount != 1002 {
+		t.Errorf("expected 1002 validators, got %d", queue.ValidatorCount)
+	}
+	if queue.LastExitEpoch != headEpoch+10 || queue.LastExitChurn != 2 {
+		t.Errorf("expected 2 exits in epoch %d, got %d in epoch %d", headEpoch+10, queue.LastExitChurn, queue.LastExitEpoch)
+	}
+
+	// The last exit epoch still has room
+	if exitEpoch := queue.EstimateExitEpoch(); exitEpoch != headEpoch+10 {
+		t.Errorf("expected exit epoch %d, got %d", headEpoch+10, exitEpoch)
+	}
+
+	// Once it's full, exits move to the next epoch
+	queue.LastExitChurn = queue.ChurnLimit
+	if exitEpoch := queue.EstimateExitEpoch(); exitEpoch != headEpoch+11 {
+		t.Errorf("expected exit epoch %d, got %d", headEpoch+11, exitEpoch)
+	}
+
+	// Without any pending exits, the earliest possible exit epoch is used
+	queue.LastExitEpoch = 0
+	if exitEpoch := queue.EstimateExitEpoch(); exitEpoch != headEpoch+1+config.MaxSeedLookahead {
+		t.Errorf("expected exit epoch %d, got %d", headEpoch+1+config.MaxSeedLookahead, exitEpoch)
+	}
+}
+
+func TestWithdrawalSweep(t *testing.T) {
+	config := mock.DefaultConfig()
+	headEpoch := uint64(1000)
+	headSlot := headEpoch * config.SlotsPerEpoch
+	server := mock.NewServer(config, headSlot)
+	defer server.Close()
+	bc := server.Client()
+
+	// The sweep moved from validator 100 to 500 over the last 64 slots, with the head slot missed
+	server.SetBlock(mock.Block{
+		Slot:                headSlot - 1,
+		HasExecutionPayload: true,
+		Withdrawals:         []beacon.WithdrawalInfo{{ValidatorIndex: 499}, {ValidatorIndex: 500}},
+	})
+	server.SetBlock(mock.Block{
+		Slot:                headSlot - 65,
+		HasExecutionPayload: true,
+		Withdrawals:         []beacon.WithdrawalInfo{{ValidatorIndex: 100}},
+	})
+
+	sweep, err := eth2.GetWithdrawalSweep(bc, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sweep.HeadSlot != headSlot-1 {
+		t.Errorf("expected sweep head slot %d, got %d", headSlot-1, sweep.HeadSlot)
+	}
+	if sweep.LastSweptIndex != 500 {
+		t.Errorf("expected last swept index 500, got %d", sweep.LastSweptIndex)
+	}
+	if sweep.ValidatorsPerSlot != 6.25 {
+		t.Errorf("expected 6.25 validators per slot, got %f", sweep.ValidatorsPerSlot)
+	}
+
+	headTime := int64(sweep.Config.GenesisTime + sweep.HeadSlot*config.SecondsPerSlot)
+
+	// Already withdrawable, so it's paid out the next time the sweep gets to it
+	expected := time.Unix(headTime+32*int64(config.SecondsPerSlot), 0)
+	if fundsTime := sweep.EstimateWithdrawalTime(700, headEpoch-1); !fundsTime.Equal(expected) {
+		t.Errorf("expected funds at %s, got %s", expected, fundsTime)
+	}
+
+	// Not withdrawable until after the sweep passes it, so it waits for the next round
+	roundTime := int64(1000 / 6.25 * float64(config.SecondsPerSlot))
+	expected = time.Unix(headTime+32*int64(config.SecondsPerSlot)+roundTime, 0)
+	if fundsTime := sweep.EstimateWithdrawalTime(700, headEpoch+2); !fundsTime.Equal(expected) {
+		t.Errorf("expected funds at %s, got %s", expected, fundsTime)
+	}
+
+	// Blocks without withdrawals can't be used to find the sweep
+	empty := mock.NewServer(config, headSlot)
+	defer empty.Close()
+	if _, err := eth2.GetWithdrawalSweep(empty.Client(), 1000); err == nil {
+		t.Error("expected an error when there are no recent withdrawals")
+	}
+}
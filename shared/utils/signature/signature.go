@@ -0,0 +1,72 @@
+package signature
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Signature types
+const (
+	TypePersonal  string = "personal"
+	TypeTypedData string = "typed"
+)
+
+// Decode an EIP-712 typed data document
+func ParseTypedData(data []byte) (apitypes.TypedData, error) {
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal(data, &typedData); err != nil {
+		return apitypes.TypedData{}, fmt.Errorf("Invalid EIP-712 typed data: %w", err)
+	}
+	if typedData.PrimaryType == "" {
+		return apitypes.TypedData{}, fmt.Errorf("Invalid EIP-712 typed data: it has no primary type")
+	}
+	return typedData, nil
+}
+
+// Get the hash that is signed for an EIP-712 typed data document
+func HashTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("Could not hash the EIP-712 typed data: %w", err)
+	}
+	return hash, nil
+}
+
+// Get the address that signed a message with the Ethereum signed message prefix (EIP-191 personal_sign)
+func RecoverPersonalSigner(message []byte, signature []byte) (common.Address, error) {
+	return recoverSigner(accounts.TextHash(message), signature)
+}
+
+// Get the address that signed an EIP-712 typed data document
+func RecoverTypedDataSigner(typedData apitypes.TypedData, signature []byte) (common.Address, error) {
+	hash, err := HashTypedData(typedData)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return recoverSigner(hash, signature)
+}
+
+// Get the address that signed a hash, accepting both 0/1 and 27/28 recovery IDs
+func recoverSigner(hash []byte, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("Invalid signature length %d, expected %d bytes", len(signature), crypto.SignatureLength)
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	if sig[crypto.RecoveryIDOffset] > 1 {
+		return common.Address{}, fmt.Errorf("Invalid signature recovery ID %d", signature[crypto.RecoveryIDOffset])
+	}
+	pubkey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("Could not recover the signer: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pubkey), nil
+}
@@ -0,0 +1,74 @@
+package signature
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const testTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"}
+		],
+		"Ownership": [
+			{"name": "operator", "type": "address"},
+			{"name": "statement", "type": "string"}
+		]
+	},
+	"primaryType": "Ownership",
+	"domain": {"name": "Stader", "version": "1", "chainId": "1"},
+	"message": {"operator": "0x00000000000000000000000000000000000000aa", "statement": "I operate this node"}
+}`
+
+func TestRecoverSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	// Personal messages, with either recovery ID convention
+	message := []byte("I operate this node")
+	sig, err := crypto.Sign(accounts.TextHash(message), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer, err := RecoverPersonalSigner(message, sig); err != nil || signer != address {
+		t.Errorf("expected %s to be recovered, got %s: %v", address.Hex(), signer.Hex(), err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	if signer, err := RecoverPersonalSigner(message, sig); err != nil || signer != address {
+		t.Errorf("expected %s to be recovered with v >= 27, got %s: %v", address.Hex(), signer.Hex(), err)
+	}
+	if signer, err := RecoverPersonalSigner([]byte("something else"), sig); err == nil && signer == address {
+		t.Error("a different message recovered the same signer")
+	}
+	if _, err := RecoverPersonalSigner(message, sig[:64]); err == nil {
+		t.Error("expected a short signature to be rejected")
+	}
+
+	// Typed data
+	typedData, err := ParseTypedData([]byte(testTypedData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := HashTypedData(typedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err = crypto.Sign(hash, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	if signer, err := RecoverTypedDataSigner(typedData, sig); err != nil || signer != address {
+		t.Errorf("expected %s to be recovered from the typed data, got %s: %v", address.Hex(), signer.Hex(), err)
+	}
+	if signer, err := RecoverPersonalSigner([]byte(testTypedData), sig); err == nil && signer == address {
+		t.Error("a typed data signature was accepted as a personal signature")
+	}
+}
@@ -4,11 +4,15 @@ import (
 	"crypto/rsa"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/beacon"
 	stader_backend "github.com/stader-labs/stader-node/shared/types/stader-backend"
 	"github.com/stader-labs/stader-node/shared/utils/crypto"
+	"github.com/stader-labs/stader-node/shared/utils/eth2"
 	"github.com/stader-labs/stader-node/shared/utils/net"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
 	"github.com/stader-labs/stader-node/stader-lib/types"
 	"github.com/urfave/cli"
 )
@@ -122,3 +126,47 @@ func GetPublicKey(c *cli.Context) (*rsa.PublicKey, error) {
 
 	return publicKey, nil
 }
+
+// Builds the presigned exit message for a validator, with the exit signature encrypted for the stader backend.
+// Returns false if the validator should not have a presigned message, i.e. it isn't on the beacon chain yet or is already exiting.
+func BuildPresignedMessage(bc beacon.Client, signer validator.Signer, validatorPubKey types.ValidatorPubkey, exitEpoch uint64, publicKey *rsa.PublicKey) (stader_backend.PreSignSendApiRequestType, bool, error) {
+	// check if validator has not yet been registered on beacon chain
+	validatorStatus, err := bc.GetValidatorStatus(validatorPubKey, nil)
+	if err != nil {
+		return stader_backend.PreSignSendApiRequestType{}, false, fmt.Errorf("error finding validator status: %w", err)
+	}
+	if !validatorStatus.Exists {
+		return stader_backend.PreSignSendApiRequestType{}, false, nil
+	}
+
+	// check if validator is already in an exiting phase, then no point sending a pre-signed message
+	if eth2.IsValidatorExiting(validatorStatus) {
+		return stader_backend.PreSignSendApiRequestType{}, false, nil
+	}
+
+	exitFork, err := validator.GetExitFork(bc)
+	if err != nil {
+		return stader_backend.PreSignSendApiRequestType{}, false, fmt.Errorf("failed to get the signature domain from beacon chain: %w", err)
+	}
+
+	// get the presigned msg
+	exitSignature, _, err := validator.GetSignedExitMessage(signer, validatorStatus.Index, exitEpoch, exitFork)
+	if err != nil {
+		return stader_backend.PreSignSendApiRequestType{}, false, fmt.Errorf("failed to generate the SignedExitMessage for validator with beacon chain index %d: %w", validatorStatus.Index, err)
+	}
+
+	// encrypt the signature
+	exitSignatureEncrypted, err := crypto.EncryptUsingPublicKey([]byte(exitSignature.String()), publicKey)
+	if err != nil {
+		return stader_backend.PreSignSendApiRequestType{}, false, fmt.Errorf("failed to encrypt exit signature: %w", err)
+	}
+
+	preSignedMessage := stader_backend.PreSignSendApiRequestType{
+		Signature:          crypto.EncodeBase64(exitSignatureEncrypted),
+		ValidatorPublicKey: validatorPubKey.String(),
+	}
+	preSignedMessage.Message.Epoch = strconv.FormatUint(exitEpoch, 10)
+	preSignedMessage.Message.ValidatorIndex = strconv.FormatUint(validatorStatus.Index, 10)
+
+	return preSignedMessage, true, nil
+}
@@ -0,0 +1,152 @@
+package stader
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"strconv"
+	"testing"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/services/beacon/mock"
+	"github.com/stader-labs/stader-node/shared/types/eth2"
+	"github.com/stader-labs/stader-node/shared/utils/crypto"
+	hexutil "github.com/stader-labs/stader-node/shared/utils/hex"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+const testHeadEpoch uint64 = 1000
+
+func TestBuildPresignedMessage(t *testing.T) {
+	config := mock.DefaultConfig()
+	server := mock.NewServer(config, testHeadEpoch*config.SlotsPerEpoch)
+	defer server.Close()
+	bc := server.Client()
+
+	key, pubkey, err := mock.GenerateValidatorKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.AddActiveValidators(5, 32e9)
+	index := server.AddValidator(mock.Validator{
+		Pubkey:           pubkey,
+		Balance:          32e9,
+		EffectiveBalance: 32e9,
+		Status:           beacon.ValidatorState_ActiveOngoing,
+		ActivationEpoch:  100,
+	})
+
+	backendKey, err := rsa.GenerateKey(rand.Reader, 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, ok, err := BuildPresignedMessage(bc, validator.NewLocalSigner(key), pubkey, testHeadEpoch, &backendKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("expected a presigned message for an active validator")
+	}
+	if message.ValidatorPublicKey != pubkey.String() {
+		t.Errorf("expected validator public key %s, got %s", pubkey.String(), message.ValidatorPublicKey)
+	}
+	if message.Message.Epoch != strconv.FormatUint(testHeadEpoch, 10) {
+		t.Errorf("expected epoch %d, got %s", testHeadEpoch, message.Message.Epoch)
+	}
+	if message.Message.ValidatorIndex != strconv.FormatUint(index, 10) {
+		t.Errorf("expected validator index %d, got %s", index, message.Message.ValidatorIndex)
+	}
+
+	// Decrypt the signature the way the backend does, and check it signs the exit
+	encrypted, err := crypto.DecodeBase64(message.Signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, backendKey, encrypted, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signatureBytes, err := hexutil.Decode(hexutil.AddPrefix(string(decrypted)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := eth2types.BLSSignatureFromBytes(signatureBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	domain, err := bc.GetExitDomainData(eth2types.DomainVoluntaryExit[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	exitMessage := eth2.VoluntaryExit{
+		Epoch:          testHeadEpoch,
+		ValidatorIndex: index,
+	}
+	objectRoot, err := exitMessage.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingRoot, err := (&eth2.SigningRoot{ObjectRoot: objectRoot[:], Domain: domain}).HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !signature.Verify(signingRoot[:], key.PublicKey()) {
+		t.Error("presigned exit signature does not verify")
+	}
+}
+
+func TestBuildPresignedMessageSkipsIneligibleValidators(t *testing.T) {
+	config := mock.DefaultConfig()
+	server := mock.NewServer(config, testHeadEpoch*config.SlotsPerEpoch)
+	defer server.Close()
+	bc := server.Client()
+
+	backendKey, err := rsa.GenerateKey(rand.Reader, 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A validator that hasn't been deposited yet
+	unknownKey, unknownPubkey, err := mock.GenerateValidatorKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ok, err := BuildPresignedMessage(bc, validator.NewLocalSigner(unknownKey), unknownPubkey, testHeadEpoch, &backendKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Error("expected no presigned message for a validator that isn't on the beacon chain")
+	}
+
+	// A validator that is already exiting
+	exitingKey, exitingPubkey, err := mock.GenerateValidatorKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.AddValidator(mock.Validator{
+		Pubkey:           exitingPubkey,
+		Balance:          32e9,
+		EffectiveBalance: 32e9,
+		Status:           beacon.ValidatorState_ActiveExiting,
+		ActivationEpoch:  100,
+		ExitEpoch:        testHeadEpoch + 5,
+	})
+	_, ok, err = BuildPresignedMessage(bc, validator.NewLocalSigner(exitingKey), exitingPubkey, testHeadEpoch, &backendKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Error("expected no presigned message for an exiting validator")
+	}
+
+	// Beacon node errors are surfaced rather than treated as ineligibility
+	server.FailRequests("/eth/v1/beacon/states/head/validators", 500, 1)
+	_, _, err = BuildPresignedMessage(bc, validator.NewLocalSigner(exitingKey), exitingPubkey, testHeadEpoch, &backendKey.PublicKey)
+	if err == nil {
+		t.Error("expected an error when the beacon node fails")
+	}
+}
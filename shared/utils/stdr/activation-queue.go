@@ -0,0 +1,127 @@
+package stdr
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/utils/eth2"
+	"github.com/stader-labs/stader-node/stader-lib/contracts"
+	"github.com/stader-labs/stader-node/stader-lib/node"
+	pool_utils "github.com/stader-labs/stader-node/stader-lib/pool-utils"
+	"github.com/stader-labs/stader-node/stader-lib/stader"
+	"github.com/stader-labs/stader-node/stader-lib/tokens"
+	"github.com/stader-labs/stader-node/stader-lib/utils/eth"
+)
+
+// A snapshot of the permissionless pool's deposit queue
+type StaderQueue struct {
+	// Validator ID to position in the queue
+	Positions map[uint64]uint64
+	Length    uint64
+
+	// The number of validators at the front of the queue that the stake pool manager currently holds enough ETH for.
+	// The ETH is shared with the other pools, so this is an upper bound.
+	FundableValidators uint64
+}
+
+// Where a validator is in the Stader and Beacon chain queues, and when it should be activated
+type ActivationEstimate struct {
+	InStaderQueue       bool      `json:"inStaderQueue"`
+	StaderQueuePosition uint64    `json:"staderQueuePosition"`
+	StaderQueueLength   uint64    `json:"staderQueueLength"`
+	StaderQueueFunded   bool      `json:"staderQueueFunded"`
+	InBeaconQueue       bool      `json:"inBeaconQueue"`
+	BeaconQueuePosition uint64    `json:"beaconQueuePosition"`
+	BeaconQueueLength   uint64    `json:"beaconQueueLength"`
+	BeaconChurnLimit    uint64    `json:"beaconChurnLimit"`
+	HasEta              bool      `json:"hasEta"`
+	ActivationEpoch     uint64    `json:"activationEpoch"`
+	ActivationTime      time.Time `json:"activationTime"`
+}
+
+// Get the permissionless pool's deposit queue
+func GetStaderQueue(pnr *stader.PermissionlessNodeRegistryContractManager, putils *stader.PoolUtilsContractManager, mc *stader.MultiCaller, stakePoolManagerAddress common.Address, opts *bind.CallOpts) (*StaderQueue, error) {
+
+	nextIndex, err := node.GetNextQueuedValidatorIndex(pnr, opts)
+	if err != nil {
+		return nil, err
+	}
+	queueSize, err := node.GetValidatorQueueSize(pnr, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	validatorIds, err := node.GetQueuedValidatorIds(pnr, mc, nextIndex, queueSize, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := StaderQueue{
+		Positions: map[uint64]uint64{},
+	}
+	for _, validatorId := range validatorIds {
+		queue.Positions[validatorId.Uint64()] = queue.Length
+		queue.Length++
+	}
+
+	// Each validator needs the pool to top up the operator's collateral to 32 ETH
+	collateral, err := pool_utils.GetCollateralETH(putils, 1, opts)
+	if err != nil {
+		return nil, err
+	}
+	poolDeposit := new(big.Int).Sub(eth.EthToWei(32), collateral)
+	poolBalance, err := tokens.GetEthBalance(pnr.Client, stakePoolManagerAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	if poolDeposit.Sign() > 0 {
+		queue.FundableValidators = new(big.Int).Div(poolBalance, poolDeposit).Uint64()
+	}
+
+	return &queue, nil
+
+}
+
+// Check if a validator is still waiting to be activated
+func IsAwaitingActivation(validatorContractInfo contracts.Validator, beaconStatus beacon.ValidatorStatus) bool {
+	if validatorContractInfo.Status == ValidatorStatus_Queued {
+		return true
+	}
+	return validatorContractInfo.Status == ValidatorStatus_Deposited && (!beaconStatus.Exists || eth2.IsValidatorQueued(beaconStatus))
+}
+
+// Estimate when a validator will be activated. Validators still in the Stader queue only get an ETA if the pool can
+// fund them right away, in which case they're assumed to join the back of the Beacon chain queue.
+func EstimateActivation(validatorId uint64, validatorContractInfo contracts.Validator, beaconStatus beacon.ValidatorStatus, staderQueue *StaderQueue, beaconQueue *eth2.ActivationQueue) ActivationEstimate {
+
+	estimate := ActivationEstimate{
+		StaderQueueLength: staderQueue.Length,
+		BeaconQueueLength: uint64(len(beaconQueue.Pending)),
+		BeaconChurnLimit:  beaconQueue.ChurnLimit,
+	}
+
+	if validatorContractInfo.Status == ValidatorStatus_Queued {
+		position, exists := staderQueue.Positions[validatorId]
+		if !exists {
+			return estimate
+		}
+		estimate.InStaderQueue = true
+		estimate.StaderQueuePosition = position
+		estimate.StaderQueueFunded = position < staderQueue.FundableValidators
+		if !estimate.StaderQueueFunded {
+			return estimate
+		}
+		_, estimate.ActivationEpoch = beaconQueue.Estimate(beacon.ValidatorStatus{})
+	} else {
+		estimate.InBeaconQueue = beaconStatus.Status == beacon.ValidatorState_PendingQueued
+		estimate.BeaconQueuePosition, estimate.ActivationEpoch = beaconQueue.Estimate(beaconStatus)
+	}
+
+	estimate.HasEta = true
+	estimate.ActivationTime = eth2.EpochTime(beaconQueue.Config, estimate.ActivationEpoch)
+	return estimate
+
+}
@@ -47,3 +47,18 @@ func GetFeeRecipientInfo(prn *stader.PermissionlessNodeRegistryContractManager,
 
 	return &feeRecipientInfo, nil
 }
+
+// Get the fee recipient the validator client should be using. While the node's socializing pool opt-in is still within
+// its cooldown window, the fee recipient from before the change is kept.
+func GetCorrectFeeRecipient(feeRecipientInfo *FeeRecipientInfo, updatable bool) common.Address {
+	if feeRecipientInfo.IsInSocializingPool {
+		if updatable {
+			return feeRecipientInfo.SocializingPoolAddress
+		}
+		return feeRecipientInfo.FeeDistributorAddress
+	}
+	if updatable {
+		return feeRecipientInfo.FeeDistributorAddress
+	}
+	return feeRecipientInfo.SocializingPoolAddress
+}
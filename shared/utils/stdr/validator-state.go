@@ -12,13 +12,23 @@ import (
 	"time"
 )
 
+// Validator statuses in the permissionless node registry
+const (
+	ValidatorStatus_Initialized      uint8 = 0
+	ValidatorStatus_InvalidSignature uint8 = 1
+	ValidatorStatus_FrontRun         uint8 = 2
+	ValidatorStatus_Queued           uint8 = 3
+	ValidatorStatus_Deposited        uint8 = 4
+	ValidatorStatus_Withdrawn        uint8 = 5
+)
+
 var ValidatorState = map[uint8]string{
-	0: "Initialized",
-	1: "Invalid Signature Submitted",
-	2: "Validator Deposit Front Run",
-	3: "Validator Queued for 28Eth deposit",
-	4: "Validator has been matched with 28Eth deposit",
-	5: "Funds Settled",
+	ValidatorStatus_Initialized:      "Initialized",
+	ValidatorStatus_InvalidSignature: "Invalid Signature Submitted",
+	ValidatorStatus_FrontRun:         "Validator Deposit Front Run",
+	ValidatorStatus_Queued:           "Validator Queued for 28Eth deposit",
+	ValidatorStatus_Deposited:        "Validator has been matched with 28Eth deposit",
+	ValidatorStatus_Withdrawn:        "Funds Settled",
 }
 
 type ValidatorInfo struct {
@@ -36,12 +46,28 @@ type ValidatorInfo struct {
 	DepositTime                      time.Time
 	WithdrawnBlock                   *big.Int
 	WithdrawnTime                    time.Time
+	ActivationEstimate               *ActivationEstimate
+	WithdrawalEstimate               *WithdrawalEstimate
 }
 
-func GetAllValidatorsRegisteredWithOperator(pnr *stader.PermissionlessNodeRegistryContractManager, operatorId *big.Int, operatorAddress common.Address, opts *bind.CallOpts) (map[types.ValidatorPubkey]contracts.Validator, []types.ValidatorPubkey, error) {
-	validators, err := node.GetAllValidatorsInfoByOperator(pnr, operatorAddress, opts)
-	if err != nil {
-		return nil, []types.ValidatorPubkey{}, err
+// Get all of an operator's validators. If a multicaller is provided, the registry is read in a single batch.
+func GetAllValidatorsRegisteredWithOperator(pnr *stader.PermissionlessNodeRegistryContractManager, mc *stader.MultiCaller, operatorId *big.Int, operatorAddress common.Address, opts *bind.CallOpts) (map[types.ValidatorPubkey]contracts.Validator, []types.ValidatorPubkey, error) {
+	var validators []contracts.Validator
+	if mc != nil {
+		totalKeys, err := node.GetTotalValidatorKeys(pnr, operatorId, opts)
+		if err != nil {
+			return nil, []types.ValidatorPubkey{}, err
+		}
+		validators, err = node.GetAllValidatorsInfoByOperatorWithMulticall(pnr, mc, operatorAddress, totalKeys, opts)
+		if err != nil {
+			return nil, []types.ValidatorPubkey{}, err
+		}
+	} else {
+		var err error
+		validators, err = node.GetAllValidatorsInfoByOperator(pnr, operatorAddress, opts)
+		if err != nil {
+			return nil, []types.ValidatorPubkey{}, err
+		}
 	}
 
 	validatorInfoMap := make(map[types.ValidatorPubkey]contracts.Validator)
@@ -52,7 +78,7 @@ func GetAllValidatorsRegisteredWithOperator(pnr *stader.PermissionlessNodeRegist
 		validatorPubKeys = append(validatorPubKeys, pubKey)
 	}
 
-	return validatorInfoMap, validatorPubKeys, err
+	return validatorInfoMap, validatorPubKeys, nil
 
 }
 
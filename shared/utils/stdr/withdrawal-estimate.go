@@ -0,0 +1,54 @@
+package stdr
+
+import (
+	"time"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/utils/eth2"
+)
+
+// When an exiting validator's funds should reach its withdraw vault
+type WithdrawalEstimate struct {
+	ExitEpoch         uint64    `json:"exitEpoch"`
+	ExitTime          time.Time `json:"exitTime"`
+	WithdrawableEpoch uint64    `json:"withdrawableEpoch"`
+	WithdrawableTime  time.Time `json:"withdrawableTime"`
+	HasFundsEta       bool      `json:"hasFundsEta"`
+	FundsTime         time.Time `json:"fundsTime"`
+}
+
+// Check if a validator has started exiting but its balance hasn't been withdrawn yet
+func IsAwaitingWithdrawal(beaconStatus beacon.ValidatorStatus) bool {
+	switch beaconStatus.Status {
+	case beacon.ValidatorState_ActiveExiting,
+		beacon.ValidatorState_ExitedUnslashed,
+		beacon.ValidatorState_ExitedSlashed,
+		beacon.ValidatorState_WithdrawalPossible:
+		return true
+	}
+	return false
+}
+
+// Estimate when an exiting validator's funds will be withdrawn. If its exit hasn't been processed yet, the exit epoch
+// comes from the exit queue. The sweep can be nil if it couldn't be measured, in which case there's no funds ETA.
+func EstimateWithdrawal(beaconStatus beacon.ValidatorStatus, exitQueue *eth2.ExitQueue, sweep *eth2.WithdrawalSweep) WithdrawalEstimate {
+
+	config := exitQueue.Config
+	estimate := WithdrawalEstimate{
+		ExitEpoch:         beaconStatus.ExitEpoch,
+		WithdrawableEpoch: beaconStatus.WithdrawableEpoch,
+	}
+	if !beaconStatus.Exists || beaconStatus.ExitEpoch == eth2.FarFutureEpoch {
+		estimate.ExitEpoch = exitQueue.EstimateExitEpoch()
+		estimate.WithdrawableEpoch = estimate.ExitEpoch + config.MinValidatorWithdrawabilityDelay
+	}
+	estimate.ExitTime = eth2.EpochTime(config, estimate.ExitEpoch)
+	estimate.WithdrawableTime = eth2.EpochTime(config, estimate.WithdrawableEpoch)
+
+	if sweep != nil && beaconStatus.Exists {
+		estimate.HasFundsEta = true
+		estimate.FundsTime = sweep.EstimateWithdrawalTime(beaconStatus.Index, estimate.WithdrawableEpoch)
+	}
+	return estimate
+
+}
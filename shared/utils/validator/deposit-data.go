@@ -20,35 +20,38 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package validator
 
 import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stader-labs/stader-node/shared/types/eth2"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/stader-labs/stader-node/shared/services/beacon"
+	hexutil "github.com/stader-labs/stader-node/shared/utils/hex"
 )
 
-// Get deposit data & root for a given validator key and withdrawal credentials
-func GetDepositData(validatorKey *eth2types.BLSPrivateKey, withdrawalCredentials common.Hash, eth2Config beacon.Eth2Config, amount uint64) (eth2.DepositData, common.Hash, error) {
+// Get deposit data & root for a given validator and withdrawal credentials
+func GetDepositData(signer Signer, withdrawalCredentials common.Hash, eth2Config beacon.Eth2Config, amount uint64) (eth2.DepositData, common.Hash, error) {
 	// Build deposit data
+	pubkey := signer.PublicKey()
 	dd := eth2.DepositDataNoSignature{
-		PublicKey:             validatorKey.PublicKey().Marshal(),
+		PublicKey:             pubkey.Bytes(),
 		WithdrawalCredentials: withdrawalCredentials[:],
 		Amount:                amount,
 	}
 
-	// Get signing root
-	or, err := dd.HashTreeRoot()
+	// Get signing root with domain
+	srHash, err := getDepositSigningRoot(dd, eth2Config.GenesisForkVersion)
 	if err != nil {
 		return eth2.DepositData{}, common.Hash{}, err
 	}
 
-	sr := eth2.SigningRoot{
-		ObjectRoot: or[:],
-		Domain:     eth2types.Domain(eth2types.DomainDeposit, eth2Config.GenesisForkVersion, eth2types.ZeroGenesisValidatorsRoot),
-	}
-
-	// Get signing root with domain
-	srHash, err := sr.HashTreeRoot()
+	// Sign it
+	signature, err := signer.SignDeposit(dd, eth2Config.GenesisForkVersion, srHash)
 	if err != nil {
 		return eth2.DepositData{}, common.Hash{}, err
 	}
@@ -58,7 +61,7 @@ func GetDepositData(validatorKey *eth2types.BLSPrivateKey, withdrawalCredentials
 		PublicKey:             dd.PublicKey,
 		WithdrawalCredentials: dd.WithdrawalCredentials,
 		Amount:                dd.Amount,
-		Signature:             validatorKey.Sign(srHash[:]).Marshal(),
+		Signature:             signature.Bytes(),
 	}
 
 	// Get deposit data root
@@ -71,3 +74,157 @@ func GetDepositData(validatorKey *eth2types.BLSPrivateKey, withdrawalCredentials
 	return depositData, depositDataRoot, nil
 
 }
+
+// The staking-deposit-cli version written to exported deposit data, which the launchpad requires to be recent
+const DepositCliVersion = "2.7.0"
+
+// Network names used by the staking-deposit-cli, by genesis fork version
+var depositNetworkNames = map[string]string{
+	"00000000": "mainnet",
+	"00001020": "goerli",
+	"01017000": "holesky",
+	"90000069": "sepolia",
+}
+
+var initBLS sync.Once
+
+// Get the staking-deposit-cli network name of a genesis fork version
+func GetDepositNetworkName(genesisForkVersion []byte) (string, bool) {
+	name, exists := depositNetworkNames[hex.EncodeToString(genesisForkVersion)]
+	return name, exists
+}
+
+// Convert signed deposit data to the staking-deposit-cli format
+func GetLaunchpadDepositData(depositData eth2.DepositData, genesisForkVersion []byte, networkName string) (eth2.LaunchpadDepositData, error) {
+	depositMessage := getDepositMessage(depositData)
+	depositMessageRoot, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		return eth2.LaunchpadDepositData{}, err
+	}
+	depositDataRoot, err := depositData.HashTreeRoot()
+	if err != nil {
+		return eth2.LaunchpadDepositData{}, err
+	}
+	return eth2.LaunchpadDepositData{
+		PublicKey:             hex.EncodeToString(depositData.PublicKey),
+		WithdrawalCredentials: hex.EncodeToString(depositData.WithdrawalCredentials),
+		Amount:                depositData.Amount,
+		Signature:             hex.EncodeToString(depositData.Signature),
+		DepositMessageRoot:    hex.EncodeToString(depositMessageRoot[:]),
+		DepositDataRoot:       hex.EncodeToString(depositDataRoot[:]),
+		ForkVersion:           hex.EncodeToString(genesisForkVersion),
+		NetworkName:           networkName,
+		DepositCliVersion:     DepositCliVersion,
+	}, nil
+}
+
+// Check that deposit data in the staking-deposit-cli format is for a network, that its roots match its contents,
+// and that it is signed by its validator key. Returns the decoded deposit data.
+func VerifyLaunchpadDepositData(deposit eth2.LaunchpadDepositData, genesisForkVersion []byte) (eth2.DepositData, error) {
+
+	// Decode the deposit
+	pubkey, err := decodeDepositField("pubkey", deposit.PublicKey, 48)
+	if err != nil {
+		return eth2.DepositData{}, err
+	}
+	withdrawalCredentials, err := decodeDepositField("withdrawal_credentials", deposit.WithdrawalCredentials, 32)
+	if err != nil {
+		return eth2.DepositData{}, err
+	}
+	signature, err := decodeDepositField("signature", deposit.Signature, 96)
+	if err != nil {
+		return eth2.DepositData{}, err
+	}
+	depositData := eth2.DepositData{
+		PublicKey:             pubkey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                deposit.Amount,
+		Signature:             signature,
+	}
+
+	// Check the network
+	forkVersion, err := decodeDepositField("fork_version", deposit.ForkVersion, 4)
+	if err != nil {
+		return eth2.DepositData{}, err
+	}
+	if !bytes.Equal(forkVersion, genesisForkVersion) {
+		return eth2.DepositData{}, fmt.Errorf("the fork version %s is not this network's genesis fork version %s", deposit.ForkVersion, hex.EncodeToString(genesisForkVersion))
+	}
+	if networkName, exists := GetDepositNetworkName(genesisForkVersion); exists && deposit.NetworkName != networkName {
+		return eth2.DepositData{}, fmt.Errorf("the network name %s does not match the fork version, which is %s's", deposit.NetworkName, networkName)
+	}
+
+	// Check the roots
+	expected, err := GetLaunchpadDepositData(depositData, genesisForkVersion, deposit.NetworkName)
+	if err != nil {
+		return eth2.DepositData{}, err
+	}
+	if deposit.DepositMessageRoot != expected.DepositMessageRoot {
+		return eth2.DepositData{}, fmt.Errorf("the deposit_message_root %s does not match the deposit, which has %s", deposit.DepositMessageRoot, expected.DepositMessageRoot)
+	}
+	if deposit.DepositDataRoot != expected.DepositDataRoot {
+		return eth2.DepositData{}, fmt.Errorf("the deposit_data_root %s does not match the deposit, which has %s", deposit.DepositDataRoot, expected.DepositDataRoot)
+	}
+
+	// Check the signature
+	var blsErr error
+	initBLS.Do(func() {
+		blsErr = eth2types.InitBLS()
+	})
+	if blsErr != nil {
+		return eth2.DepositData{}, blsErr
+	}
+	signingRoot, err := getDepositSigningRoot(getDepositMessage(depositData), genesisForkVersion)
+	if err != nil {
+		return eth2.DepositData{}, err
+	}
+	blsPubkey, err := eth2types.BLSPublicKeyFromBytes(pubkey)
+	if err != nil {
+		return eth2.DepositData{}, fmt.Errorf("the pubkey is not a valid BLS public key: %w", err)
+	}
+	blsSignature, err := eth2types.BLSSignatureFromBytes(signature)
+	if err != nil {
+		return eth2.DepositData{}, fmt.Errorf("the signature is not a valid BLS signature: %w", err)
+	}
+	if !blsSignature.Verify(signingRoot[:], blsPubkey) {
+		return eth2.DepositData{}, errors.New("the signature was not made by the validator key")
+	}
+
+	// Return
+	return depositData, nil
+
+}
+
+// Get the deposit message a deposit's signature is over
+func getDepositMessage(depositData eth2.DepositData) eth2.DepositDataNoSignature {
+	return eth2.DepositDataNoSignature{
+		PublicKey:             depositData.PublicKey,
+		WithdrawalCredentials: depositData.WithdrawalCredentials,
+		Amount:                depositData.Amount,
+	}
+}
+
+// Get the root a deposit message is signed over, in the deposit domain of a network
+func getDepositSigningRoot(dd eth2.DepositDataNoSignature, genesisForkVersion []byte) ([32]byte, error) {
+	or, err := dd.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	sr := eth2.SigningRoot{
+		ObjectRoot: or[:],
+		Domain:     eth2types.Domain(eth2types.DomainDeposit, genesisForkVersion, eth2types.ZeroGenesisValidatorsRoot),
+	}
+	return sr.HashTreeRoot()
+}
+
+// Decode a hex field of a staking-deposit-cli deposit
+func decodeDepositField(name string, value string, length int) ([]byte, error) {
+	decoded, err := hex.DecodeString(hexutil.RemovePrefix(value))
+	if err != nil {
+		return nil, fmt.Errorf("the %s is not valid hex: %w", name, err)
+	}
+	if len(decoded) != length {
+		return nil, fmt.Errorf("the %s is %d bytes long instead of %d", name, len(decoded), length)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,69 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/services/beacon/mock"
+)
+
+func TestLaunchpadDepositData(t *testing.T) {
+	key, pubkey, err := mock.GenerateValidatorKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	eth2Config := beacon.Eth2Config{GenesisForkVersion: []byte{0x01, 0x01, 0x70, 0x00}}
+	withdrawalCredentials := common.HexToHash("0x010000000000000000000000000000000000000000000000000000000000beef")
+	depositData, depositDataRoot, err := GetDepositData(NewLocalSigner(key), withdrawalCredentials, eth2Config, 31e9)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	networkName, _ := GetDepositNetworkName(eth2Config.GenesisForkVersion)
+	deposit, err := GetLaunchpadDepositData(depositData, eth2Config.GenesisForkVersion, networkName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deposit.NetworkName != "holesky" || deposit.ForkVersion != "01017000" {
+		t.Errorf("unexpected network %s with fork version %s", deposit.NetworkName, deposit.ForkVersion)
+	}
+	if deposit.PublicKey != pubkey.Hex() || deposit.DepositDataRoot != common.Hash(depositDataRoot).Hex()[2:] {
+		t.Errorf("the deposit doesn't match the deposit data: %+v", deposit)
+	}
+	if _, err := VerifyLaunchpadDepositData(deposit, eth2Config.GenesisForkVersion); err != nil {
+		t.Fatalf("expected the deposit to verify, got %s", err.Error())
+	}
+
+	// Deposits for another network are rejected
+	if _, err := VerifyLaunchpadDepositData(deposit, []byte{0x00, 0x00, 0x00, 0x00}); err == nil {
+		t.Error("expected a deposit for another network to be rejected")
+	}
+
+	// So are deposits whose roots don't match
+	tampered := deposit
+	tampered.Amount = 32e9
+	if _, err := VerifyLaunchpadDepositData(tampered, eth2Config.GenesisForkVersion); err == nil {
+		t.Error("expected a deposit with a changed amount to be rejected")
+	}
+
+	// And deposits that were signed by another key, even with recomputed roots
+	otherKey, _, err := mock.GenerateValidatorKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherDepositData, _, err := GetDepositData(NewLocalSigner(otherKey), withdrawalCredentials, eth2Config, 31e9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := depositData
+	forged.Signature = otherDepositData.Signature
+	forgedDeposit, err := GetLaunchpadDepositData(forged, eth2Config.GenesisForkVersion, networkName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyLaunchpadDepositData(forgedDeposit, eth2Config.GenesisForkVersion); err == nil {
+		t.Error("expected a deposit signed by another key to be rejected")
+	}
+}
@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	eth2utils "github.com/stader-labs/stader-node/shared/utils/eth2"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+// Check which validator keys can be removed. A key can only be removed once its validator has exited and reached its
+// withdrawable epoch, after which it can never be asked to sign anything again.
+func GetKeyRemovalEligibility(bc beacon.Client, validatorPubkeys []types.ValidatorPubkey) (*api.CanRemoveValidatorKeysResponse, error) {
+
+	response := api.CanRemoveValidatorKeysResponse{
+		Keys: []api.ValidatorKeyRemoval{},
+	}
+	if len(validatorPubkeys) == 0 {
+		return &response, nil
+	}
+
+	beaconHead, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, err
+	}
+	response.CurrentEpoch = beaconHead.Epoch
+	validatorStatuses, err := bc.GetValidatorStatuses(validatorPubkeys, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pubkey := range validatorPubkeys {
+		removal := api.ValidatorKeyRemoval{
+			Pubkey: pubkey,
+		}
+		validatorStatus, exists := validatorStatuses[pubkey]
+		if !exists || !validatorStatus.Exists {
+			removal.ValidatorNotFound = true
+			response.Keys = append(response.Keys, removal)
+			continue
+		}
+		removal.BeaconStatus = string(validatorStatus.Status)
+		removal.WithdrawableEpoch = validatorStatus.WithdrawableEpoch
+		if eth2utils.IsValidatorWithdrawn(validatorStatus) ||
+			(isValidatorExited(validatorStatus) && beaconHead.Epoch >= validatorStatus.WithdrawableEpoch) {
+			removal.Removable = true
+		} else {
+			removal.NotWithdrawable = true
+		}
+		response.Keys = append(response.Keys, removal)
+	}
+
+	return &response, nil
+
+}
+
+// Check whether a validator has left the active set
+func isValidatorExited(validatorStatus beacon.ValidatorStatus) bool {
+	switch validatorStatus.Status {
+	case beacon.ValidatorState_ExitedUnslashed, beacon.ValidatorState_ExitedSlashed:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/services/beacon/mock"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+func TestKeyRemovalEligibility(t *testing.T) {
+	server := newTestServer(t)
+	bc := server.Client()
+	addValidator := func(status beacon.ValidatorState, withdrawableEpoch uint64) types.ValidatorPubkey {
+		_, pubkey, err := mock.GenerateValidatorKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		server.AddValidator(mock.Validator{
+			Pubkey:            pubkey,
+			Status:            status,
+			ActivationEpoch:   100,
+			ExitEpoch:         withdrawableEpoch - 256,
+			WithdrawableEpoch: withdrawableEpoch,
+		})
+		return pubkey
+	}
+	withdrawn := addValidator(beacon.ValidatorState_WithdrawalDone, 500)
+	exitedPast := addValidator(beacon.ValidatorState_ExitedUnslashed, testHeadEpoch)
+	exitedBefore := addValidator(beacon.ValidatorState_ExitedSlashed, testHeadEpoch+1)
+	active := addValidator(beacon.ValidatorState_ActiveOngoing, mock.FarFutureEpoch)
+	_, unknown, err := mock.GenerateValidatorKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eligibility, err := GetKeyRemovalEligibility(bc, []types.ValidatorPubkey{withdrawn, exitedPast, exitedBefore, active, unknown})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eligibility.CurrentEpoch != testHeadEpoch {
+		t.Errorf("expected epoch %d, got %d", testHeadEpoch, eligibility.CurrentEpoch)
+	}
+	expected := []struct {
+		removable bool
+		notFound  bool
+	}{{true, false}, {true, false}, {false, false}, {false, false}, {false, true}}
+	for i, key := range eligibility.Keys {
+		if key.Removable != expected[i].removable || key.ValidatorNotFound != expected[i].notFound || key.NotWithdrawable == (key.Removable || key.ValidatorNotFound) {
+			t.Errorf("key %d: unexpected eligibility %+v", i, key)
+		}
+	}
+}
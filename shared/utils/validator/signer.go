@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/services/beacon/client"
+	"github.com/stader-labs/stader-node/shared/services/web3signer"
+	"github.com/stader-labs/stader-node/shared/types/eth2"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+// Signs messages for a validator, whose key is either held by the node or by a remote signer
+type Signer interface {
+	// Get the validator's public key
+	PublicKey() types.ValidatorPubkey
+
+	// Sign a deposit, given its signing root
+	SignDeposit(deposit eth2.DepositDataNoSignature, genesisForkVersion []byte, signingRoot [32]byte) (types.ValidatorSignature, error)
+
+	// Sign a voluntary exit, given its signing root
+	SignVoluntaryExit(exit eth2.VoluntaryExit, fork ExitFork, signingRoot [32]byte) (types.ValidatorSignature, error)
+}
+
+// The fork voluntary exits are signed for, which is fixed at Capella since Deneb (EIP-7044)
+type ExitFork struct {
+	Version               []byte
+	GenesisValidatorsRoot []byte
+}
+
+// Get the fork to sign voluntary exits for
+func GetExitFork(bc beacon.Client) (ExitFork, error) {
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return ExitFork{}, err
+	}
+	forkVersion, err := hexutil.Decode(client.CapellaForkVersion)
+	if err != nil {
+		return ExitFork{}, err
+	}
+	return ExitFork{
+		Version:               forkVersion,
+		GenesisValidatorsRoot: eth2Config.GenesisValidatorsRoot,
+	}, nil
+}
+
+// Get the signature domain of voluntary exits
+func (f ExitFork) Domain() []byte {
+	return eth2types.Domain(eth2types.DomainVoluntaryExit, f.Version, f.GenesisValidatorsRoot)
+}
+
+// Signs with a validator key held by the node
+type LocalSigner struct {
+	key *eth2types.BLSPrivateKey
+}
+
+// Create a signer for a validator key held by the node
+func NewLocalSigner(key *eth2types.BLSPrivateKey) *LocalSigner {
+	return &LocalSigner{key: key}
+}
+
+func (s *LocalSigner) PublicKey() types.ValidatorPubkey {
+	return types.BytesToValidatorPubkey(s.key.PublicKey().Marshal())
+}
+
+func (s *LocalSigner) SignDeposit(deposit eth2.DepositDataNoSignature, genesisForkVersion []byte, signingRoot [32]byte) (types.ValidatorSignature, error) {
+	return types.BytesToValidatorSignature(s.key.Sign(signingRoot[:]).Marshal()), nil
+}
+
+func (s *LocalSigner) SignVoluntaryExit(exit eth2.VoluntaryExit, fork ExitFork, signingRoot [32]byte) (types.ValidatorSignature, error) {
+	return types.BytesToValidatorSignature(s.key.Sign(signingRoot[:]).Marshal()), nil
+}
+
+// Signs with a validator key held by a Web3Signer
+type RemoteSigner struct {
+	client *web3signer.Client
+	pubkey types.ValidatorPubkey
+}
+
+// Create a signer for a validator key held by a Web3Signer
+func NewRemoteSigner(client *web3signer.Client, pubkey types.ValidatorPubkey) *RemoteSigner {
+	return &RemoteSigner{
+		client: client,
+		pubkey: pubkey,
+	}
+}
+
+func (s *RemoteSigner) PublicKey() types.ValidatorPubkey {
+	return s.pubkey
+}
+
+func (s *RemoteSigner) SignDeposit(deposit eth2.DepositDataNoSignature, genesisForkVersion []byte, signingRoot [32]byte) (types.ValidatorSignature, error) {
+	return s.client.SignDeposit(s.pubkey, signingRoot, deposit.WithdrawalCredentials, deposit.Amount, genesisForkVersion)
+}
+
+func (s *RemoteSigner) SignVoluntaryExit(exit eth2.VoluntaryExit, fork ExitFork, signingRoot [32]byte) (types.ValidatorSignature, error) {
+	return s.client.SignVoluntaryExit(s.pubkey, signingRoot, exit.Epoch, exit.ValidatorIndex, web3signer.ForkInfo{
+		PreviousVersion:       fork.Version,
+		CurrentVersion:        fork.Version,
+		Epoch:                 0,
+		GenesisValidatorsRoot: fork.GenesisValidatorsRoot,
+	})
+}
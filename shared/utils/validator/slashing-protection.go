@@ -0,0 +1,195 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"github.com/stader-labs/stader-node/shared/services/config"
+	cfgtypes "github.com/stader-labs/stader-node/shared/types/config"
+)
+
+// Config
+const (
+	SlashingProtectionScript     = "/setup/slashing-protection.sh"
+	SlashingProtectionImportFile = "slashing-protection.json"
+	slashingProtectionExportFile = "slashing-protection-export.json"
+)
+
+// Get the name of the file the slashing protection history of removed validator keys is saved to in the validator
+// keychain folder; the source is optional and tells apart histories that come from somewhere other than the Validator client
+func GetRemovedSlashingProtectionFile(source string, removedAt time.Time) string {
+	if source != "" {
+		return fmt.Sprintf("slashing-protection-removed-%s-%s.json", source, removedAt.UTC().Format("20060102-150405"))
+	}
+	return fmt.Sprintf("slashing-protection-removed-%s.json", removedAt.UTC().Format("20060102-150405"))
+}
+
+// Export the EIP-3076 slashing protection history of the validator client
+func ExportSlashingProtection(cfg *config.StaderConfig, d *client.Client) ([]byte, error) {
+
+	if cfg.IsNativeMode {
+		return nil, errors.New("Exporting the slashing protection history is not supported in native mode")
+	}
+	if cfg.StaderNode.ProjectName.Value == "" {
+		return nil, errors.New("Stader docker project name not set")
+	}
+	containerName := cfg.StaderNode.ProjectName.Value.(string) + ValidatorContainerSuffix
+
+	// Run the export script; it writes to the validators folder which is shared with this container.
+	// Prysm locks its database while the validator client runs and the Nimbus validator client image can't export its
+	// history, so those are exported from a temporary container while the validator client is stopped.
+	cmd := []string{"sh", SlashingProtectionScript, "export", "/validators/" + slashingProtectionExportFile}
+	var output []byte
+	var exitCode int
+	var err error
+	cc, _ := cfg.GetSelectedConsensusClient()
+	switch cc {
+	case cfgtypes.ConsensusClient_Prysm:
+		output, exitCode, err = runWithValidatorStopped(d, containerName, "", cmd)
+	case cfgtypes.ConsensusClient_Nimbus:
+		output, exitCode, err = runWithValidatorStopped(d, containerName, cfg.Nimbus.BnContainerTag.Value.(string), cmd)
+	default:
+		output, exitCode, err = runInValidator(d, containerName, cmd)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if exitCode != 0 {
+		return nil, fmt.Errorf("The slashing protection export failed with exit code %d: %s", exitCode, bytes.TrimSpace(output))
+	}
+
+	// Read and remove the exported file
+	exportPath := filepath.Join(cfg.StaderNode.GetValidatorKeychainPath(), slashingProtectionExportFile)
+	data, err := ioutil.ReadFile(exportPath)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the exported slashing protection history: %w", err)
+	}
+	_ = os.Remove(exportPath)
+	return data, nil
+
+}
+
+// Run a command in the running validator container and return its output and exit code
+func runInValidator(d *client.Client, containerName string, cmd []string) ([]byte, int, error) {
+
+	exec, err := d.ContainerExecCreate(context.Background(), containerName, types.ExecConfig{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("Could not run the slashing protection script in %s: %w", containerName, err)
+	}
+	attach, err := d.ContainerExecAttach(context.Background(), exec.ID, types.ExecStartCheck{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("Could not run the slashing protection script in %s: %w", containerName, err)
+	}
+	var output bytes.Buffer
+	_, err = stdcopy.StdCopy(&output, &output, attach.Reader)
+	attach.Close()
+	if err != nil {
+		return nil, 0, fmt.Errorf("Error reading the slashing protection script output: %w", err)
+	}
+	inspect, err := d.ContainerExecInspect(context.Background(), exec.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Could not check the slashing protection script result: %w", err)
+	}
+	return output.Bytes(), inspect.ExitCode, nil
+
+}
+
+// Stop the validator container, run a command in a temporary container with the same environment and volumes, and start
+// the validator container again if it was running; the image defaults to the validator container's own
+func runWithValidatorStopped(d *client.Client, containerName string, image string, cmd []string) ([]byte, int, error) {
+
+	ctx := context.Background()
+	validatorContainer, err := d.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Could not find the validator container %s: %w", containerName, err)
+	}
+	if image == "" {
+		image = validatorContainer.Config.Image
+	}
+
+	// Pull the image if it isn't there yet, e.g. the beacon node image for an external beacon node
+	if _, _, err := d.ImageInspectWithRaw(ctx, image); client.IsErrNotFound(err) {
+		pull, err := d.ImagePull(ctx, image, types.ImagePullOptions{})
+		if err != nil {
+			return nil, 0, fmt.Errorf("Could not pull %s: %w", image, err)
+		}
+		_, err = io.Copy(ioutil.Discard, pull)
+		pull.Close()
+		if err != nil {
+			return nil, 0, fmt.Errorf("Could not pull %s: %w", image, err)
+		}
+	} else if err != nil {
+		return nil, 0, fmt.Errorf("Could not check for %s: %w", image, err)
+	}
+
+	// Stop the validator container, and start it again when done
+	if validatorContainer.State != nil && validatorContainer.State.Running {
+		timeout := int(validatorRestartTimeout.Seconds())
+		if err := d.ContainerStop(ctx, validatorContainer.ID, container.StopOptions{Timeout: &timeout}); err != nil {
+			return nil, 0, fmt.Errorf("Could not stop the validator container %s: %w", containerName, err)
+		}
+		defer func() {
+			_ = d.ContainerStart(ctx, validatorContainer.ID, types.ContainerStartOptions{})
+		}()
+	}
+
+	// Run the command and wait for it to finish
+	created, err := d.ContainerCreate(ctx, &container.Config{
+		Image:      image,
+		User:       "root",
+		Env:        validatorContainer.Config.Env,
+		Entrypoint: []string{cmd[0]},
+		Cmd:        cmd[1:],
+	}, &container.HostConfig{
+		VolumesFrom: []string{validatorContainer.ID},
+	}, nil, nil, "")
+	if err != nil {
+		return nil, 0, fmt.Errorf("Could not create a container for the slashing protection script: %w", err)
+	}
+	defer func() {
+		_ = d.ContainerRemove(ctx, created.ID, types.ContainerRemoveOptions{Force: true})
+	}()
+	waitCh, errCh := d.ContainerWait(ctx, created.ID, container.WaitConditionNextExit)
+	if err := d.ContainerStart(ctx, created.ID, types.ContainerStartOptions{}); err != nil {
+		return nil, 0, fmt.Errorf("Could not start the slashing protection script: %w", err)
+	}
+	var exitCode int
+	select {
+	case result := <-waitCh:
+		if result.Error != nil {
+			return nil, 0, fmt.Errorf("Error running the slashing protection script: %s", result.Error.Message)
+		}
+		exitCode = int(result.StatusCode)
+	case err := <-errCh:
+		return nil, 0, fmt.Errorf("Error running the slashing protection script: %w", err)
+	}
+
+	// Get its output
+	logs, err := d.ContainerLogs(ctx, created.ID, types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true})
+	if err != nil {
+		return nil, 0, fmt.Errorf("Error reading the slashing protection script output: %w", err)
+	}
+	defer logs.Close()
+	var output bytes.Buffer
+	if _, err := stdcopy.StdCopy(&output, &output, logs); err != nil {
+		return nil, 0, fmt.Errorf("Error reading the slashing protection script output: %w", err)
+	}
+	return output.Bytes(), exitCode, nil
+
+}
@@ -20,13 +20,18 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package validator
 
 import (
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/types/api"
 	"github.com/stader-labs/stader-node/shared/types/eth2"
+	eth2utils "github.com/stader-labs/stader-node/shared/utils/eth2"
 	"github.com/stader-labs/stader-node/stader-lib/types"
-	eth2types "github.com/wealdtech/go-eth2-types/v2"
 )
 
-// Get a voluntary exit message signature for a given validator key and index
-func GetSignedExitMessage(validatorKey *eth2types.BLSPrivateKey, validatorIndex uint64, epoch uint64, signatureDomain []byte) (types.ValidatorSignature, [32]byte, error) {
+// The number of epochs a validator must be active for before it can exit (SHARD_COMMITTEE_PERIOD)
+const shardCommitteePeriod uint64 = 256
+
+// Get a voluntary exit message signature for a given validator and index
+func GetSignedExitMessage(signer Signer, validatorIndex uint64, epoch uint64, fork ExitFork) (types.ValidatorSignature, [32]byte, error) {
 
 	// Build voluntary exit message
 	exitMessage := eth2.VoluntaryExit{
@@ -43,7 +48,7 @@ func GetSignedExitMessage(validatorKey *eth2types.BLSPrivateKey, validatorIndex
 	// Get signing root
 	sr := eth2.SigningRoot{
 		ObjectRoot: or[:],
-		Domain:     signatureDomain,
+		Domain:     fork.Domain(),
 	}
 
 	srHash, err := sr.HashTreeRoot()
@@ -52,9 +57,79 @@ func GetSignedExitMessage(validatorKey *eth2types.BLSPrivateKey, validatorIndex
 	}
 
 	// Sign message
-	signature := validatorKey.Sign(srHash[:]).Marshal()
+	signature, err := signer.SignVoluntaryExit(exitMessage, fork, srHash)
+	if err != nil {
+		return types.ValidatorSignature{}, [32]byte{}, err
+	}
 
 	// Return
-	return types.BytesToValidatorSignature(signature), srHash, nil
+	return signature, srHash, nil
+
+}
+
+// Check whether a validator is able to exit right now
+func GetExitEligibility(bc beacon.Client, validatorPubkey types.ValidatorPubkey) (*api.CanExitValidatorResponse, error) {
+
+	response := api.CanExitValidatorResponse{}
+
+	validatorStatus, err := bc.GetValidatorStatus(validatorPubkey, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !validatorStatus.Exists {
+		response.ValidatorNotRegistered = true
+		return &response, nil
+	}
+	if !eth2utils.IsValidatorActive(validatorStatus) {
+		response.ValidatorNotActive = true
+		return &response, nil
+	}
+	if eth2utils.IsValidatorExiting(validatorStatus) {
+		response.ValidatorExiting = true
+		return &response, nil
+	}
+
+	beaconHead, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, err
+	}
+	if validatorStatus.ActivationEpoch+shardCommitteePeriod > beaconHead.Epoch {
+		response.ValidatorTooYoung = true
+		return &response, nil
+	}
+
+	return &response, nil
+
+}
+
+// Sign a voluntary exit for a validator at the current epoch and broadcast it to the Beacon node
+func ExitValidator(bc beacon.Client, signer Signer, validatorPubkey types.ValidatorPubkey) error {
+
+	// Get beacon head
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return err
+	}
+
+	// Get the fork voluntary exits are signed for
+	fork, err := GetExitFork(bc)
+	if err != nil {
+		return err
+	}
+
+	// Get validator index
+	validatorIndex, err := bc.GetValidatorIndex(validatorPubkey)
+	if err != nil {
+		return err
+	}
+
+	// Get signed voluntary exit message
+	signature, _, err := GetSignedExitMessage(signer, validatorIndex, head.Epoch, fork)
+	if err != nil {
+		return err
+	}
+
+	// Broadcast voluntary exit message
+	return bc.ExitValidator(validatorIndex, head.Epoch, signature)
 
 }
@@ -0,0 +1,144 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/services/beacon/mock"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// Head of the fake chain, far enough from genesis for validators to be old enough to exit
+const testHeadEpoch uint64 = 1000
+
+func newTestServer(t *testing.T) *mock.Server {
+	config := mock.DefaultConfig()
+	server := mock.NewServer(config, testHeadEpoch*config.SlotsPerEpoch)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func addTestValidator(t *testing.T, server *mock.Server, status beacon.ValidatorState, activationEpoch uint64) (*eth2types.BLSPrivateKey, mock.Validator) {
+	key, pubkey, err := mock.GenerateValidatorKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := server.AddValidator(mock.Validator{
+		Pubkey:           pubkey,
+		Balance:          32e9,
+		EffectiveBalance: 32e9,
+		Status:           status,
+		ActivationEpoch:  activationEpoch,
+	})
+	validator, _ := server.GetValidator(index)
+	return key, validator
+}
+
+func TestExitValidator(t *testing.T) {
+	server := newTestServer(t)
+	bc := server.Client()
+	server.AddActiveValidators(10, 32e9)
+	key, validator := addTestValidator(t, server, beacon.ValidatorState_ActiveOngoing, 100)
+
+	eligibility, err := GetExitEligibility(bc, validator.Pubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eligibility.ValidatorNotRegistered || eligibility.ValidatorNotActive || eligibility.ValidatorExiting || eligibility.ValidatorTooYoung {
+		t.Fatalf("expected validator to be able to exit, got %+v", eligibility)
+	}
+
+	if err := ExitValidator(bc, NewLocalSigner(key), validator.Pubkey); err != nil {
+		t.Fatal(err)
+	}
+
+	// The fake node only accepts exits with a valid signature over the exit domain
+	exits := server.GetVoluntaryExits()
+	if len(exits) != 1 {
+		t.Fatalf("expected 1 voluntary exit, got %d", len(exits))
+	}
+	if exits[0].ValidatorIndex != validator.Index {
+		t.Errorf("expected exit for validator %d, got %d", validator.Index, exits[0].ValidatorIndex)
+	}
+	if exits[0].Epoch != testHeadEpoch {
+		t.Errorf("expected exit at epoch %d, got %d", testHeadEpoch, exits[0].Epoch)
+	}
+
+	// The validator is now exiting, so it can't exit again
+	status, err := bc.GetValidatorStatus(validator.Pubkey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != beacon.ValidatorState_ActiveExiting {
+		t.Errorf("expected validator status %s, got %s", beacon.ValidatorState_ActiveExiting, status.Status)
+	}
+	eligibility, err = GetExitEligibility(bc, validator.Pubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !eligibility.ValidatorNotActive && !eligibility.ValidatorExiting {
+		t.Errorf("expected exiting validator to be ineligible to exit, got %+v", eligibility)
+	}
+}
+
+func TestExitValidatorWithWrongKey(t *testing.T) {
+	server := newTestServer(t)
+	bc := server.Client()
+	_, validator := addTestValidator(t, server, beacon.ValidatorState_ActiveOngoing, 100)
+	wrongKey, _, err := mock.GenerateValidatorKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ExitValidator(bc, NewLocalSigner(wrongKey), validator.Pubkey); err == nil {
+		t.Fatal("expected exit signed with the wrong key to be rejected")
+	}
+	if exits := server.GetVoluntaryExits(); len(exits) != 0 {
+		t.Errorf("expected no voluntary exits, got %d", len(exits))
+	}
+}
+
+func TestExitEligibility(t *testing.T) {
+	server := newTestServer(t)
+	bc := server.Client()
+
+	_, pending := addTestValidator(t, server, beacon.ValidatorState_PendingQueued, 0)
+	_, young := addTestValidator(t, server, beacon.ValidatorState_ActiveOngoing, testHeadEpoch-10)
+	_, exited := addTestValidator(t, server, beacon.ValidatorState_ExitedUnslashed, 100)
+	_, unknownPubkey, err := mock.GenerateValidatorKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eligibility, err := GetExitEligibility(bc, unknownPubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !eligibility.ValidatorNotRegistered {
+		t.Errorf("expected unknown validator to be reported as not registered, got %+v", eligibility)
+	}
+
+	eligibility, err = GetExitEligibility(bc, pending.Pubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !eligibility.ValidatorNotActive {
+		t.Errorf("expected pending validator to be reported as not active, got %+v", eligibility)
+	}
+
+	eligibility, err = GetExitEligibility(bc, young.Pubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !eligibility.ValidatorTooYoung {
+		t.Errorf("expected recently activated validator to be reported as too young, got %+v", eligibility)
+	}
+
+	eligibility, err = GetExitEligibility(bc, exited.Pubkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !eligibility.ValidatorNotActive {
+		t.Errorf("expected exited validator to be reported as not active, got %+v", eligibility)
+	}
+}
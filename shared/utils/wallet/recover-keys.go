@@ -2,9 +2,13 @@ package wallet
 
 import (
 	"fmt"
+	"runtime"
+	"sort"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stader-labs/stader-node/shared/services/wallet"
+	"github.com/stader-labs/stader-node/shared/types/api"
 	"github.com/stader-labs/stader-node/shared/utils/stdr"
 	"github.com/stader-labs/stader-node/stader-lib/node"
 	"github.com/stader-labs/stader-node/stader-lib/stader"
@@ -12,62 +16,196 @@ import (
 )
 
 const (
-	pageSize  uint = 20
-	pageLimit uint = 2000
+	DefaultRecoveryEndIndex uint = 2000
 )
 
-func RecoverStaderKeys(pnr *stader.PermissionlessNodeRegistryContractManager, address common.Address, w *wallet.Wallet, testOnly bool) ([]types.ValidatorPubkey, error) {
-	recoveredKeys := []types.ValidatorPubkey{}
+// Validator key derivation paths used by other staking tools, searched after the main one
+var AlternativeValidatorKeyPaths = []string{
+	"m/12381/60/%d/0",
+	"m/12381/60/0/%d",
+}
+
+// Where and how to search for an operator's validator keys
+type RecoveryOptions struct {
+	StartIndex     uint
+	EndIndex       uint
+	DerivationPath string
+	Workers        int
+	TestOnly       bool
+}
+
+// Search the wallet for the validator keys registered with an operator and save the ones that are found.
+// Keys are derived concurrently across the index range of the main derivation path and then the alternative paths.
+// Keys that aren't found are listed in the report rather than failing the recovery.
+func RecoverStaderKeys(pnr *stader.PermissionlessNodeRegistryContractManager, address common.Address, w *wallet.Wallet, options RecoveryOptions) (*api.ValidatorKeyRecoveryReport, error) {
+
+	// Fill in the defaults
+	if options.EndIndex == 0 {
+		options.EndIndex = options.StartIndex + DefaultRecoveryEndIndex
+	}
+	if options.EndIndex < options.StartIndex {
+		return nil, fmt.Errorf("the end index %d is before the start index %d", options.EndIndex, options.StartIndex)
+	}
+	if options.DerivationPath == "" {
+		options.DerivationPath = wallet.ValidatorKeyPath
+	}
+	if options.Workers <= 0 {
+		options.Workers = runtime.NumCPU()
+	}
+	paths := []string{options.DerivationPath}
+	for _, path := range AlternativeValidatorKeyPaths {
+		if path != options.DerivationPath {
+			paths = append(paths, path)
+		}
+	}
+	report := &api.ValidatorKeyRecoveryReport{
+		StartIndex:      options.StartIndex,
+		EndIndex:        options.EndIndex,
+		DerivationPaths: paths,
+		RecoveredKeys:   []api.RecoveredValidatorKey{},
+		MissingKeys:     []api.MissingValidatorKey{},
+	}
+
 	operatorId, err := node.GetOperatorId(pnr, address, nil)
 	if err != nil {
 		return nil, err
 	}
 	// Get node's validating pubkeys
-	allOperatorValidators, _, err := stdr.GetAllValidatorsRegisteredWithOperator(pnr, operatorId, address, nil)
+	allOperatorValidators, _, err := stdr.GetAllValidatorsRegisteredWithOperator(pnr, nil, operatorId, address, nil)
 	if err != nil {
 		return nil, err
 	}
+	// Keys that were removed from the wallet stay removed
+	missing := map[types.ValidatorPubkey]bool{}
+	for pubkey := range allOperatorValidators {
+		if !w.IsRemovedValidatorKey(pubkey) {
+			missing[pubkey] = true
+		}
+	}
 
-	// Recover conventionally generated keys
-	pageStart := uint(0)
-	for {
-		if pageStart >= pageLimit {
-			return nil, fmt.Errorf("attempt limit exceeded (%d keys)", pageLimit)
+	// Recover imported keys, which can't be derived from the wallet
+	importedKeys, err := w.GetImportedValidatorKeys()
+	if err != nil {
+		return nil, err
+	}
+	for _, validatorKey := range importedKeys {
+		if !missing[validatorKey.PublicKey] {
+			continue
 		}
-		pageEnd := pageStart + pageSize
-		if pageEnd > pageLimit {
-			pageEnd = pageLimit
+		delete(missing, validatorKey.PublicKey)
+		if !options.TestOnly {
+			err := w.StoreValidatorKey(validatorKey.PrivateKey, validatorKey.DerivationPath)
+			if err != nil {
+				return nil, fmt.Errorf("error recovering imported validator keys: %w", err)
+			}
 		}
+		report.RecoveredKeys = append(report.RecoveredKeys, api.RecoveredValidatorKey{
+			Pubkey:         validatorKey.PublicKey,
+			DerivationPath: validatorKey.DerivationPath,
+			Imported:       true,
+			ContractStatus: stdr.ValidatorState[allOperatorValidators[validatorKey.PublicKey].Status],
+		})
+	}
 
-		// Get the keys for this bucket
-		keys, err := w.GetValidatorKeys(pageStart, pageEnd-pageStart)
+	// Derive the rest, one path at a time so keys at the main path win
+	for _, path := range paths {
+		if len(missing) == 0 {
+			break
+		}
+		found, err := searchValidatorKeys(w, path, options, missing)
 		if err != nil {
 			return nil, err
 		}
-		for _, validatorKey := range keys {
-			_, exists := allOperatorValidators[validatorKey.PublicKey]
-			if exists {
-				// Found one!
-				delete(allOperatorValidators, validatorKey.PublicKey)
-				if !testOnly {
-					err := w.SaveValidatorKey(validatorKey)
-					if err != nil {
-						return nil, fmt.Errorf("error recovering validator keys: %w", err)
-					}
-					recoveredKeys = append(recoveredKeys, validatorKey.PublicKey)
+		for _, validatorKey := range found {
+			delete(missing, validatorKey.PublicKey)
+			if !options.TestOnly {
+				if err := w.SaveValidatorKey(validatorKey); err != nil {
+					return nil, fmt.Errorf("error recovering validator keys: %w", err)
 				}
 			}
+			report.RecoveredKeys = append(report.RecoveredKeys, api.RecoveredValidatorKey{
+				Pubkey:         validatorKey.PublicKey,
+				DerivationPath: validatorKey.DerivationPath,
+				ContractStatus: stdr.ValidatorState[allOperatorValidators[validatorKey.PublicKey].Status],
+			})
 		}
+	}
 
-		if len(allOperatorValidators) == 0 {
-			// All keys recovered!
-			break
+	// Report the keys that weren't found
+	for pubkey := range missing {
+		report.MissingKeys = append(report.MissingKeys, api.MissingValidatorKey{
+			Pubkey:         pubkey,
+			ContractStatus: stdr.ValidatorState[allOperatorValidators[pubkey].Status],
+		})
+	}
+	sort.Slice(report.MissingKeys, func(i, j int) bool {
+		return report.MissingKeys[i].Pubkey.Hex() < report.MissingKeys[j].Pubkey.Hex()
+	})
+
+	return report, nil
+
+}
+
+// Derive the keys in the index range of a path concurrently, returning the ones in the wanted set ordered by index.
+// The search stops early once every wanted key has been found.
+func searchValidatorKeys(w *wallet.Wallet, path string, options RecoveryOptions, wanted map[types.ValidatorPubkey]bool) ([]wallet.ValidatorKey, error) {
+
+	indices := make(chan uint)
+	done := make(chan struct{})
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	var searchErr error
+	found := []wallet.ValidatorKey{}
+	stop := func() {
+		select {
+		case <-done:
+		default:
+			close(done)
 		}
+	}
+
+	// Start the workers
+	for i := 0; i < options.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indices {
+				key, err := w.DeriveValidatorKey(path, index)
+				lock.Lock()
+				if err != nil {
+					if searchErr == nil {
+						searchErr = err
+					}
+					stop()
+				} else if wanted[key.PublicKey] {
+					found = append(found, key)
+					if len(found) == len(wanted) {
+						stop()
+					}
+				}
+				lock.Unlock()
+			}
+		}()
+	}
 
-		// Run another iteration with the next bucket
-		pageStart = pageEnd
+	// Hand out the indices until the range is exhausted or the search is stopped
+feed:
+	for index := options.StartIndex; index < options.EndIndex; index++ {
+		select {
+		case indices <- index:
+		case <-done:
+			break feed
+		}
+	}
+	close(indices)
+	wg.Wait()
+	if searchErr != nil {
+		return nil, searchErr
 	}
 
-	return recoveredKeys, nil
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].WalletIndex < found[j].WalletIndex
+	})
+	return found, nil
 
 }
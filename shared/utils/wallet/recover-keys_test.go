@@ -0,0 +1,73 @@
+package wallet
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/stader-labs/stader-node/shared/services/passwords"
+	"github.com/stader-labs/stader-node/shared/services/wallet"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+func TestSearchValidatorKeys(t *testing.T) {
+	dir := t.TempDir()
+	pm := passwords.NewPasswordManager(filepath.Join(dir, "password"))
+	if err := pm.SetPassword("node-password"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := wallet.NewWallet(filepath.Join(dir, "wallet"), 5, big.NewInt(30e9), big.NewInt(1e9), 0, pm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Initialize(wallet.DefaultNodeKeyPath, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// Keys with a gap in the index space, and one at an alternative path
+	wanted := map[types.ValidatorPubkey]bool{}
+	for _, index := range []uint{37, 3} {
+		key, err := w.DeriveValidatorKey(wallet.ValidatorKeyPath, index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wanted[key.PublicKey] = true
+	}
+	altKey, err := w.DeriveValidatorKey(AlternativeValidatorKeyPaths[0], 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted[altKey.PublicKey] = true
+
+	// The main path finds the two keys in index order
+	options := RecoveryOptions{StartIndex: 0, EndIndex: 50, Workers: 4}
+	found, err := searchValidatorKeys(w, wallet.ValidatorKeyPath, options, wanted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 2 || found[0].WalletIndex != 3 || found[1].WalletIndex != 37 {
+		t.Fatalf("unexpected keys found: %+v", found)
+	}
+	if found[0].DerivationPath != "m/12381/3600/3/0/0" {
+		t.Errorf("unexpected derivation path %s", found[0].DerivationPath)
+	}
+
+	// The alternative path finds the other one
+	found, err = searchValidatorKeys(w, AlternativeValidatorKeyPaths[0], options, wanted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].PublicKey != altKey.PublicKey {
+		t.Fatalf("unexpected keys found: %+v", found)
+	}
+
+	// A range that doesn't cover a key doesn't find it
+	options = RecoveryOptions{StartIndex: 10, EndIndex: 20, Workers: 4}
+	found, err = searchValidatorKeys(w, wallet.ValidatorKeyPath, options, wanted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 0 {
+		t.Fatalf("unexpected keys found: %+v", found)
+	}
+}
@@ -368,6 +368,146 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					return nodeApproveSd(c)
 				},
 			},
+			{
+				Name:      "report",
+				Usage:     "Export snapshots of the node's collateral, validators, penalties and unclaimed rewards over a period of time",
+				UsageText: "stader-cli node report [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "from, f",
+						Usage: "The start of the report, as a date (YYYY-MM-DD), an RFC3339 timestamp or a block number",
+					},
+					cli.StringFlag{
+						Name:  "to, t",
+						Usage: "The end of the report, as a date (YYYY-MM-DD), an RFC3339 timestamp, a block number or 'now'",
+						Value: "now",
+					},
+					cli.StringFlag{
+						Name:  "interval, i",
+						Usage: "The time between snapshots ('hour', 'day', 'week' or 'month')",
+						Value: "day",
+					},
+					cli.StringFlag{
+						Name:  "format",
+						Usage: "The output format ('csv' or 'json')",
+						Value: "csv",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to write the report to (defaults to the terminal)",
+					},
+					cli.BoolFlag{
+						Name:  "validators",
+						Usage: "Include a row for each validator in CSV reports",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if c.String("from") == "" {
+						return fmt.Errorf("a start date or block must be provided with --from")
+					}
+					if c.String("format") != "csv" && c.String("format") != "json" {
+						return fmt.Errorf("invalid format '%s'; must be 'csv' or 'json'", c.String("format"))
+					}
+
+					// Run
+					return getReport(c)
+				},
+			},
+			{
+				Name:      "sign-message",
+				Aliases:   []string{"sm"},
+				Usage:     "Sign an arbitrary message with the node's private key (EIP-191 personal_sign)",
+				UsageText: "stader-cli node sign-message [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "message, m",
+						Usage: "The message to sign",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return signMessage(c)
+				},
+			},
+			{
+				Name:      "sign-typed-data",
+				Aliases:   []string{"st"},
+				Usage:     "Sign an EIP-712 typed data document with the node's private key",
+				UsageText: "stader-cli node sign-typed-data --file path",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "The JSON file with the EIP-712 typed data to sign",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if c.String("file") == "" {
+						return fmt.Errorf("a typed data file must be provided with --file")
+					}
+
+					// Run
+					return signTypedData(c)
+				},
+			},
+			{
+				Name:      "verify-signature",
+				Aliases:   []string{"vs"},
+				Usage:     "Recover the signer of a personal message or EIP-712 typed data document and check it against an address",
+				UsageText: "stader-cli node verify-signature (--message message | --typed-data-file path) --signature signature --address address",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "message, m",
+						Usage: "The message that was signed with personal_sign",
+					},
+					cli.StringFlag{
+						Name:  "typed-data-file, f",
+						Usage: "The JSON file with the EIP-712 typed data that was signed",
+					},
+					cli.StringFlag{
+						Name:  "signature, s",
+						Usage: "The signature, as a hex string",
+					},
+					cli.StringFlag{
+						Name:  "address, a",
+						Usage: "The address that should have signed the message",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if (c.String("message") == "") == (c.String("typed-data-file") == "") {
+						return fmt.Errorf("exactly one of --message or --typed-data-file must be provided")
+					}
+					if c.String("signature") == "" {
+						return fmt.Errorf("a signature must be provided with --signature")
+					}
+					if c.String("address") == "" {
+						return fmt.Errorf("the expected signer must be provided with --address")
+					}
+
+					// Run
+					return verifySignature(c)
+				},
+			},
 		},
 	})
 }
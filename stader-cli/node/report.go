@@ -0,0 +1,163 @@
+package node
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+var reportHeader = []string{
+	"time",
+	"slot",
+	"block",
+	"sd_collateral",
+	"sd_collateral_in_eth",
+	"eth_collateral",
+	"active_validators",
+	"stader_queued_validators",
+	"beacon_chain_queued_validators",
+	"exiting_validators",
+	"withdrawn_validators",
+	"slashed_validators",
+	"funds_settled_validators",
+	"cumulative_penalty",
+	"unclaimed_cl_rewards",
+	"unclaimed_non_socializing_pool_el_rewards",
+	"unclaimed_socializing_pool_el_rewards",
+	"unclaimed_socializing_pool_sd_rewards",
+}
+
+var validatorReportHeader = []string{
+	"time",
+	"slot",
+	"block",
+	"pubkey",
+	"status",
+	"beacon_balance",
+	"withdraw_vault_address",
+	"withdraw_vault_balance",
+	"penalty",
+}
+
+func getReport(c *cli.Context) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Get the report; older snapshots can take a while with a large number of validators
+	fmt.Fprintln(os.Stderr, "Building the report, this may take a while...")
+	response, err := staderClient.NodeReport(c.String("from"), c.String("to"), c.String("interval"))
+	if err != nil {
+		return err
+	}
+
+	// Open the output
+	var out io.Writer = os.Stdout
+	if path := c.String("output"); path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("error creating report file %s: %w", path, err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	// Write the report
+	if c.String("format") == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "    ")
+		err = encoder.Encode(response.Snapshots)
+	} else if c.Bool("validators") {
+		err = writeValidatorReportCsv(out, response.Snapshots)
+	} else {
+		err = writeReportCsv(out, response.Snapshots)
+	}
+	if err != nil {
+		return fmt.Errorf("error writing report: %w", err)
+	}
+
+	if path := c.String("output"); path != "" {
+		fmt.Fprintf(os.Stderr, "Wrote %d snapshots to %s.\n", len(response.Snapshots), path)
+	}
+	return nil
+
+}
+
+// Write one row per snapshot
+func writeReportCsv(out io.Writer, snapshots []api.OperatorSnapshot) error {
+	writer := csv.NewWriter(out)
+	if err := writer.Write(reportHeader); err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		row := []string{
+			snapshot.Time.UTC().Format(time.RFC3339),
+			strconv.FormatUint(snapshot.Slot, 10),
+			strconv.FormatUint(snapshot.BlockNumber, 10),
+			formatReportAmount(snapshot.SdCollateral),
+			formatReportAmount(snapshot.SdCollateralInEth),
+			formatReportAmount(snapshot.EthCollateral),
+			strconv.FormatInt(snapshot.ActiveValidators, 10),
+			strconv.FormatInt(snapshot.StaderQueuedValidators, 10),
+			strconv.FormatInt(snapshot.BeaconChainQueuedValidators, 10),
+			strconv.FormatInt(snapshot.ExitingValidators, 10),
+			strconv.FormatInt(snapshot.WithdrawnValidators, 10),
+			strconv.FormatInt(snapshot.SlashedValidators, 10),
+			strconv.FormatInt(snapshot.FundsSettledValidators, 10),
+			formatReportAmount(snapshot.CumulativePenalty),
+			formatReportAmount(snapshot.UnclaimedClRewards),
+			formatReportAmount(snapshot.UnclaimedNonSocializingPoolElRewards),
+			formatReportAmount(snapshot.UnclaimedSocializingPoolElRewards),
+			formatReportAmount(snapshot.UnclaimedSocializingPoolSdRewards),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Write one row per validator per snapshot
+func writeValidatorReportCsv(out io.Writer, snapshots []api.OperatorSnapshot) error {
+	writer := csv.NewWriter(out)
+	if err := writer.Write(validatorReportHeader); err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		for _, validator := range snapshot.Validators {
+			row := []string{
+				snapshot.Time.UTC().Format(time.RFC3339),
+				strconv.FormatUint(snapshot.Slot, 10),
+				strconv.FormatUint(snapshot.BlockNumber, 10),
+				validator.Pubkey.Hex(),
+				validator.Status,
+				formatReportAmount(validator.BeaconBalance),
+				validator.WithdrawVaultAddress.Hex(),
+				formatReportAmount(validator.WithdrawVaultBalance),
+				formatReportAmount(validator.Penalty),
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatReportAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
@@ -0,0 +1,71 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/utils/signature"
+)
+
+type TypedDataSignature struct {
+	Address   common.Address  `json:"address"`
+	TypedData json.RawMessage `json:"typedData"`
+	Signature string          `json:"sig"`
+	Version   string          `json:"version"`
+}
+
+func signTypedData(c *cli.Context) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Get & check wallet status
+	status, err := staderClient.WalletStatus()
+	if err != nil {
+		return err
+	}
+
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Read the typed data
+	typedDataJson, err := ioutil.ReadFile(c.String("file"))
+	if err != nil {
+		return fmt.Errorf("error reading the typed data file: %w", err)
+	}
+	if _, err := signature.ParseTypedData(typedDataJson); err != nil {
+		return err
+	}
+
+	response, err := staderClient.SignTypedData(typedDataJson)
+	if err != nil {
+		return err
+	}
+
+	// Print the signature
+	formattedSignature := TypedDataSignature{
+		Address:   status.AccountAddress,
+		TypedData: json.RawMessage(typedDataJson),
+		Signature: response.SignedData,
+		Version:   fmt.Sprint(signatureVersion),
+	}
+	bytes, err := json.MarshalIndent(formattedSignature, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Signed Typed Data:\n\n%s\n", string(bytes))
+
+	return nil
+
+}
@@ -0,0 +1,52 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+	"github.com/stader-labs/stader-node/shared/utils/signature"
+)
+
+func verifySignature(c *cli.Context) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Get the signed message
+	signatureType := signature.TypePersonal
+	message := c.String("message")
+	if c.String("typed-data-file") != "" {
+		typedDataJson, err := ioutil.ReadFile(c.String("typed-data-file"))
+		if err != nil {
+			return fmt.Errorf("error reading the typed data file: %w", err)
+		}
+		signatureType = signature.TypeTypedData
+		message = string(typedDataJson)
+	}
+	expectedAddress, err := cliutils.ValidateAddress("address", c.String("address"))
+	if err != nil {
+		return err
+	}
+
+	response, err := staderClient.VerifySignature(signatureType, message, c.String("signature"), expectedAddress)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	if !response.Matches {
+		fmt.Printf("%sThe signature is NOT valid for %s; it was signed by %s.%s\n", colorRed, response.ExpectedAddress.Hex(), response.Signer.Hex(), colorReset)
+		return errors.New("signature verification failed")
+	}
+	fmt.Printf("%sThe signature is valid and was signed by %s.%s\n", colorGreen, response.Signer.Hex(), colorReset)
+	return nil
+
+}
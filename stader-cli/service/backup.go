@@ -0,0 +1,97 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/passwords"
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+)
+
+// Write an encrypted backup of the node
+func backupService(c *cli.Context) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Prompt for a passphrase
+	fmt.Println("The backup contains your wallet and validator keys. It is encrypted with a passphrase, which you will need to restore it.")
+	fmt.Println()
+	var passphrase string
+	for {
+		passphrase = cliutils.PromptPassword(
+			"Please enter a passphrase to encrypt the backup with:",
+			fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+			fmt.Sprintf("Your passphrase must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+		)
+		confirmation := cliutils.PromptPassword("Please confirm your passphrase:", "^.*$", "")
+		if passphrase == confirmation {
+			break
+		}
+		fmt.Println("Passphrase confirmation does not match.")
+		fmt.Println()
+	}
+
+	// Back up the node
+	response, err := staderClient.ServiceBackup(passphrase, c.String("output"))
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	for _, warning := range response.Warnings {
+		fmt.Printf("%sWARNING: %s%s\n", colorYellow, warning, colorReset)
+	}
+	fmt.Printf("Backed up %d files for node %s to %s.\n", response.FileCount, response.NodeAddress.Hex(), response.File)
+	fmt.Printf("%sStore the backup and its passphrase somewhere safe. Anyone with both can take control of your node and validators.%s\n", colorYellow, colorReset)
+	fmt.Println("The backup can also be decrypted with `age -d`.")
+	return nil
+
+}
+
+// Restore the node from an encrypted backup
+func restoreService(c *cli.Context, backupFile string) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Check for an existing wallet
+	status, err := staderClient.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if status.WalletInitialized {
+		fmt.Printf("%sThis node already has a wallet for %s. Restoring the backup will replace it, along with its validator keys and settings.%s\n", colorYellow, status.AccountAddress.Hex(), colorReset)
+		if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to replace the node's wallet?")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	// Restore the node
+	passphrase := cliutils.PromptPassword("Please enter the passphrase of the backup:", "^.*$", "")
+	response, err := staderClient.ServiceRestore(backupFile, passphrase, status.WalletInitialized)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Restored %d files for node %s from the backup created at %s.\n", response.FileCount, response.NodeAddress.Hex(), response.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+	if response.SlashingProtectionRestored {
+		fmt.Println("The slashing protection history will be imported when the Validator client next starts.")
+	} else {
+		fmt.Printf("%sThe backup has no slashing protection history. Wait at least 15 minutes after the validators last attested before starting the Validator client.%s\n", colorYellow, colorReset)
+	}
+	fmt.Println()
+	fmt.Printf("Please run %sstader-cli service start%s to restart the node with the restored wallet, keys and settings.\n", colorGreen, colorReset)
+	return nil
+
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+)
+
+// View the health of every client in the Execution and Consensus pools
+func getClientStatus(c *cli.Context) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Print what network we're on
+	err = cliutils.PrintNetwork(staderClient)
+	if err != nil {
+		return err
+	}
+
+	// Get the pool status
+	status, err := staderClient.GetClientStatus()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s=== Execution Clients ===%s\n", colorGreen, colorReset)
+	printClientPool(status.EcManagerStatus, "blocks")
+	fmt.Println()
+	fmt.Printf("%s=== Consensus Clients ===%s\n", colorGreen, colorReset)
+	printClientPool(status.BcManagerStatus, "slots")
+
+	return nil
+
+}
+
+// Print the health report of each client in a pool
+func printClientPool(status api.ClientManagerStatus, headUnit string) {
+
+	if len(status.Endpoints) == 0 {
+		fmt.Println("No clients are configured.")
+		return
+	}
+
+	for _, endpoint := range status.Endpoints {
+		name := "Primary"
+		if endpoint.Priority > 0 {
+			name = fmt.Sprintf("Fallback #%d", endpoint.Priority)
+		}
+		selected := ""
+		if endpoint.IsSelected {
+			selected = fmt.Sprintf(" %s(in use)%s", colorLightBlue, colorReset)
+		}
+		fmt.Printf("%s%s%s [%s]%s\n", colorBold, name, colorReset, redactUrl(endpoint.Url), selected)
+
+		// Print the sync state
+		if endpoint.Status.Error != "" {
+			fmt.Printf("\tStatus:     %sunavailable (%s)%s\n", colorRed, endpoint.Status.Error, colorReset)
+		} else if endpoint.Status.IsSynced {
+			fmt.Printf("\tStatus:     %ssynced%s\n", colorGreen, colorReset)
+		} else if endpoint.Status.IsWorking {
+			fmt.Printf("\tStatus:     %ssyncing (%.2f%%)%s\n", colorYellow, endpoint.Status.SyncProgress*100, colorReset)
+		} else {
+			fmt.Printf("\tStatus:     %snot checked%s\n", colorYellow, colorReset)
+		}
+
+		// Print the health metrics
+		if endpoint.Status.IsWorking {
+			fmt.Printf("\tHead:       %d (%d %s behind the pool)\n", endpoint.Head, endpoint.HeadLag, headUnit)
+			fmt.Printf("\tLatency:    %d ms\n", endpoint.LatencyMs)
+		}
+		fmt.Printf("\tError rate: %.1f%%\n", endpoint.ErrorRate*100)
+		if endpoint.IsHealthy {
+			fmt.Printf("\tScore:      %.1f\n", endpoint.Score)
+		} else {
+			fmt.Printf("\tScore:      %snot eligible for requests%s\n", colorRed, colorReset)
+		}
+	}
+
+}
+
+// Strip everything but the scheme and host from a URL, since provider URLs often contain API keys
+func redactUrl(rawUrl string) string {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil || parsedUrl.Host == "" {
+		return "<invalid url>"
+	}
+	return fmt.Sprintf("%s://%s", parsedUrl.Scheme, parsedUrl.Host)
+}
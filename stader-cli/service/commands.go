@@ -407,6 +407,24 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-client-status",
+				Aliases:   []string{"gcs"},
+				Usage:     "View the health of every Execution and Consensus client in the pool, including fallbacks",
+				UsageText: "stader-cli service get-client-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return getClientStatus(c)
+
+				},
+			},
+
 			{
 				Name:      "check-cpu-features",
 				Aliases:   []string{"ccf"},
@@ -567,6 +585,54 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "backup",
+				Usage:     "Writes an encrypted backup of the node's wallet, password, validator keys, slashing protection history, settings and reward data to a single file",
+				UsageText: "stader-cli service backup [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file or folder to write the backup to",
+						Value: ".",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return backupService(c)
+
+				},
+			},
+
+			{
+				Name:      "restore",
+				Usage:     "Verifies an encrypted backup created by `stader-cli service backup` and restores the node from it",
+				UsageText: "stader-cli service restore [options] backup-file",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm replacing an existing wallet",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					backupFile := c.Args().Get(0)
+
+					// Run command
+					return restoreService(c, backupFile)
+
+				},
+			},
 		},
 	})
 }
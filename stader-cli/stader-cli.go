@@ -20,14 +20,17 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/stader-labs/stader-node/shared"
+	"github.com/stader-labs/stader-node/shared/types/api"
 	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
 	"github.com/stader-labs/stader-node/stader-cli/node"
 	"github.com/stader-labs/stader-node/stader-cli/service"
+	"github.com/stader-labs/stader-node/stader-cli/tx"
 	"github.com/stader-labs/stader-node/stader-cli/validator"
 	"github.com/stader-labs/stader-node/stader-cli/wallet"
 	"github.com/urfave/cli"
@@ -764,6 +767,14 @@ Public License instead of this License. But first, please read
 			Name:  "debug",
 			Usage: "Enable debug printing of API commands",
 		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Simulate the transaction a command would send against the latest block and print what it would do, without signing or sending it",
+		},
+		cli.StringFlag{
+			Name:  "export-unsigned-tx",
+			Usage: "Save the transaction a command would send to `path` as unsigned JSON, so it can be signed offline with 'wallet sign-tx' and sent with 'tx broadcast'",
+		},
 		cli.BoolFlag{
 			Name: "secure-session, s",
 			Usage: "Some commands may print sensitive information to your terminal. " +
@@ -790,6 +801,9 @@ Public License instead of this License. But first, please read
 	}
 	// Register commands
 
+	// Set by the --export-unsigned-tx flag
+	var exportPath string
+
 	// Get the config path from the arguments (or use the default)
 	configPath := "~/.stader"
 	for index, arg := range os.Args {
@@ -814,6 +828,7 @@ Public License instead of this License. But first, please read
 	service.RegisterCommands(app, "service", []string{"s"})
 	wallet.RegisterCommands(app, "wallet", []string{"w"})
 	validator.RegisterCommands(app, "validator", []string{"v"})
+	tx.RegisterCommands(app, "tx", []string{"t"})
 	app.Commands = append(app.Commands, cli.Command{
 		Name:    "license",
 		Aliases: []string{"l"},
@@ -842,13 +857,29 @@ Public License instead of this License. But first, please read
 			os.Exit(1)
 		}
 
+		// Check the transaction flags
+		exportPath = c.GlobalString("export-unsigned-tx")
+		if exportPath != "" && c.GlobalBool("dry-run") {
+			return fmt.Errorf("--dry-run and --export-unsigned-tx can't be used together")
+		}
+
 		return nil
 	}
 
 	// Run application
 	fmt.Println("")
 	if err := app.Run(os.Args); err != nil {
-		cliutils.PrettyPrintError(err)
+		var dryRunErr *api.DryRunError
+		var unsignedTxErr *api.UnsignedTxError
+		if errors.As(err, &dryRunErr) {
+			cliutils.PrintDryRunSimulation(dryRunErr.Simulation)
+		} else if errors.As(err, &unsignedTxErr) && exportPath != "" {
+			if err := cliutils.SaveUnsignedTx(exportPath, unsignedTxErr.UnsignedTx); err != nil {
+				cliutils.PrettyPrintError(err)
+			}
+		} else {
+			cliutils.PrettyPrintError(err)
+		}
 	}
 	fmt.Println("")
 
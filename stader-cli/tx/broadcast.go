@@ -0,0 +1,58 @@
+package tx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+)
+
+func broadcastTransaction(c *cli.Context, path string) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Load the signed transaction
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading signed transaction: %w", err)
+	}
+	var signedTx txmanager.SignedTx
+	if err := json.Unmarshal(bytes, &signedTx); err != nil {
+		return fmt.Errorf("error parsing signed transaction: %w", err)
+	}
+	if len(signedTx.RawTx) == 0 {
+		return fmt.Errorf("%s doesn't contain a signed transaction", path)
+	}
+
+	// Prompt for confirmation
+	fmt.Println("This signed transaction will be sent:")
+	cliutils.PrintUnsignedTx(signedTx.UnsignedTx)
+	fmt.Println()
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to broadcast this transaction?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Broadcast it
+	response, err := staderClient.TxBroadcast(signedTx.RawTx)
+	if err != nil {
+		return err
+	}
+	cliutils.PrintTransactionHash(staderClient, response.TxHash)
+	if _, err = staderClient.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	fmt.Printf("Transaction %s was mined.\n", response.TxHash.Hex())
+	return nil
+
+}
@@ -0,0 +1,150 @@
+package tx
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+)
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Manage the transactions sent by the node",
+		Subcommands: []cli.Command{
+
+			{
+				Name:      "list",
+				Aliases:   []string{"l"},
+				Usage:     "List the transactions sent by the node",
+				UsageText: "stader-cli tx list [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "pending, p",
+						Usage: "Only list pending transactions",
+					},
+					cli.IntFlag{
+						Name:  "limit, n",
+						Usage: "The number of most recent transactions to list",
+						Value: 20,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return listTransactions(c)
+
+				},
+			},
+
+			{
+				Name:      "status",
+				Aliases:   []string{"s"},
+				Usage:     "Get the status of a transaction and any replacements for it",
+				UsageText: "stader-cli tx status tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return getTransactionStatus(c, hash)
+
+				},
+			},
+
+			{
+				Name:      "speed-up",
+				Aliases:   []string{"u"},
+				Usage:     "Resend a pending transaction with higher fees. Use the global --maxFee and --maxPrioFee flags to set the fees yourself.",
+				UsageText: "stader-cli tx speed-up [options] tx-hash",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the speed-up",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return replaceTransaction(c, hash, txmanager.TxKind_SpeedUp)
+
+				},
+			},
+
+			{
+				Name:      "cancel",
+				Aliases:   []string{"c"},
+				Usage:     "Cancel a pending transaction by replacing it with an empty transfer to the node wallet. Use the global --maxFee and --maxPrioFee flags to set the fees yourself.",
+				UsageText: "stader-cli tx cancel [options] tx-hash",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the cancellation",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return replaceTransaction(c, hash, txmanager.TxKind_Cancel)
+
+				},
+			},
+
+			{
+				Name:      "broadcast",
+				Aliases:   []string{"b"},
+				Usage:     "Send a transaction signed offline with 'wallet sign-tx'",
+				UsageText: "stader-cli tx broadcast [options] signed-tx-file",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the broadcast",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return broadcastTransaction(c, c.Args().Get(0))
+
+				},
+			},
+		},
+	})
+}
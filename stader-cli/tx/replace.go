@@ -0,0 +1,88 @@
+package tx
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+)
+
+// How often to check on a replacement while waiting for it to be mined
+const replacementPollInterval = 12 * time.Second
+
+func replaceTransaction(c *cli.Context, hash common.Hash, kind txmanager.TxKind) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Check the transaction can be replaced
+	action := "speed up"
+	canReplace := staderClient.CanTxSpeedUp
+	replace := staderClient.TxSpeedUp
+	if kind == txmanager.TxKind_Cancel {
+		action = "cancel"
+		canReplace = staderClient.CanTxCancel
+		replace = staderClient.TxCancel
+	}
+	canResponse, err := canReplace(hash)
+	if err != nil {
+		return err
+	}
+	if !canResponse.CanReplace {
+		fmt.Printf("Cannot %s transaction %s:\n", action, hash.Hex())
+		if canResponse.NotPending {
+			fmt.Printf("It is no longer pending; its status is: %s.\n", describeStatus(canResponse.Transaction))
+		}
+		return nil
+	}
+
+	// Prompt for confirmation
+	fmt.Printf("Transaction %s (%s, nonce %d) will be replaced with:\n", hash.Hex(), canResponse.Transaction.Method, canResponse.Transaction.Nonce)
+	fmt.Printf("  Max fee:          %s (was %s)\n", formatGwei(canResponse.MaxFee), formatGwei(canResponse.Transaction.MaxFee))
+	fmt.Printf("  Max priority fee: %s (was %s)\n", formatGwei(canResponse.MaxPriorityFee), formatGwei(canResponse.Transaction.MaxPriorityFee))
+	fmt.Printf("  Gas limit:        %d\n\n", canResponse.GasLimit)
+	if kind == txmanager.TxKind_Cancel {
+		fmt.Println("The replacement sends nothing to the node wallet, so the original call won't happen if it's mined first.")
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to %s this transaction?", action))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Send the replacement
+	response, err := replace(hash)
+	if err != nil {
+		return err
+	}
+	replacementHash := response.TxHash
+	cliutils.PrintTransactionHash(staderClient, replacementHash)
+
+	// Either the original or one of its replacements will be mined, so wait until one of them is
+	for {
+		statusResponse, err := staderClient.TxStatus(replacementHash)
+		if err != nil {
+			return err
+		}
+		switch statusResponse.Transaction.Status {
+		case txmanager.TxStatus_Pending:
+			time.Sleep(replacementPollInterval)
+			continue
+		case txmanager.TxStatus_Mined:
+			fmt.Printf("Replacement %s was mined in block %d.\n", replacementHash.Hex(), statusResponse.Transaction.BlockNumber)
+		case txmanager.TxStatus_Failed:
+			fmt.Printf("Replacement %s was mined in block %d, but it failed.\n", replacementHash.Hex(), statusResponse.Transaction.BlockNumber)
+		default:
+			fmt.Printf("Replacement %s was not mined; it was %s.\n", replacementHash.Hex(), describeStatus(statusResponse.Transaction))
+		}
+		return nil
+	}
+
+}
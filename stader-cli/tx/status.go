@@ -0,0 +1,117 @@
+package tx
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
+	"github.com/stader-labs/stader-node/stader-lib/utils/eth"
+)
+
+func listTransactions(c *cli.Context) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	response, err := staderClient.TxList()
+	if err != nil {
+		return err
+	}
+
+	// Show the most recent transactions last
+	transactions := []txmanager.JournalEntry{}
+	for _, entry := range response.Transactions {
+		if c.Bool("pending") && entry.Status != txmanager.TxStatus_Pending {
+			continue
+		}
+		transactions = append(transactions, entry)
+	}
+	if limit := c.Int("limit"); limit > 0 && len(transactions) > limit {
+		transactions = transactions[len(transactions)-limit:]
+	}
+	if len(transactions) == 0 {
+		fmt.Println("The node has no transactions to show.")
+		return nil
+	}
+
+	fmt.Printf("%-66s  %-8s  %-6s  %-9s  %-28s  %-12s  %s\n", "Hash", "Status", "Nonce", "Kind", "Method", "Max Fee", "Sent")
+	for _, entry := range transactions {
+		fmt.Printf("%-66s  %-8s  %-6d  %-9s  %-28s  %-12s  %s\n",
+			entry.Hash.Hex(),
+			entry.Status,
+			entry.Nonce,
+			entry.Kind,
+			entry.Method,
+			formatGwei(entry.MaxFee),
+			entry.SubmittedAt.Format(time.RFC822))
+	}
+	return nil
+
+}
+
+func getTransactionStatus(c *cli.Context, hash common.Hash) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	response, err := staderClient.TxStatus(hash)
+	if err != nil {
+		return err
+	}
+
+	entry := response.Transaction
+	fmt.Printf("Transaction:      %s\n", entry.Hash.Hex())
+	fmt.Printf("Status:           %s\n", describeStatus(entry))
+	fmt.Printf("Method:           %s\n", entry.Method)
+	if entry.To != nil {
+		fmt.Printf("To:               %s\n", entry.To.Hex())
+	}
+	fmt.Printf("Nonce:            %d\n", entry.Nonce)
+	fmt.Printf("Max fee:          %s\n", formatGwei(entry.MaxFee))
+	fmt.Printf("Max priority fee: %s\n", formatGwei(entry.MaxPriorityFee))
+	fmt.Printf("Gas limit:        %d\n", entry.GasLimit)
+	fmt.Printf("Sent:             %s\n", entry.SubmittedAt.Format(time.RFC822))
+
+	if len(response.Group) > 1 {
+		fmt.Printf("\nTransactions using nonce %d:\n", entry.Nonce)
+		for _, other := range response.Group {
+			fmt.Printf("  %s  %-9s  %-12s  %s\n", other.Hash.Hex(), other.Kind, formatGwei(other.MaxFee), describeStatus(other))
+		}
+	}
+	return nil
+
+}
+
+// Describe a transaction's status, including the block or replacement where there is one
+func describeStatus(entry txmanager.JournalEntry) string {
+	switch entry.Status {
+	case txmanager.TxStatus_Mined:
+		return fmt.Sprintf("mined in block %d", entry.BlockNumber)
+	case txmanager.TxStatus_Failed:
+		return fmt.Sprintf("failed in block %d", entry.BlockNumber)
+	case txmanager.TxStatus_Replaced:
+		return fmt.Sprintf("replaced by %s", entry.ReplacedBy.Hex())
+	case txmanager.TxStatus_Dropped:
+		return "dropped (its nonce was used by a transaction that isn't in the journal)"
+	default:
+		return string(entry.Status)
+	}
+}
+
+func formatGwei(wei *big.Int) string {
+	if wei == nil {
+		return "---"
+	}
+	return fmt.Sprintf("%.2f gwei", eth.WeiToGwei(wei))
+}
@@ -46,7 +46,11 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					},
 					cli.Uint64Flag{
 						Name:  "num-validators, nv",
-						Usage: "Number of validators you want to create (Required)",
+						Usage: "Number of validators you want to create (Required unless --imported-keys is set)",
+					},
+					cli.StringFlag{
+						Name:  "imported-keys, ik",
+						Usage: "Comma-separated public keys of imported validator keys to create validators with, instead of new keys from the node wallet",
 					},
 				},
 				Action: func(c *cli.Context) error {
@@ -57,7 +61,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
-					if c.Uint64("num-validators") == 0 {
+					if _, err := cliutils.ValidatePubkeys("imported-keys", c.String("imported-keys")); err != nil {
+						return err
+					}
+					if c.Uint64("num-validators") == 0 && c.String("imported-keys") == "" {
 						return fmt.Errorf("num-validator needs to be > 0")
 					}
 
@@ -66,6 +73,81 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "import-keystores",
+				Aliases:   []string{"ik"},
+				Usage:     "Import EIP-2335 validator keystores generated outside of the node wallet, such as by staking-deposit-cli",
+				UsageText: "stader-cli validator import-keystores [options] keystore-path [keystore-path...]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "password-file, p",
+						Usage: "A file holding the password of the keystores; you'll be prompted for it if this isn't set",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the import",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if len(c.Args()) == 0 {
+						return fmt.Errorf("incorrect argument count; usage: %s", c.Command.UsageText)
+					}
+
+					// Run
+					return importKeystores(c, c.Args())
+
+				},
+			},
+			{
+				Name:    "deposit-data",
+				Aliases: []string{"dd"},
+				Usage:   "Export and verify validator deposit data in the staking-deposit-cli format",
+				Subcommands: []cli.Command{
+					{
+						Name:      "export",
+						Aliases:   []string{"e"},
+						Usage:     "Write the 1 ETH pre-deposit and 31 ETH deposit data of your validators to deposit_data-*.json files",
+						UsageText: "stader-cli validator deposit-data export [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "output, o",
+								Usage: "The folder to write the deposit data files to",
+								Value: ".",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return exportDepositData(c)
+
+						},
+					},
+					{
+						Name:      "verify",
+						Aliases:   []string{"v"},
+						Usage:     "Check a deposit_data-*.json file against the network and your operator's withdraw vaults",
+						UsageText: "stader-cli validator deposit-data verify deposit-data-file",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run
+							return verifyDepositData(c, c.Args().Get(0))
+
+						},
+					},
+				},
+			},
 			{
 				Name:      "exit-validator",
 				Aliases:   []string{"e"},
@@ -93,6 +175,54 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					return ExitValidator(c, validatorPubKey)
 				},
 			},
+			{
+				Name:      "remove-keys",
+				Aliases:   []string{"rk"},
+				Usage:     "Remove the keys of validators that have exited and are withdrawable, after exporting their slashing protection history",
+				UsageText: "stader-cli validator remove-keys [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "pubkey, p",
+						Usage: "Comma-separated public keys of the validators whose keys to remove",
+					},
+					cli.BoolFlag{
+						Name:  "all-withdrawn, a",
+						Usage: "Remove the keys of all the node's validators that have exited and are withdrawable",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The folder to save the exported slashing protection history to",
+						Value: ".",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm key removal",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					validatorPubKeys, err := cliutils.ValidatePubkeys("pubkey", c.String("pubkey"))
+					if err != nil {
+						return err
+					}
+					if len(validatorPubKeys) == 0 && !c.Bool("all-withdrawn") {
+						return fmt.Errorf("Please select keys to remove with --pubkey or --all-withdrawn")
+					}
+					if len(validatorPubKeys) > 0 && c.Bool("all-withdrawn") {
+						return fmt.Errorf("--pubkey and --all-withdrawn can't be used together")
+					}
+
+					// Run
+					return removeValidatorKeys(c, validatorPubKeys, c.Bool("all-withdrawn"))
+
+				},
+			},
 			{
 				Name:      "send-cl-rewards",
 				Aliases:   []string{"wcr"},
@@ -0,0 +1,124 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/types/eth2"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+)
+
+func exportDepositData(c *cli.Context) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Get the deposit data
+	response, err := staderClient.ExportDepositData()
+	if err != nil {
+		return err
+	}
+	for _, pubkey := range response.MissingKeys {
+		fmt.Printf("%sThe key of validator %s is not in the node wallet, so its deposit data can't be exported.%s\n", log.ColorYellow, pubkey.Hex(), log.ColorReset)
+	}
+	if len(response.DepositData) == 0 {
+		fmt.Println("There is no deposit data to export.")
+		return nil
+	}
+
+	// Write it in the staking-deposit-cli format
+	outputDir := c.String("output")
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("error creating %s: %w", outputDir, err)
+	}
+	timestamp := time.Now().Unix()
+	preDepositFile := filepath.Join(outputDir, fmt.Sprintf("deposit_data-pre-deposit-%d.json", timestamp))
+	if err := writeDepositData(preDepositFile, response.PreDepositData); err != nil {
+		return err
+	}
+	depositFile := filepath.Join(outputDir, fmt.Sprintf("deposit_data-%d.json", timestamp))
+	if err := writeDepositData(depositFile, response.DepositData); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Exported the %s deposit data of %d validators:\n", response.NetworkName, len(response.DepositData))
+	fmt.Printf("1 ETH pre-deposits: %s\n", preDepositFile)
+	fmt.Printf("31 ETH deposits:    %s\n", depositFile)
+	return nil
+
+}
+
+func verifyDepositData(c *cli.Context, depositDataFile string) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Read the deposit data
+	depositDataBytes, err := ioutil.ReadFile(depositDataFile)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", depositDataFile, err)
+	}
+	var deposits []eth2.LaunchpadDepositData
+	if err := json.Unmarshal(depositDataBytes, &deposits); err != nil {
+		return fmt.Errorf("%s is not a deposit data file: %w", depositDataFile, err)
+	}
+	if len(deposits) == 0 {
+		fmt.Printf("%s has no deposits.\n", depositDataFile)
+		return nil
+	}
+
+	// Verify it
+	response, err := staderClient.VerifyDepositData(deposits)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	invalidCount := 0
+	for _, result := range response.Results {
+		amount := float64(result.Amount) / 1e9
+		if !result.Valid {
+			invalidCount++
+			fmt.Printf("%sINVALID%s %s (%.9g ETH): %s\n", log.ColorRed, log.ColorReset, result.Pubkey, amount, result.Error)
+			continue
+		}
+		registration := "not registered yet"
+		if result.Registered {
+			registration = "registered"
+		}
+		fmt.Printf("%sVALID%s   %s (%.9g ETH): withdraws to vault %s, %s\n", log.ColorGreen, log.ColorReset, result.Pubkey, amount, result.WithdrawVaultAddress.Hex(), registration)
+	}
+	fmt.Println()
+	if invalidCount > 0 {
+		return fmt.Errorf("%d of the %d deposits in %s are invalid", invalidCount, len(response.Results), depositDataFile)
+	}
+	fmt.Printf("All %d deposits in %s are valid.\n", len(response.Results), depositDataFile)
+	return nil
+
+}
+
+// Write deposit data in the staking-deposit-cli format
+func writeDepositData(path string, deposits []eth2.LaunchpadDepositData) error {
+	depositDataBytes, err := json.Marshal(deposits)
+	if err != nil {
+		return fmt.Errorf("error encoding the deposit data: %w", err)
+	}
+	if err := ioutil.WriteFile(path, depositDataBytes, 0644); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -29,6 +29,13 @@ func nodeDeposit(c *cli.Context) error {
 	}
 
 	numValidators := c.Uint64("num-validators")
+	importedPubkeys, err := cliutils.ValidatePubkeys("imported-keys", c.String("imported-keys"))
+	if err != nil {
+		return err
+	}
+	if len(importedPubkeys) > 0 {
+		numValidators = uint64(len(importedPubkeys))
+	}
 
 	baseAmountInEth := 4
 	baseAmount := eth.EthToWei(4.0)
@@ -52,7 +59,7 @@ func nodeDeposit(c *cli.Context) error {
 		}
 	}
 
-	canNodeDepositResponse, err := staderClient.CanNodeDeposit(baseAmount, big.NewInt(int64(numValidators)), true)
+	canNodeDepositResponse, err := staderClient.CanNodeDeposit(baseAmount, big.NewInt(int64(numValidators)), true, importedPubkeys)
 	if err != nil {
 		return err
 	}
@@ -95,7 +102,7 @@ func nodeDeposit(c *cli.Context) error {
 	}
 
 	// Make deposit
-	response, err := staderClient.NodeDeposit(baseAmount, big.NewInt(int64(numValidators)), true)
+	response, err := staderClient.NodeDeposit(baseAmount, big.NewInt(int64(numValidators)), true, importedPubkeys)
 	if err != nil {
 		return err
 	}
@@ -57,6 +57,10 @@ func ExitValidator(c *cli.Context, validatorPubKey types.ValidatorPubkey) error
 	}
 
 	fmt.Printf("Exiting validator %s, you check check the validator status at %s\n", validatorPubKey, fmt.Sprintf("%s/validator/%s#withdrawals", exitResponse.BeaconChainUrl, validatorPubKey))
+	if exitResponse.WithdrawalEstimate != nil {
+		fmt.Println()
+		printWithdrawalEstimate(exitResponse.WithdrawalEstimate)
+	}
 
 	return nil
 }
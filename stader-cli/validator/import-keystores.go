@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+)
+
+func importKeystores(c *cli.Context, paths []string) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Find the keystore files; folders are searched for staking-deposit-cli's keystore-*.json files
+	keystorePaths := []string{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("error reading keystore %s: %w", path, err)
+		}
+		if !info.IsDir() {
+			keystorePaths = append(keystorePaths, path)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(path, "keystore*.json"))
+		if err != nil {
+			return fmt.Errorf("error searching %s for keystores: %w", path, err)
+		}
+		keystorePaths = append(keystorePaths, matches...)
+	}
+	if len(keystorePaths) == 0 {
+		fmt.Println("No keystores were found.")
+		return nil
+	}
+
+	// Get the keystores' password
+	var password string
+	if passwordFile := c.String("password-file"); passwordFile != "" {
+		passwordBytes, err := ioutil.ReadFile(passwordFile)
+		if err != nil {
+			return fmt.Errorf("error reading password file: %w", err)
+		}
+		password = strings.TrimRight(string(passwordBytes), "\r\n")
+	} else {
+		password = cliutils.PromptPassword("Please enter the password of the keystores:", "^.*$", "")
+	}
+
+	// Load the keystores
+	keystores := make([]api.KeystoreImport, 0, len(keystorePaths))
+	for _, keystorePath := range keystorePaths {
+		keystoreBytes, err := ioutil.ReadFile(keystorePath)
+		if err != nil {
+			return fmt.Errorf("error reading keystore %s: %w", keystorePath, err)
+		}
+		if !json.Valid(keystoreBytes) {
+			return fmt.Errorf("keystore %s is not valid JSON", keystorePath)
+		}
+		keystores = append(keystores, api.KeystoreImport{
+			Keystore: keystoreBytes,
+			Password: password,
+		})
+	}
+
+	// Prompt for confirmation
+	fmt.Printf("Found %d keystores:\n", len(keystorePaths))
+	for _, keystorePath := range keystorePaths {
+		fmt.Printf("\t%s\n", keystorePath)
+	}
+	fmt.Println()
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf(
+		"%sYour Validator client will start validating with these keys. If any of them are active anywhere else, YOU WILL BE SLASHED.%s\n"+
+			"Are you sure you want to import these keystores?",
+		log.ColorYellow,
+		log.ColorReset))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Import them
+	response, err := staderClient.ImportKeystores(keystores, true)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Imported %d validator keys:\n", len(response.ImportedKeys))
+	for _, importedKey := range response.ImportedKeys {
+		if importedKey.ValidatorExists {
+			fmt.Printf("\t0x%s (already on the Beacon chain)\n", importedKey.ValidatorPubKey.Hex())
+		} else {
+			fmt.Printf("\t0x%s\n", importedKey.ValidatorPubKey.Hex())
+		}
+	}
+	fmt.Println()
+	fmt.Println("Keys that aren't on the Beacon chain yet can be deposited with `stader-cli validator deposit --imported-keys`.")
+
+	return nil
+
+}
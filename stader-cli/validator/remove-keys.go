@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+func removeValidatorKeys(c *cli.Context, validatorPubKeys []types.ValidatorPubkey, allWithdrawn bool) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(staderClient)
+	if err != nil {
+		return err
+	}
+
+	// Check which keys can be removed
+	response, err := staderClient.CanRemoveValidatorKeys(validatorPubKeys, allWithdrawn)
+	if err != nil {
+		return err
+	}
+	removableKeys := []types.ValidatorPubkey{}
+	for _, key := range response.Keys {
+		switch {
+		case key.ValidatorNotFound:
+			fmt.Printf("Validator %s is not on the Beacon chain, so its key can't be removed.\n", key.Pubkey.Hex())
+		case key.NotWithdrawable:
+			fmt.Printf("Validator %s is %s and becomes withdrawable at epoch %d (the current epoch is %d), so its key can't be removed yet.\n", key.Pubkey.Hex(), key.BeaconStatus, key.WithdrawableEpoch, response.CurrentEpoch)
+		default:
+			removableKeys = append(removableKeys, key.Pubkey)
+		}
+	}
+	if len(removableKeys) == 0 {
+		fmt.Println("There are no validator keys to remove.")
+		return nil
+	}
+	if len(removableKeys) != len(response.Keys) {
+		fmt.Println("Please only select the keys of validators that have exited and are withdrawable.")
+		return nil
+	}
+
+	// Prompt for confirmation
+	fmt.Println("The following validators have exited and are withdrawable:")
+	for _, pubkey := range removableKeys {
+		fmt.Printf("\t%s\n", pubkey.Hex())
+	}
+	fmt.Println()
+	fmt.Println("Their slashing protection history will be exported first, then their keys will be deleted from the node wallet and the Validator client.")
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to remove the keys of %d validators?", len(removableKeys)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Remove the keys
+	removeResponse, err := staderClient.RemoveValidatorKeys(removableKeys)
+	if err != nil {
+		// Keys may already be deleted from the Validator client, so keep whatever history it returned
+		if removeResponse.SlashingProtection != "" {
+			saveSlashingProtection(c.String("output"), removeResponse)
+		}
+		return err
+	}
+
+	// Save a copy of the slashing protection history
+	saveSlashingProtection(c.String("output"), removeResponse)
+
+	// Log & return
+	fmt.Printf("Removed the keys of %d validators.\n", len(removeResponse.RemovedKeys))
+	return nil
+
+}
+
+// Save a copy of the slashing protection history of removed keys
+func saveSlashingProtection(outputDir string, removeResponse api.RemoveValidatorKeysResponse) {
+	slashingProtectionFile := filepath.Join(outputDir, removeResponse.SlashingProtectionFile)
+	err := os.MkdirAll(outputDir, 0755)
+	if err == nil {
+		err = ioutil.WriteFile(slashingProtectionFile, []byte(removeResponse.SlashingProtection), 0600)
+	}
+	switch {
+	case err == nil:
+		fmt.Printf("Saved the slashing protection history to %s.\n", slashingProtectionFile)
+	case removeResponse.SlashingProtectionSaved:
+		fmt.Printf("%sThe slashing protection history could not be saved to %s: %s. A copy is in the node's validators folder as %s.%s\n", log.ColorYellow, slashingProtectionFile, err.Error(), removeResponse.SlashingProtectionFile, log.ColorReset)
+	default:
+		fmt.Printf("%sThe slashing protection history could not be saved to %s: %s. Please keep a copy of it:%s\n%s\n", log.ColorYellow, slashingProtectionFile, err.Error(), log.ColorReset, removeResponse.SlashingProtection)
+	}
+}
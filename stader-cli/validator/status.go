@@ -6,6 +6,7 @@ import (
 	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
 	"github.com/stader-labs/stader-node/shared/utils/log"
 	"github.com/stader-labs/stader-node/shared/utils/math"
+	"github.com/stader-labs/stader-node/shared/utils/stdr"
 	"github.com/stader-labs/stader-node/stader-lib/types"
 	"github.com/stader-labs/stader-node/stader-lib/utils/eth"
 	"github.com/urfave/cli"
@@ -84,6 +85,14 @@ func getValidatorStatus(c *cli.Context) error {
 
 		fmt.Printf("-Validator Withdraw Vault: %s\n\n", validatorInfo.WithdrawVaultAddress)
 
+		if validatorInfo.ActivationEstimate != nil {
+			printActivationEstimate(validatorInfo.ActivationEstimate)
+		}
+
+		if validatorInfo.WithdrawalEstimate != nil {
+			printWithdrawalEstimate(validatorInfo.WithdrawalEstimate)
+		}
+
 		if validatorInfo.DepositBlock.Int64() > 0 {
 			fmt.Printf("-Deposit time: %s\n\n", validatorInfo.DepositTime.Format("2006-01-02 15:04:05"))
 		}
@@ -97,3 +106,31 @@ func getValidatorStatus(c *cli.Context) error {
 
 	return nil
 }
+
+// Print where a validator is in the activation queues and when it should go live
+func printActivationEstimate(estimate *stdr.ActivationEstimate) {
+	if estimate.InStaderQueue {
+		fmt.Printf("-Stader Deposit Queue Position: %d of %d\n", estimate.StaderQueuePosition+1, estimate.StaderQueueLength)
+		if !estimate.StaderQueueFunded {
+			fmt.Printf("-Estimated Activation: waiting for the pool to have enough ETH to deposit for this validator\n\n")
+			return
+		}
+		fmt.Printf("The pool has enough ETH to deposit for this validator in its next batch.\n")
+	} else if estimate.InBeaconQueue {
+		fmt.Printf("-Beacon Chain Activation Queue Position: %d of %d (%d validators activated per epoch)\n", estimate.BeaconQueuePosition+1, estimate.BeaconQueueLength, estimate.BeaconChurnLimit)
+	}
+	if estimate.HasEta {
+		fmt.Printf("-Estimated Activation: %s (epoch %d)\n\n", estimate.ActivationTime.Format("2006-01-02 15:04:05"), estimate.ActivationEpoch)
+	}
+}
+
+// Print when an exiting validator's funds should reach its withdraw vault
+func printWithdrawalEstimate(estimate *stdr.WithdrawalEstimate) {
+	fmt.Printf("-Exit Epoch: %d (%s)\n", estimate.ExitEpoch, estimate.ExitTime.Format("2006-01-02 15:04:05"))
+	fmt.Printf("-Withdrawable Epoch: %d (%s)\n", estimate.WithdrawableEpoch, estimate.WithdrawableTime.Format("2006-01-02 15:04:05"))
+	if estimate.HasFundsEta {
+		fmt.Printf("-Funds expected in the withdraw vault at ~%s\n\n", estimate.FundsTime.Format("2006-01-02 15:04:05"))
+	} else {
+		fmt.Printf("-Funds will be sent to the withdraw vault when the withdrawal sweep reaches this validator after it becomes withdrawable\n\n")
+	}
+}
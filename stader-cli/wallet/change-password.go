@@ -0,0 +1,55 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+)
+
+func changePassword(c *cli.Context) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Get & check wallet status
+	status, err := staderClient.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Get the passwords
+	currentPassword := cliutils.PromptPassword("Please enter the current wallet password:", "^.*$", "")
+	var newPassword string
+	if c.String("password") != "" {
+		newPassword = c.String("password")
+	} else {
+		newPassword = promptPassword()
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("The wallet and every validator keystore will be re-encrypted, and your Validator client will be restarted. Are you sure you want to change the password?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Change the password
+	if _, err := staderClient.ChangePassword(currentPassword, newPassword); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Println("The wallet password was changed, the wallet and validator keystores were re-encrypted, and the Validator client was restarted.")
+	fmt.Println("Please update any backups of the password file; backups made with `stader-cli service backup` before now still contain the old password.")
+	return nil
+
+}
@@ -20,11 +20,35 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package wallet
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli"
 
 	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
 )
 
+// Splits the mnemonic into shares
+var mnemonicSharesFlag = cli.StringFlag{
+	Name:  "shares",
+	Usage: "Split the mnemonic phrase into shares in the format 'M-of-N', such as '3-of-5', so that any M of the N shares recover it. The shares are printed instead of the mnemonic phrase.",
+}
+
+// Validator key recovery flags
+var (
+	keyStartIndexFlag = cli.UintFlag{
+		Name:  "key-start-index",
+		Usage: "The first index to search for validator keys at",
+	}
+	keyEndIndexFlag = cli.UintFlag{
+		Name:  "key-end-index",
+		Usage: "The index to stop searching for validator keys at (defaults to 2000 past the start index)",
+	}
+	validatorKeyPathFlag = cli.StringFlag{
+		Name:  "validator-key-path",
+		Usage: "The derivation path to search for validator keys first, with %d in place of the index (defaults to \"m/12381/3600/%d/0/0\")",
+	}
+)
+
 // Register commands
 func RegisterCommands(app *cli.App, name string, aliases []string) {
 	app.Commands = append(app.Commands, cli.Command{
@@ -65,6 +89,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "mnemonic, m",
 						Usage: "The mnemonic phrase to recover the wallet from",
 					},
+					cli.BoolFlag{
+						Name:  "shares",
+						Usage: "Recover the mnemonic phrase from shares made with --shares, instead of entering it",
+					},
 					cli.BoolFlag{
 						Name:  "skip-validator-key-recovery, k",
 						Usage: "Recover the node wallet, but do not regenerate its validator keys",
@@ -82,6 +110,9 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "address, a",
 						Usage: "If you are recovering a wallet that was not generated by the Stadernode and don't know the derivation path or index of it, enter the address here. The Stadernode will search through its library of paths and indices to try to find it.",
 					},
+					keyStartIndexFlag,
+					keyEndIndexFlag,
+					validatorKeyPathFlag,
 				},
 				Action: func(c *cli.Context) error {
 
@@ -100,6 +131,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						if _, err := cliutils.ValidateWalletMnemonic("mnemonic", c.String("mnemonic")); err != nil {
 							return err
 						}
+						if c.Bool("shares") {
+							return fmt.Errorf("--mnemonic and --shares can't be used together")
+						}
+					}
+					if c.String("validator-key-path") != "" {
+						if _, err := cliutils.ValidateValidatorKeyPath("validator-key-path", c.String("validator-key-path")); err != nil {
+							return err
+						}
 					}
 
 					// Run
@@ -108,6 +147,64 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "recover-validator-keys",
+				Usage:     "Search the node wallet for the validator keys registered with your operator and recover the ones that are found",
+				UsageText: "stader-cli wallet recover-validator-keys [options]",
+				Flags: []cli.Flag{
+					keyStartIndexFlag,
+					keyEndIndexFlag,
+					validatorKeyPathFlag,
+					cli.BoolFlag{
+						Name:  "test-only, t",
+						Usage: "Only report which keys would be recovered, without saving them or restarting the Validator client",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm restarting the Validator client",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("validator-key-path") != "" {
+						if _, err := cliutils.ValidateValidatorKeyPath("validator-key-path", c.String("validator-key-path")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return recoverValidatorKeys(c)
+
+				},
+			},
+
+			{
+				Name:      "watch",
+				Usage:     "Set up a watch-only node wallet that only knows your operator address, for monitoring without the wallet's keys",
+				UsageText: "stader-cli wallet watch address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					address, err := cliutils.ValidateAddress("address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return watchWallet(c, address)
+
+				},
+			},
+
 			{
 				Name:      "init",
 				Aliases:   []string{"i"},
@@ -126,6 +223,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "derivation-path, d",
 						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
 					},
+					mnemonicSharesFlag,
 				},
 				Action: func(c *cli.Context) error {
 
@@ -140,6 +238,11 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if c.String("shares") != "" {
+						if _, _, err := cliutils.ValidateShareScheme("shares", c.String("shares")); err != nil {
+							return err
+						}
+					}
 
 					// Run
 					return initWallet(c)
@@ -150,8 +253,11 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "export",
 				Aliases:   []string{"e"},
-				Usage:     "Export the node wallet in JSON format",
-				UsageText: "stader-cli wallet export",
+				Usage:     "Export the node wallet in JSON format, or split its mnemonic into shares",
+				UsageText: "stader-cli wallet export [options]",
+				Flags: []cli.Flag{
+					mnemonicSharesFlag,
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -159,11 +265,79 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						return err
 					}
 
+					// Validate flags
+					if c.String("shares") != "" {
+						if _, _, err := cliutils.ValidateShareScheme("shares", c.String("shares")); err != nil {
+							return err
+						}
+					}
+
 					// Run
 					return exportWallet(c)
 
 				},
 			},
+
+			{
+				Name:      "change-password",
+				Usage:     "Change the node wallet password, re-encrypting the wallet and every validator keystore",
+				UsageText: "stader-cli wallet change-password [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "password, p",
+						Usage: "The new password to secure the wallet with",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm changing the password and restarting the Validator client",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("password") != "" {
+						if _, err := cliutils.ValidateNodePassword("password", c.String("password")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return changePassword(c)
+
+				},
+			},
+
+			{
+				Name:      "sign-tx",
+				Usage:     "Sign a transaction exported with --export-unsigned-tx, so it can be broadcast from another machine",
+				UsageText: "stader-cli wallet sign-tx [options] unsigned-tx-file",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to save the signed transaction to (defaults to the unsigned transaction's file name with a -signed suffix)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm signing the transaction",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return signTransaction(c, c.Args().Get(0))
+
+				},
+			},
 		},
 	})
 }
@@ -62,6 +62,21 @@ func exportWallet(c *cli.Context) error {
 		}
 	}
 
+	// Split the mnemonic into shares instead of exporting the wallet
+	if c.String("shares") != "" {
+		fmt.Println("The wallet only stores the seed derived from your mnemonic phrase, so please enter the mnemonic phrase to split.")
+		mnemonic := promptMnemonic()
+		verifyResponse, err := staderCLient.VerifyMnemonic(mnemonic)
+		if err != nil {
+			return err
+		}
+		if !verifyResponse.Matches {
+			return fmt.Errorf("The mnemonic phrase you entered is not the one the node wallet was created from.")
+		}
+		fmt.Println("")
+		return printMnemonicShares(mnemonic, c.String("shares"))
+	}
+
 	// Export wallet
 	export, err := staderCLient.ExportWallet()
 	if err != nil {
@@ -69,10 +84,15 @@ func exportWallet(c *cli.Context) error {
 	}
 
 	// Print wallet & return
-	fmt.Println("Node account private key:")
-	fmt.Println("")
-	fmt.Println(export.AccountPrivateKey)
-	fmt.Println("")
+	if export.AccountPrivateKey != "" {
+		fmt.Println("Node account private key:")
+		fmt.Println("")
+		fmt.Println(export.AccountPrivateKey)
+		fmt.Println("")
+	} else {
+		fmt.Println("The node account private key is held by your external signer and is not included.")
+		fmt.Println("")
+	}
 	fmt.Println("Wallet password:")
 	fmt.Println("")
 	fmt.Println(export.Password)
@@ -78,25 +78,38 @@ func initWallet(c *cli.Context) error {
 		return err
 	}
 
-	// Print mnemonic
-	fmt.Println("Your mnemonic phrase to recover your wallet is printed below. It can be used to recover your node account and validator keys if they are lost.")
-	fmt.Println("We request that you take note of your password and mnemonic key phrase and store them in a secure location.")
-	fmt.Println("The recovery of either the password or the mnemonic phrase is not possible if you lose control of this device or if the node crashes")
-	fmt.Println("It is therefore imperative that you safeguard these credentials to avoid any potential loss of access to your node or validator.")
-	fmt.Println("==============================================================================================================================================")
-	fmt.Println("")
-	fmt.Println(response.Mnemonic)
-	fmt.Println("")
-	fmt.Println("==============================================================================================================================================")
-	fmt.Println("")
-
-	// Confirm mnemonic
-	if !c.Bool("confirm-mnemonic") {
-		confirmMnemonic(response.Mnemonic)
+	// Print mnemonic, or its shares
+	if c.String("shares") != "" {
+		if err := printMnemonicShares(response.Mnemonic, c.String("shares")); err != nil {
+			return err
+		}
+		fmt.Println("We request that you take note of your password and store it in a secure location.")
+		fmt.Println("")
+
+		// Confirm shares
+		if !c.Bool("confirm-mnemonic") {
+			confirmMnemonicShares(response.Mnemonic)
+		}
+	} else {
+		fmt.Println("Your mnemonic phrase to recover your wallet is printed below. It can be used to recover your node account and validator keys if they are lost.")
+		fmt.Println("We request that you take note of your password and mnemonic key phrase and store them in a secure location.")
+		fmt.Println("The recovery of either the password or the mnemonic phrase is not possible if you lose control of this device or if the node crashes")
+		fmt.Println("It is therefore imperative that you safeguard these credentials to avoid any potential loss of access to your node or validator.")
+		fmt.Println("==============================================================================================================================================")
+		fmt.Println("")
+		fmt.Println(response.Mnemonic)
+		fmt.Println("")
+		fmt.Println("==============================================================================================================================================")
+		fmt.Println("")
+
+		// Confirm mnemonic
+		if !c.Bool("confirm-mnemonic") {
+			confirmMnemonic(response.Mnemonic)
+		}
 	}
 
 	// Do a recover to save the wallet
-	recoverResponse, err := staderClient.RecoverWallet(response.Mnemonic, true, derivationPath, 0)
+	recoverResponse, err := staderClient.RecoverWallet(response.Mnemonic, true, derivationPath, 0, 0, 0, "")
 	if err != nil {
 		return fmt.Errorf("error saving wallet: %w", err)
 	}
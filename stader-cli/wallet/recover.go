@@ -29,6 +29,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/types/api"
 	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
 )
 
@@ -70,6 +71,8 @@ func recoverWallet(c *cli.Context) error {
 	var mnemonic string
 	if c.String("mnemonic") != "" {
 		mnemonic = c.String("mnemonic")
+	} else if c.Bool("shares") {
+		mnemonic = promptMnemonicShares()
 	} else {
 		mnemonic = promptMnemonic()
 	}
@@ -149,7 +152,7 @@ func recoverWallet(c *cli.Context) error {
 		}
 
 		// Recover wallet
-		response, err := staderOwner.RecoverWallet(mnemonic, skipValidatorKeyRecovery, derivationPath, walletIndex)
+		response, err := staderOwner.RecoverWallet(mnemonic, skipValidatorKeyRecovery, derivationPath, walletIndex, c.Uint("key-start-index"), c.Uint("key-end-index"), c.String("validator-key-path"))
 		if err != nil {
 			return err
 		}
@@ -164,16 +167,76 @@ func recoverWallet(c *cli.Context) error {
 				return nil
 			}
 
-			if len(response.ValidatorKeys) > 0 {
-				fmt.Println("Validator keys:")
-				for _, key := range response.ValidatorKeys {
-					fmt.Println(key.Hex())
-				}
-			} else {
-				fmt.Println("No validator keys were found.")
-			}
+			printRecoveryReport(response.RecoveryReport)
 		}
 	}
 
 	return nil
 }
+
+func recoverValidatorKeys(c *cli.Context) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(staderClient)
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	testOnly := c.Bool("test-only")
+	if !(testOnly || c.Bool("yes") || cliutils.Confirm("Any validator keys that are found will be saved and your Validator client will be restarted. Do you want to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Recover the keys
+	fmt.Println("Searching for validator keys...")
+	response, err := staderClient.RecoverValidatorKeys(c.Uint("key-start-index"), c.Uint("key-end-index"), c.String("validator-key-path"), testOnly)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	if !response.OperatorExists {
+		fmt.Println("Operator not registered with Stader, no validator keys to recover")
+		return nil
+	}
+	printRecoveryReport(response.RecoveryReport)
+	if !testOnly && len(response.RecoveryReport.RecoveredKeys) > 0 {
+		fmt.Println("The recovered validator keys were saved and the Validator client was restarted.")
+	}
+	return nil
+
+}
+
+// Print the validator keys that were and weren't found
+func printRecoveryReport(report *api.ValidatorKeyRecoveryReport) {
+	fmt.Printf("Searched indices %d to %d of %s.\n", report.StartIndex, report.EndIndex, strings.Join(report.DerivationPaths, ", "))
+	if len(report.RecoveredKeys) > 0 {
+		fmt.Println("Validator keys:")
+		for _, key := range report.RecoveredKeys {
+			source := key.DerivationPath
+			if key.Imported {
+				source = "imported"
+			}
+			fmt.Printf("%s (%s, %s)\n", key.Pubkey.Hex(), source, key.ContractStatus)
+		}
+	} else {
+		fmt.Println("No validator keys were found.")
+	}
+	if len(report.MissingKeys) > 0 {
+		fmt.Println()
+		fmt.Printf("%sThe following validator keys registered with your operator were not found:%s\n", log.ColorYellow, log.ColorReset)
+		for _, key := range report.MissingKeys {
+			fmt.Printf("%s (%s)\n", key.Pubkey.Hex(), key.ContractStatus)
+		}
+		fmt.Println("If they were created from this wallet, run `stader-cli wallet recover-validator-keys` with a wider --key-start-index/--key-end-index range or a different --validator-key-path.")
+		fmt.Println("If they were imported, recover them with `stader-cli validator import-keystores`.")
+	}
+}
@@ -0,0 +1,69 @@
+package shamir
+
+// Arithmetic in GF(256) with the Rijndael polynomial x^8 + x^4 + x^3 + x + 1, as used by SLIP-39
+var (
+	expTable [255]byte
+	logTable [256]byte
+)
+
+func init() {
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		expTable[i] = x
+		logTable[x] = byte(i)
+		// Multiply by the generator 3
+		x ^= xtime(x)
+	}
+}
+
+// Multiply by x, reducing by the polynomial
+func xtime(a byte) byte {
+	if a&0x80 != 0 {
+		return (a << 1) ^ 0x1b
+	}
+	return a << 1
+}
+
+func gfMul(a byte, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[(int(logTable[a])+int(logTable[b]))%255]
+}
+
+func gfDiv(a byte, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return expTable[(int(logTable[a])-int(logTable[b])+255)%255]
+}
+
+// A point on a polynomial for every byte of a value
+type point struct {
+	x byte
+	y []byte
+}
+
+// Evaluate the polynomials through a set of points at x with Lagrange interpolation.
+// The points must have distinct x values and equally long y values.
+func interpolate(points []point, x byte) []byte {
+	for _, p := range points {
+		if p.x == x {
+			return append([]byte{}, p.y...)
+		}
+	}
+	result := make([]byte, len(points[0].y))
+	for i, pi := range points {
+		// The Lagrange basis polynomial of point i at x
+		basis := byte(1)
+		for j, pj := range points {
+			if i != j {
+				basis = gfMul(basis, gfDiv(x^pj.x, pi.x^pj.x))
+			}
+		}
+		for k := range result {
+			result[k] ^= gfMul(basis, pi.y[k])
+		}
+	}
+	return result
+}
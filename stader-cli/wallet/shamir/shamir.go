@@ -0,0 +1,293 @@
+// Package shamir splits a BIP-39 mnemonic into M-of-N shares in the style of SLIP-39: Shamir's secret sharing over
+// GF(256) with a digest share that detects wrong combinations. Shares are written with the BIP-39 word list and carry
+// their own checksum, so they aren't interchangeable with SLIP-39 wallets.
+package shamir
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Share limits and encoding sizes
+const (
+	MaxShareCount = 16
+
+	identifierBits      = 15
+	thresholdBits       = 4
+	indexBits           = 4
+	headerBits          = identifierBits + thresholdBits + indexBits
+	wordBits            = 11
+	checksumWords       = 3
+	digestLength        = 4
+	digestIndex    byte = 254
+	secretIndex    byte = 255
+)
+
+// Prefix of the data the share checksum is computed over
+var checksumCustomization = []byte("stader-shamir")
+
+// One share of a secret
+type Share struct {
+	Identifier uint16
+	Threshold  int
+	Index      int
+	Value      []byte
+}
+
+// Split a secret into count shares, any threshold of which recover it
+func Split(secret []byte, threshold int, count int) ([]Share, error) {
+
+	// Check the parameters
+	if len(secret) < 16 || len(secret) > 32 || len(secret)%4 != 0 {
+		return nil, fmt.Errorf("the secret must be 16 to 32 bytes long in steps of 4, not %d bytes", len(secret))
+	}
+	if threshold < 1 || threshold > count {
+		return nil, fmt.Errorf("the threshold must be between 1 and the share count %d", count)
+	}
+	if count > MaxShareCount {
+		return nil, fmt.Errorf("at most %d shares can be made", MaxShareCount)
+	}
+
+	// Get a random identifier, which ties the shares together
+	identifierBytes, err := randomBytes(2)
+	if err != nil {
+		return nil, err
+	}
+	identifier := binary.BigEndian.Uint16(identifierBytes) & (1<<identifierBits - 1)
+
+	shares := make([]Share, count)
+	for i := range shares {
+		shares[i] = Share{Identifier: identifier, Threshold: threshold, Index: i}
+	}
+
+	// With a threshold of 1, every share is the secret itself
+	if threshold == 1 {
+		for i := range shares {
+			shares[i].Value = append([]byte{}, secret...)
+		}
+		return shares, nil
+	}
+
+	// Fix the polynomials with random shares, the digest and the secret
+	points := []point{}
+	for i := 0; i < threshold-2; i++ {
+		value, err := randomBytes(len(secret))
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point{x: byte(i), y: value})
+	}
+	digestKey, err := randomBytes(len(secret) - digestLength)
+	if err != nil {
+		return nil, err
+	}
+	points = append(points,
+		point{x: digestIndex, y: append(getDigest(digestKey, secret), digestKey...)},
+		point{x: secretIndex, y: secret},
+	)
+
+	// Evaluate them at every share's index
+	for i := range shares {
+		shares[i].Value = interpolate(points, byte(i))
+	}
+	return shares, nil
+
+}
+
+// Recover a secret from at least a threshold of its shares
+func Combine(shares []Share) ([]byte, error) {
+
+	// Check the shares belong together
+	if len(shares) == 0 {
+		return nil, errors.New("no shares were given")
+	}
+	first := shares[0]
+	indices := map[int]bool{}
+	for _, share := range shares {
+		if share.Identifier != first.Identifier || share.Threshold != first.Threshold || len(share.Value) != len(first.Value) {
+			return nil, errors.New("the shares are not all from the same set")
+		}
+		if indices[share.Index] {
+			return nil, fmt.Errorf("share %d was given more than once", share.Index+1)
+		}
+		indices[share.Index] = true
+	}
+	if len(shares) < first.Threshold {
+		return nil, fmt.Errorf("%d shares are needed, but only %d were given", first.Threshold, len(shares))
+	}
+	if first.Threshold == 1 {
+		return append([]byte{}, first.Value...), nil
+	}
+
+	// Interpolate the secret and the digest
+	points := make([]point, first.Threshold)
+	for i := range points {
+		points[i] = point{x: byte(shares[i].Index), y: shares[i].Value}
+	}
+	secret := interpolate(points, secretIndex)
+	digest := interpolate(points, digestIndex)
+	if !hmac.Equal(digest[:digestLength], getDigest(digest[digestLength:], secret)) {
+		return nil, errors.New("the shares don't recover a valid secret; one of them may have been entered wrong")
+	}
+	return secret, nil
+
+}
+
+// Encode a share as words from the BIP-39 word list
+func (s Share) String() string {
+
+	// Pack the header, the padding and the value
+	padding := (wordBits - (headerBits+len(s.Value)*8)%wordBits) % wordBits
+	data := big.NewInt(int64(s.Identifier))
+	data.Lsh(data, thresholdBits).Or(data, big.NewInt(int64(s.Threshold-1)))
+	data.Lsh(data, indexBits).Or(data, big.NewInt(int64(s.Index)))
+	data.Lsh(data, uint(padding+len(s.Value)*8)).Or(data, new(big.Int).SetBytes(s.Value))
+	dataWordCount := (headerBits + padding + len(s.Value)*8) / wordBits
+
+	// Split it into words and add the checksum
+	indices := make([]int, dataWordCount)
+	mask := big.NewInt(1<<wordBits - 1)
+	for i := dataWordCount - 1; i >= 0; i-- {
+		indices[i] = int(new(big.Int).And(data, mask).Int64())
+		data.Rsh(data, wordBits)
+	}
+	indices = append(indices, getChecksum(indices)...)
+	wordList := bip39.GetWordList()
+	words := make([]string, len(indices))
+	for i, index := range indices {
+		words[i] = wordList[index]
+	}
+	return strings.Join(words, " ")
+
+}
+
+// Decode a share, checking its checksum
+func ParseShare(encoded string) (Share, error) {
+
+	// Get the word indices
+	words := strings.Fields(strings.ToLower(encoded))
+	if len(words) <= checksumWords {
+		return Share{}, errors.New("the share is too short")
+	}
+	indices := make([]int, len(words))
+	for i, word := range words {
+		index, exists := bip39.GetWordIndex(word)
+		if !exists {
+			return Share{}, fmt.Errorf("word %d (%s) is not in the word list", i+1, word)
+		}
+		indices[i] = index
+	}
+
+	// Check the checksum
+	dataIndices := indices[:len(indices)-checksumWords]
+	if !equalIndices(getChecksum(dataIndices), indices[len(indices)-checksumWords:]) {
+		return Share{}, errors.New("the share's checksum is wrong; check it for typos")
+	}
+
+	// Unpack the header and the value
+	data := new(big.Int)
+	for _, index := range dataIndices {
+		data.Lsh(data, wordBits).Or(data, big.NewInt(int64(index)))
+	}
+	if len(dataIndices)*wordBits < headerBits+16*8 {
+		return Share{}, errors.New("the share is too short")
+	}
+	valueLength := (len(dataIndices)*wordBits - headerBits) / 8
+	padding := len(dataIndices)*wordBits - headerBits - valueLength*8
+	valueBits := uint(valueLength * 8)
+	value := new(big.Int).And(data, new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), valueBits), big.NewInt(1)))
+	header := new(big.Int).Rsh(data, valueBits)
+	if new(big.Int).And(header, big.NewInt(1<<padding-1)).Sign() != 0 {
+		return Share{}, errors.New("the share's padding is not zero")
+	}
+	header.Rsh(header, uint(padding))
+	headerValue := header.Uint64()
+	share := Share{
+		Identifier: uint16(headerValue >> (thresholdBits + indexBits)),
+		Threshold:  int(headerValue>>indexBits&(1<<thresholdBits-1)) + 1,
+		Index:      int(headerValue & (1<<indexBits - 1)),
+		Value:      make([]byte, valueLength),
+	}
+	value.FillBytes(share.Value)
+	return share, nil
+
+}
+
+// Split a BIP-39 mnemonic into count shares, any threshold of which recover it
+func SplitMnemonic(mnemonic string, threshold int, count int) ([]string, error) {
+	entropy, err := bip39.EntropyFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	shares, err := Split(entropy, threshold, count)
+	if err != nil {
+		return nil, err
+	}
+	encodedShares := make([]string, len(shares))
+	for i, share := range shares {
+		encodedShares[i] = share.String()
+	}
+	return encodedShares, nil
+}
+
+// Recover a BIP-39 mnemonic from at least a threshold of its shares
+func CombineMnemonic(shares []Share) (string, error) {
+	entropy, err := Combine(shares)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// Get the digest that checks a recovered secret
+func getDigest(key []byte, secret []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(secret)
+	return mac.Sum(nil)[:digestLength]
+}
+
+// Get the checksum words of a share's data words
+func getChecksum(indices []int) []int {
+	data := append([]byte{}, checksumCustomization...)
+	for _, index := range indices {
+		data = append(data, byte(index>>8), byte(index))
+	}
+	hash := sha256.Sum256(data)
+	bits := new(big.Int).SetBytes(hash[:])
+	bits.Rsh(bits, uint(len(hash)*8-checksumWords*wordBits))
+	checksum := make([]int, checksumWords)
+	mask := big.NewInt(1<<wordBits - 1)
+	for i := checksumWords - 1; i >= 0; i-- {
+		checksum[i] = int(new(big.Int).And(bits, mask).Int64())
+		bits.Rsh(bits, wordBits)
+	}
+	return checksum
+}
+
+func equalIndices(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func randomBytes(length int) ([]byte, error) {
+	value := make([]byte, length)
+	if _, err := rand.Read(value); err != nil {
+		return nil, fmt.Errorf("could not generate random bytes: %w", err)
+	}
+	return value, nil
+}
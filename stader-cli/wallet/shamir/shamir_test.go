@@ -0,0 +1,103 @@
+package shamir
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+func parseShares(t *testing.T, encoded []string) []Share {
+	shares := make([]Share, len(encoded))
+	for i, value := range encoded {
+		share, err := ParseShare(value)
+		if err != nil {
+			t.Fatalf("could not parse share %d: %s", i+1, err.Error())
+		}
+		shares[i] = share
+	}
+	return shares
+}
+
+func TestSplitMnemonic(t *testing.T) {
+	for _, bits := range []int{128, 256} {
+		entropy, err := bip39.NewEntropy(bits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mnemonic, err := bip39.NewMnemonic(entropy)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encoded, err := SplitMnemonic(mnemonic, 3, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		shares := parseShares(t, encoded)
+
+		// Any 3 shares recover the mnemonic
+		for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}} {
+			chosen := []Share{}
+			for _, i := range subset {
+				chosen = append(chosen, shares[i])
+			}
+			recovered, err := CombineMnemonic(chosen)
+			if err != nil {
+				t.Fatalf("could not recover from shares %v: %s", subset, err.Error())
+			}
+			if recovered != mnemonic {
+				t.Errorf("shares %v recovered the wrong mnemonic", subset)
+			}
+		}
+
+		// Two aren't enough
+		if _, err := CombineMnemonic(shares[:2]); err == nil {
+			t.Error("expected 2 of 3 shares to be rejected")
+		}
+	}
+}
+
+func TestShareChecksum(t *testing.T) {
+	encoded, err := SplitMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	words := strings.Fields(encoded[0])
+	if words[5] == "zoo" {
+		words[5] = "abandon"
+	} else {
+		words[5] = "zoo"
+	}
+	if _, err := ParseShare(strings.Join(words, " ")); err == nil {
+		t.Error("expected a share with a changed word to fail its checksum")
+	}
+}
+
+func TestCombineMismatchedShares(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+	shares, err := Split(secret, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Shares from another split don't combine
+	otherShares, err := Split(secret, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherShares[1].Identifier = shares[0].Identifier
+	if _, err := Combine([]Share{shares[0], otherShares[1]}); err == nil {
+		t.Error("expected shares from different splits to fail the digest check")
+	}
+
+	// A threshold of 1 gives the secret in every share
+	single, err := Split(secret, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovered, err := Combine(single[1:])
+	if err != nil || string(recovered) != string(secret) {
+		t.Errorf("expected a single share to recover the secret, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+)
+
+func signTransaction(c *cli.Context, path string) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Load the unsigned transaction
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading unsigned transaction: %w", err)
+	}
+	var unsignedTx txmanager.UnsignedTx
+	if err := json.Unmarshal(bytes, &unsignedTx); err != nil {
+		return fmt.Errorf("error parsing unsigned transaction: %w", err)
+	}
+	if _, err := unsignedTx.Transaction(); err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	fmt.Println("The node wallet will sign this transaction:")
+	cliutils.PrintUnsignedTx(unsignedTx)
+	fmt.Println()
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to sign this transaction?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Sign it
+	response, err := staderClient.SignTx(unsignedTx)
+	if err != nil {
+		return err
+	}
+
+	// Save the signed transaction
+	output := c.String("output")
+	if output == "" {
+		output = strings.TrimSuffix(path, ".json") + "-signed.json"
+	}
+	signedBytes, err := json.MarshalIndent(response.SignedTx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing signed transaction: %w", err)
+	}
+	if err := ioutil.WriteFile(output, signedBytes, 0600); err != nil {
+		return fmt.Errorf("error saving signed transaction to %s: %w", output, err)
+	}
+	fmt.Printf("Signed transaction %s was saved to %s.\n", response.SignedTx.Hash.Hex(), output)
+	fmt.Printf("Copy it to the node and run `stader-cli tx broadcast %s` to send it.\n", output)
+	return nil
+
+}
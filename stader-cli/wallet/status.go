@@ -54,6 +54,11 @@ func getStatus(c *cli.Context) error {
 		fmt.Printf("Node account: %s\n", status.AccountAddress.Hex())
 		fmt.Printf("Current Nonce: %d\n", status.CurrentNonce)
 		fmt.Printf("Pending Nonce: %d\n", status.PendingNonce)
+	} else if status.WatchOnly {
+		fmt.Println("The node wallet is watch-only: it knows the node account's address, but not its keys.")
+		fmt.Printf("Node account: %s\n", status.AccountAddress.Hex())
+		fmt.Printf("Current Nonce: %d\n", status.CurrentNonce)
+		fmt.Printf("Pending Nonce: %d\n", status.PendingNonce)
 	} else {
 		fmt.Println("The node wallet has not been initialized.")
 	}
@@ -38,6 +38,7 @@ import (
 	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
 	hexutils "github.com/stader-labs/stader-node/shared/utils/hex"
 	"github.com/stader-labs/stader-node/stader-cli/wallet/bip39"
+	"github.com/stader-labs/stader-node/stader-cli/wallet/shamir"
 	"github.com/stader-labs/stader-node/stader-lib/types"
 	"gopkg.in/yaml.v2"
 )
@@ -120,6 +121,78 @@ func confirmMnemonic(mnemonic string) {
 	}
 }
 
+// Split a mnemonic phrase into shares and print them
+func printMnemonicShares(mnemonic string, scheme string) error {
+	threshold, count, err := cliutils.ValidateShareScheme("shares", scheme)
+	if err != nil {
+		return err
+	}
+	shares, err := shamir.SplitMnemonic(mnemonic, threshold, count)
+	if err != nil {
+		return fmt.Errorf("error splitting the mnemonic phrase into shares: %w", err)
+	}
+	fmt.Printf("Your mnemonic phrase has been split into %d shares, printed below. Any %d of them can be combined to recover your wallet, and fewer than %d reveal nothing about it.\n", count, threshold, threshold)
+	fmt.Printf("Store each share in a different secure location. If more than %d of them are lost, your wallet can no longer be recovered from them.\n", count-threshold)
+	fmt.Println("These shares use the BIP-39 word list and can only be combined by stader-cli; they are not compatible with SLIP-39 wallets.")
+	fmt.Println("==============================================================================================================================================")
+	for i, share := range shares {
+		fmt.Println("")
+		fmt.Printf("Share %d of %d:\n", i+1, count)
+		fmt.Println(share)
+	}
+	fmt.Println("")
+	fmt.Println("==============================================================================================================================================")
+	fmt.Println("")
+	return nil
+}
+
+// Prompt for enough mnemonic shares to recover a mnemonic phrase
+func promptMnemonicShares() string {
+	for {
+		shares := []shamir.Share{}
+		threshold := 1
+		for len(shares) < threshold {
+			prompt := fmt.Sprintf("Enter %sShare Number %d%s of your mnemonic phrase:", bold, len(shares)+1, unbold)
+			if len(shares) > 0 {
+				prompt = fmt.Sprintf("Enter %sShare Number %d of %d%s of your mnemonic phrase:", bold, len(shares)+1, threshold, unbold)
+			}
+			input := cliutils.PromptPassword(prompt, "^[a-zA-Z ]+$", "Please enter the words of the share, separated by spaces.")
+			share, err := shamir.ParseShare(strings.ToLower(input))
+			if err != nil {
+				fmt.Printf("Invalid share: %s. Please retry.\n", err)
+				continue
+			}
+			if len(shares) == 0 {
+				threshold = share.Threshold
+			}
+			shares = append(shares, share)
+		}
+
+		mnemonic, err := shamir.CombineMnemonic(shares)
+		if err != nil {
+			fmt.Printf("Error combining the shares: %s\n", err)
+			fmt.Println("Please try again.")
+			fmt.Println("")
+			continue
+		}
+
+		return mnemonic
+	}
+}
+
+// Confirm that mnemonic shares recover a mnemonic phrase
+func confirmMnemonicShares(mnemonic string) {
+	for {
+		fmt.Println("Please enter enough of your shares to confirm that they recover your mnemonic phrase.")
+		confirmation := promptMnemonicShares()
+		if mnemonic == confirmation {
+			return
+		}
+		fmt.Println("The shares you entered do not recover your mnemonic phrase. Please try again.")
+		fmt.Println("")
+	}
+}
+
 // Check for custom keys, prompt for their passwords, and store them in the custom keys file
 func promptForCustomKeyPasswords(sd *stader.Client, cfg *config.StaderConfig, testOnly bool) (string, error) {
 
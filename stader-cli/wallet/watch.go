@@ -0,0 +1,44 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/stader"
+	"github.com/stader-labs/stader-node/shared/utils/log"
+)
+
+func watchWallet(c *cli.Context, address common.Address) error {
+
+	staderClient, err := stader.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer staderClient.Close()
+
+	// Get & check wallet status
+	status, err := staderClient.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if status.WalletInitialized || status.WatchOnly {
+		fmt.Println("The node wallet is already initialized.")
+		return nil
+	}
+
+	// Save the operator address
+	response, err := staderClient.WatchWallet(address)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("The node wallet is now watch-only for node account %s.\n", response.AccountAddress.Hex())
+	fmt.Println("Status commands, exports and metrics will work, but anything that needs to sign will fail.")
+	fmt.Printf("%sDon't run a Validator client with this node: it has no validator keys.%s\n", log.ColorYellow, log.ColorReset)
+	fmt.Println("To sign with this node later, replace the watch-only wallet with `stader-cli wallet recover`.")
+	return nil
+
+}
@@ -12,6 +12,9 @@ import (
 	types2 "github.com/stader-labs/stader-node/stader-lib/types"
 )
 
+// The number of validators to read from the registry at a time
+const validatorPageSize int64 = 100
+
 func EstimateOnboardNodeOperator(pnr *stader.PermissionlessNodeRegistryContractManager, mevSocialize bool, operatorName string, operatorRewarderAddress common.Address, opts *bind.TransactOpts) (stader.GasInfo, error) {
 	return pnr.PermissionlessNodeRegistryContract.GetTransactionGasInfo(opts, "onboardNodeOperator", mevSocialize, operatorName, operatorRewarderAddress)
 }
@@ -140,7 +143,7 @@ func GetValidatorInfosByOperator(pnr *stader.PermissionlessNodeRegistryContractM
 func GetAllValidatorsInfoByOperator(pnr *stader.PermissionlessNodeRegistryContractManager, operatorAddress common.Address, opts *bind.CallOpts) ([]contracts.Validator, error) {
 	finalValidators := []contracts.Validator{}
 	pageNumber := big.NewInt(1)
-	pageSize := big.NewInt(100)
+	pageSize := big.NewInt(validatorPageSize)
 	for {
 		validators, err := pnr.PermissionlessNodeRegistry.GetValidatorsByOperator(opts, operatorAddress, pageNumber, pageSize)
 
@@ -157,3 +160,25 @@ func GetAllValidatorsInfoByOperator(pnr *stader.PermissionlessNodeRegistryContra
 
 	return finalValidators, nil
 }
+
+// Get all of an operator's validators, fetching every page in a single multicall. totalKeys is the operator's total
+// number of validator keys, and determines how many pages are read.
+func GetAllValidatorsInfoByOperatorWithMulticall(pnr *stader.PermissionlessNodeRegistryContractManager, mc *stader.MultiCaller, operatorAddress common.Address, totalKeys *big.Int, opts *bind.CallOpts) ([]contracts.Validator, error) {
+	pageCount := (totalKeys.Int64() + validatorPageSize - 1) / validatorPageSize
+	pages := make([][]contracts.Validator, pageCount)
+	for i := range pages {
+		if err := mc.AddCall(pnr.PermissionlessNodeRegistryContract, &pages[i], "getValidatorsByOperator", operatorAddress, big.NewInt(int64(i+1)), big.NewInt(validatorPageSize)); err != nil {
+			return nil, err
+		}
+	}
+	if err := mc.Execute(opts); err != nil {
+		return nil, err
+	}
+
+	finalValidators := []contracts.Validator{}
+	for _, page := range pages {
+		finalValidators = append(finalValidators, page...)
+	}
+
+	return finalValidators, nil
+}
@@ -104,6 +104,24 @@ func CalculateValidatorWithdrawVaultWithdrawShare(executionClient stader.Executi
 	return vwv.ValidatorWithdrawVault.CalculateValidatorWithdrawalShare(opts)
 }
 
+func CalculateValidatorWithdrawVaultWithdrawShares(executionClient stader.ExecutionClient, mc *stader.MultiCaller, validatorWithdrawVaultAddresses []common.Address, opts *bind.CallOpts) ([]types2.RewardShare, error) {
+	withdrawShares := make([]types2.RewardShare, len(validatorWithdrawVaultAddresses))
+	for i, validatorWithdrawVaultAddress := range validatorWithdrawVaultAddresses {
+		vwv, err := stader.NewValidatorWithdrawVaultFactory(executionClient, validatorWithdrawVaultAddress)
+		if err != nil {
+			return nil, err
+		}
+		if err := mc.AddCall(vwv.ValidatorWithdrawVaultContract, &withdrawShares[i], "calculateValidatorWithdrawalShare"); err != nil {
+			return nil, err
+		}
+	}
+	if err := mc.Execute(opts); err != nil {
+		return nil, err
+	}
+
+	return withdrawShares, nil
+}
+
 func GetValidatorIdByPubKey(pnr *stader.PermissionlessNodeRegistryContractManager, validatorPubKey []byte, opts *bind.CallOpts) (*big.Int, error) {
 	return pnr.PermissionlessNodeRegistry.ValidatorIdByPubkey(opts, validatorPubKey)
 }
@@ -123,3 +141,35 @@ func GetTotalQueuedValidators(pnr *stader.PermissionlessNodeRegistryContractMana
 func GetInputKeyLimitCount(pnr *stader.PermissionlessNodeRegistryContractManager, opts *bind.CallOpts) (uint16, error) {
 	return pnr.PermissionlessNodeRegistry.InputKeyCountLimit(opts)
 }
+
+func GetNextQueuedValidatorIndex(pnr *stader.PermissionlessNodeRegistryContractManager, opts *bind.CallOpts) (*big.Int, error) {
+	return pnr.PermissionlessNodeRegistry.NextQueuedValidatorIndex(opts)
+}
+
+func GetValidatorQueueSize(pnr *stader.PermissionlessNodeRegistryContractManager, opts *bind.CallOpts) (*big.Int, error) {
+	return pnr.PermissionlessNodeRegistry.ValidatorQueueSize(opts)
+}
+
+func GetQueuedValidatorId(pnr *stader.PermissionlessNodeRegistryContractManager, queueIndex *big.Int, opts *bind.CallOpts) (*big.Int, error) {
+	return pnr.PermissionlessNodeRegistry.QueuedValidators(opts, queueIndex)
+}
+
+// Get the IDs of the validators from startIndex up to endIndex in the queue, fetching them in a single multicall
+func GetQueuedValidatorIds(pnr *stader.PermissionlessNodeRegistryContractManager, mc *stader.MultiCaller, startIndex *big.Int, endIndex *big.Int, opts *bind.CallOpts) ([]*big.Int, error) {
+	validatorIds := []*big.Int{}
+	if startIndex.Cmp(endIndex) >= 0 {
+		return validatorIds, nil
+	}
+	validatorIds = make([]*big.Int, new(big.Int).Sub(endIndex, startIndex).Int64())
+	for i := range validatorIds {
+		queueIndex := new(big.Int).Add(startIndex, big.NewInt(int64(i)))
+		if err := mc.AddCall(pnr.PermissionlessNodeRegistryContract, &validatorIds[i], "queuedValidators", queueIndex); err != nil {
+			return nil, err
+		}
+	}
+	if err := mc.Execute(opts); err != nil {
+		return nil, err
+	}
+
+	return validatorIds, nil
+}
@@ -10,3 +10,17 @@ import (
 func GetCumulativeValidatorPenalty(pt *stader.PenaltyTrackerContractManager, validatorPubKey types.ValidatorPubkey, opts *bind.CallOpts) (*big.Int, error) {
 	return pt.Penalty.TotalPenaltyAmount(opts, validatorPubKey.Bytes())
 }
+
+func GetCumulativeValidatorPenalties(pt *stader.PenaltyTrackerContractManager, mc *stader.MultiCaller, validatorPubKeys []types.ValidatorPubkey, opts *bind.CallOpts) ([]*big.Int, error) {
+	penalties := make([]*big.Int, len(validatorPubKeys))
+	for i, validatorPubKey := range validatorPubKeys {
+		if err := mc.AddCall(pt.PenaltyContract, &penalties[i], "totalPenaltyAmount", validatorPubKey.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+	if err := mc.Execute(opts); err != nil {
+		return nil, err
+	}
+
+	return penalties, nil
+}
@@ -15,3 +15,21 @@ func CalculateRewardShare(pool_utils *stader.PoolUtilsContractManager, poolId ui
 func IsExistingOperator(pool_utils *stader.PoolUtilsContractManager, operatorAddress common.Address, opts *bind.CallOpts) (bool, error) {
 	return pool_utils.PoolUtils.IsExistingOperator(opts, operatorAddress)
 }
+
+func GetCollateralETH(pool_utils *stader.PoolUtilsContractManager, poolId uint8, opts *bind.CallOpts) (*big.Int, error) {
+	return pool_utils.PoolUtils.GetCollateralETH(opts, poolId)
+}
+
+func CalculateRewardShares(pool_utils *stader.PoolUtilsContractManager, mc *stader.MultiCaller, poolId uint8, totalRewards []*big.Int, opts *bind.CallOpts) ([]types.RewardShare, error) {
+	rewardShares := make([]types.RewardShare, len(totalRewards))
+	for i, rewards := range totalRewards {
+		if err := mc.AddCall(pool_utils.PoolUtilsContract, &rewardShares[i], "calculateRewardShare", poolId, rewards); err != nil {
+			return nil, err
+		}
+	}
+	if err := mc.Execute(opts); err != nil {
+		return nil, err
+	}
+
+	return rewardShares, nil
+}
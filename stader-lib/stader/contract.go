@@ -104,7 +104,7 @@ func (c *Contract) Transact(opts *bind.TransactOpts, method string, params ...in
 	// Send transaction
 	tx, err := c.Contract.Transact(opts, method, params...)
 	if err != nil {
-		return nil, err
+		return nil, DecodeRevert(err, c.ABI)
 	}
 
 	return tx, nil
@@ -162,7 +162,7 @@ func (c *Contract) estimateGasLimit(opts *bind.TransactOpts, input []byte) (uint
 	})
 
 	if err != nil {
-		return 0, 0, fmt.Errorf("Could not estimate gas needed: %w", err)
+		return 0, 0, fmt.Errorf("Could not estimate gas needed: %w", DecodeRevert(err, c.ABI))
 	}
 
 	// Pad and return gas limit
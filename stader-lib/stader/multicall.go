@@ -0,0 +1,222 @@
+package stader
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Multicall3 is deployed to the same address on every network
+var Multicall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// The default number of calls to send in a single eth_call
+const DefaultMulticallBatchSize int = 500
+
+const multicall3Abi = `[
+	{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bool","name":"allowFailure","type":"bool"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall3.Call3[]","name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"internalType":"address","name":"addr","type":"address"}],"name":"getEthBalance","outputs":[{"internalType":"uint256","name":"balance","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+// A call for Multicall3's aggregate3
+type multicall3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// A result from Multicall3's aggregate3
+type multicall3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// A contract call waiting to be sent
+type multicall struct {
+	contract  *Contract
+	method    string
+	input     []byte
+	result    interface{}
+	balanceOf *common.Address
+}
+
+// Batches contract reads into as few eth_calls as possible using Multicall3.
+// Calls are queued with AddCall, which takes the same arguments as Contract.Call, and their results are only
+// populated once Execute returns. On networks without Multicall3 the calls are sent one at a time instead.
+type MultiCaller struct {
+	Client    ExecutionClient
+	Address   common.Address
+	BatchSize int
+	contract  *Contract
+	calls     []multicall
+}
+
+// Create a new multicaller for the Multicall3 contract at the given address
+func NewMultiCaller(client ExecutionClient, multicallAddress common.Address) (*MultiCaller, error) {
+	multicallAbi, err := abi.JSON(strings.NewReader(multicall3Abi))
+	if err != nil {
+		return nil, err
+	}
+	multicallContract := &Contract{
+		Contract: bind.NewBoundContract(multicallAddress, multicallAbi, client, client, client),
+		Address:  &multicallAddress,
+		ABI:      &multicallAbi,
+		Client:   client,
+	}
+
+	return &MultiCaller{
+		Client:    client,
+		Address:   multicallAddress,
+		BatchSize: DefaultMulticallBatchSize,
+		contract:  multicallContract,
+	}, nil
+}
+
+// Queue a contract call; the result is written when Execute is called
+func (mc *MultiCaller) AddCall(contract *Contract, result interface{}, method string, params ...interface{}) error {
+	input, err := contract.ABI.Pack(method, params...)
+	if err != nil {
+		return fmt.Errorf("Could not encode input data for %s: %w", method, err)
+	}
+	mc.calls = append(mc.calls, multicall{
+		contract: contract,
+		method:   method,
+		input:    input,
+		result:   result,
+	})
+	return nil
+}
+
+// Queue a read of an address's ETH balance; the result is written when Execute is called
+func (mc *MultiCaller) AddEthBalance(result **big.Int, address common.Address) error {
+	if err := mc.AddCall(mc.contract, result, "getEthBalance", address); err != nil {
+		return err
+	}
+	mc.calls[len(mc.calls)-1].balanceOf = &address
+	return nil
+}
+
+// Get the number of calls waiting to be sent
+func (mc *MultiCaller) PendingCalls() int {
+	return len(mc.calls)
+}
+
+// Send all of the queued calls and write their results. The queue is cleared even if a call fails.
+func (mc *MultiCaller) Execute(opts *bind.CallOpts) error {
+	calls := mc.calls
+	mc.calls = nil
+
+	if opts == nil {
+		opts = &bind.CallOpts{}
+	}
+	if len(calls) == 0 {
+		return nil
+	}
+
+	// Fall back to sending each call on its own if Multicall3 isn't deployed
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	code, err := mc.Client.CodeAt(ctx, mc.Address, opts.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("Could not check for Multicall3 at %s: %w", mc.Address.Hex(), err)
+	}
+	if len(code) == 0 {
+		return mc.executeEach(ctx, opts, calls)
+	}
+
+	batchSize := mc.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultMulticallBatchSize
+	}
+	for start := 0; start < len(calls); start += batchSize {
+		end := start + batchSize
+		if end > len(calls) {
+			end = len(calls)
+		}
+		if err := mc.executeBatch(opts, calls[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Send a batch of calls in a single aggregate3 call
+func (mc *MultiCaller) executeBatch(opts *bind.CallOpts, calls []multicall) error {
+	aggregateCalls := make([]multicall3Call, len(calls))
+	for i, call := range calls {
+		aggregateCalls[i] = multicall3Call{
+			Target:       *call.contract.Address,
+			AllowFailure: true,
+			CallData:     call.input,
+		}
+	}
+	input, err := mc.contract.ABI.Pack("aggregate3", aggregateCalls)
+	if err != nil {
+		return fmt.Errorf("Could not encode multicall input data: %w", err)
+	}
+
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	output, err := mc.Client.CallContract(ctx, ethereum.CallMsg{
+		From: opts.From,
+		To:   &mc.Address,
+		Data: input,
+	}, opts.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("Could not execute multicall: %w", err)
+	}
+
+	unpacked, err := mc.contract.ABI.Unpack("aggregate3", output)
+	if err != nil {
+		return fmt.Errorf("Could not decode multicall results: %w", err)
+	}
+	results := *abi.ConvertType(unpacked[0], new([]multicall3Result)).(*[]multicall3Result)
+	if len(results) != len(calls) {
+		return fmt.Errorf("Multicall returned %d results for %d calls", len(results), len(calls))
+	}
+
+	for i, call := range calls {
+		if !results[i].Success {
+			return fmt.Errorf("Could not call %s on %s: execution reverted", call.method, call.contract.Address.Hex())
+		}
+		if err := call.contract.ABI.UnpackIntoInterface(call.result, call.method, results[i].ReturnData); err != nil {
+			return fmt.Errorf("Could not decode result of %s on %s: %w", call.method, call.contract.Address.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// Send each call in its own eth_call, for networks without Multicall3
+func (mc *MultiCaller) executeEach(ctx context.Context, opts *bind.CallOpts, calls []multicall) error {
+	for _, call := range calls {
+		if call.balanceOf != nil {
+			balance, err := mc.Client.BalanceAt(ctx, *call.balanceOf, opts.BlockNumber)
+			if err != nil {
+				return fmt.Errorf("Could not get ETH balance of %s: %w", call.balanceOf.Hex(), err)
+			}
+			*call.result.(**big.Int) = balance
+			continue
+		}
+		output, err := mc.Client.CallContract(ctx, ethereum.CallMsg{
+			From: opts.From,
+			To:   call.contract.Address,
+			Data: call.input,
+		}, opts.BlockNumber)
+		if err != nil {
+			return fmt.Errorf("Could not call %s on %s: %w", call.method, call.contract.Address.Hex(), err)
+		}
+		if err := call.contract.ABI.UnpackIntoInterface(call.result, call.method, output); err != nil {
+			return fmt.Errorf("Could not decode result of %s on %s: %w", call.method, call.contract.Address.Hex(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package stader
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stader-labs/stader-node/stader-lib/contracts"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+// An execution client that serves Multicall3's aggregate3, or plain calls when it isn't deployed, and counts how many eth_calls it gets
+type fakeMulticallClient struct {
+	ExecutionClient
+	t          *testing.T
+	abi        abi.ABI
+	handlers   map[common.Address]func(input []byte) ([]byte, bool)
+	calls      int
+	undeployed bool
+}
+
+func (c *fakeMulticallClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.calls++
+	if c.undeployed {
+		handler, ok := c.handlers[*call.To]
+		if !ok {
+			return nil, errors.New("execution reverted")
+		}
+		output, success := handler(call.Data)
+		if !success {
+			return nil, errors.New("execution reverted")
+		}
+		return output, nil
+	}
+	if *call.To != Multicall3Address {
+		return nil, errors.New("unexpected call target")
+	}
+	method, err := c.abi.MethodById(call.Data[:4])
+	if err != nil || method.Name != "aggregate3" {
+		return nil, errors.New("unexpected multicall method")
+	}
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	aggregateCalls := *abi.ConvertType(args[0], new([]multicall3Call)).(*[]multicall3Call)
+
+	results := make([]multicall3Result, len(aggregateCalls))
+	for i, aggregateCall := range aggregateCalls {
+		if !aggregateCall.AllowFailure {
+			c.t.Error("expected calls to allow failure")
+		}
+		handler, ok := c.handlers[aggregateCall.Target]
+		if !ok {
+			continue
+		}
+		results[i].ReturnData, results[i].Success = handler(aggregateCall.CallData)
+	}
+	return method.Outputs.Pack(results)
+}
+
+func (c *fakeMulticallClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	if c.undeployed {
+		return nil, nil
+	}
+	return []byte{0x01}, nil
+}
+
+// Every address's balance is its own value
+func (c *fakeMulticallClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return new(big.Int).SetBytes(account.Bytes()), nil
+}
+
+func newFakeMulticallClient(t *testing.T) *fakeMulticallClient {
+	multicallAbi, err := abi.JSON(strings.NewReader(multicall3Abi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &fakeMulticallClient{
+		t:        t,
+		abi:      multicallAbi,
+		handlers: map[common.Address]func(input []byte) ([]byte, bool){},
+	}
+}
+
+func TestMultiCaller(t *testing.T) {
+	testMultiCaller(t, false)
+}
+
+func TestMultiCallerWithoutMulticall3(t *testing.T) {
+	testMultiCaller(t, true)
+}
+
+// Read balances and reward shares in batches of 4, or one at a time when Multicall3 isn't deployed
+func testMultiCaller(t *testing.T, undeployed bool) {
+	client := newFakeMulticallClient(t)
+	client.undeployed = undeployed
+
+	// Balances are read through Multicall3 itself
+	client.handlers[Multicall3Address] = func(input []byte) ([]byte, bool) {
+		args, err := client.abi.Methods["getEthBalance"].Inputs.Unpack(input[4:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		address := args[0].(common.Address)
+		output, err := client.abi.Methods["getEthBalance"].Outputs.Pack(new(big.Int).SetBytes(address.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return output, true
+	}
+
+	// Reward shares split the total 10/20/70
+	poolUtilsAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	poolUtils, err := NewPoolUtils(client, poolUtilsAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	poolUtilsAbi, err := abi.JSON(strings.NewReader(contracts.PoolUtilsMetaData.ABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.handlers[poolUtilsAddress] = func(input []byte) ([]byte, bool) {
+		args, err := poolUtilsAbi.Methods["calculateRewardShare"].Inputs.Unpack(input[4:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		total := args[1].(*big.Int)
+		output, err := poolUtilsAbi.Methods["calculateRewardShare"].Outputs.Pack(
+			new(big.Int).Div(total, big.NewInt(10)),
+			new(big.Int).Div(total, big.NewInt(5)),
+			new(big.Int).Sub(total, new(big.Int).Div(new(big.Int).Mul(total, big.NewInt(3)), big.NewInt(10))),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return output, true
+	}
+
+	mc, err := NewMultiCaller(client, Multicall3Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mc.BatchSize = 4
+
+	addresses := make([]common.Address, 10)
+	balances := make([]*big.Int, len(addresses))
+	rewardShares := make([]types.RewardShare, len(addresses))
+	for i := range addresses {
+		addresses[i] = common.BigToAddress(big.NewInt(int64(1000 * (i + 1))))
+		if err := mc.AddEthBalance(&balances[i], addresses[i]); err != nil {
+			t.Fatal(err)
+		}
+		if err := mc.AddCall(poolUtils.PoolUtilsContract, &rewardShares[i], "calculateRewardShare", uint8(1), big.NewInt(int64(100*(i+1)))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if mc.PendingCalls() != 20 {
+		t.Fatalf("expected 20 pending calls, got %d", mc.PendingCalls())
+	}
+	if err := mc.Execute(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// 20 calls in batches of 4, or the 10 reward shares on their own with the balances read directly
+	expectedCalls := 5
+	if undeployed {
+		expectedCalls = 10
+	}
+	if client.calls != expectedCalls {
+		t.Errorf("expected %d eth_calls, got %d", expectedCalls, client.calls)
+	}
+	if mc.PendingCalls() != 0 {
+		t.Errorf("expected the queue to be cleared, got %d pending calls", mc.PendingCalls())
+	}
+	for i := range addresses {
+		if balances[i].Int64() != int64(1000*(i+1)) {
+			t.Errorf("expected balance %d for %s, got %s", 1000*(i+1), addresses[i].Hex(), balances[i])
+		}
+		total := int64(100 * (i + 1))
+		if rewardShares[i].UserShare.Int64() != total/10 || rewardShares[i].OperatorShare.Int64() != total/5 {
+			t.Errorf("unexpected reward share for %d: user %s, operator %s", total, rewardShares[i].UserShare, rewardShares[i].OperatorShare)
+		}
+	}
+}
+
+func TestMultiCallerRevert(t *testing.T) {
+	client := newFakeMulticallClient(t)
+
+	// Nothing is deployed at the pool utils address, so its calls fail
+	poolUtils, err := NewPoolUtils(client, common.HexToAddress("0x1111111111111111111111111111111111111111"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mc, err := NewMultiCaller(client, Multicall3Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rewardShare types.RewardShare
+	if err := mc.AddCall(poolUtils.PoolUtilsContract, &rewardShare, "calculateRewardShare", uint8(1), big.NewInt(100)); err != nil {
+		t.Fatal(err)
+	}
+	if err := mc.Execute(nil); err == nil {
+		t.Error("expected an error when a call reverts")
+	}
+
+	// Calls for methods the contract doesn't have are rejected up front
+	if err := mc.AddCall(poolUtils.PoolUtilsContract, &rewardShare, "notAMethod"); err == nil {
+		t.Error("expected an error for an unknown method")
+	}
+}
+
+func TestMultiCallerDecodesValidatorPages(t *testing.T) {
+	client := newFakeMulticallClient(t)
+
+	registryAddress := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	registry, err := NewPermissionlessNodeRegistry(client, registryAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.handlers[registryAddress] = func(input []byte) ([]byte, bool) {
+		method := registry.PermissionlessNodeRegistryContract.ABI.Methods["getValidatorsByOperator"]
+		args, err := method.Inputs.Unpack(input[4:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		page := args[1].(*big.Int).Int64()
+		output, err := method.Outputs.Pack([]contracts.Validator{{
+			Status:               4,
+			Pubkey:               []byte{byte(page)},
+			WithdrawVaultAddress: common.BigToAddress(big.NewInt(page)),
+			OperatorId:           big.NewInt(7),
+			DepositBlock:         big.NewInt(page * 100),
+			WithdrawnBlock:       big.NewInt(0),
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return output, true
+	}
+
+	mc, err := NewMultiCaller(client, Multicall3Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pages := make([][]contracts.Validator, 3)
+	for i := range pages {
+		if err := mc.AddCall(registry.PermissionlessNodeRegistryContract, &pages[i], "getValidatorsByOperator", common.Address{}, big.NewInt(int64(i+1)), big.NewInt(100)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mc.Execute(nil); err != nil {
+		t.Fatal(err)
+	}
+	for i, page := range pages {
+		if len(page) != 1 {
+			t.Fatalf("expected 1 validator on page %d, got %d", i+1, len(page))
+		}
+		if page[0].Status != 4 || page[0].Pubkey[0] != byte(i+1) || page[0].DepositBlock.Int64() != int64(100*(i+1)) {
+			t.Errorf("unexpected validator on page %d: %+v", i+1, page[0])
+		}
+	}
+}
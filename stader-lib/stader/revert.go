@@ -0,0 +1,246 @@
+package stader
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stader-labs/stader-node/stader-lib/contracts"
+)
+
+var (
+	// The selector of Error(string), used by require() and revert("...")
+	errorStringSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+	// The selector of Panic(uint256), used by failed asserts and arithmetic errors
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+
+	// Clients that don't return the revert data as error data put it in the message instead
+	revertDataPattern = regexp.MustCompile(`0x(?:[0-9a-fA-F]{2}){4,}`)
+)
+
+// The ABIs of the Stader contracts, used to decode errors that bubble up from a contract other than the one called
+var staderContractAbis = []string{
+	contracts.NodeElRewardVaultMetaData.ABI,
+	contracts.OperatorRewardsCollectorMetaData.ABI,
+	contracts.PenaltyTrackerMetaData.ABI,
+	contracts.PermissionlessNodeRegistryMetaData.ABI,
+	contracts.PermissionlessPoolMetaData.ABI,
+	contracts.PoolUtilsMetaData.ABI,
+	contracts.SdCollateralMetaData.ABI,
+	contracts.SocializingPoolMetaData.ABI,
+	contracts.StaderConfigMetaData.ABI,
+	contracts.StakePoolManagerMetaData.ABI,
+	contracts.ValidatorWithdrawVaultMetaData.ABI,
+	contracts.VaultFactoryMetaData.ABI,
+	contracts.VaultProxyMetaData.ABI,
+}
+
+var staderErrors map[[4]byte]abi.Error
+var loadStaderErrors sync.Once
+
+// What the node operator can do about the Stader contracts' custom errors
+var revertHints = map[string]string{
+	"CooldownNotComplete":                "the cooldown period since the last change hasn't passed yet; try again later",
+	"InsufficientSDToWithdraw":           "the amount is more than the SD collateral that can be withdrawn",
+	"InSufficientBalance":                "the node wallet doesn't have enough ETH for this",
+	"InsufficientBalance":                "the node wallet doesn't have enough ETH for this",
+	"InsufficientETHRewards":             "the socializing pool doesn't hold enough ETH rewards for this claim yet",
+	"InsufficientSDRewards":              "the socializing pool doesn't hold enough SD rewards for this claim yet",
+	"InvalidBondEthValue":                "the ETH sent doesn't match the bond required for the number of validators",
+	"InvalidCycleIndex":                  "the rewards cycle doesn't exist",
+	"FutureCycleIndex":                   "the rewards cycle hasn't finished yet",
+	"InvalidProof":                       "the merkle proof doesn't match the cycle's rewards; download the latest merkle proofs and try again",
+	"InvalidKeyCount":                    "the number of keys is zero or above the batch limit",
+	"MisMatchingInputKeysSize":           "the number of public keys and signatures don't match",
+	"NameCrossedMaxLength":               "the operator name is too long",
+	"EmptyNameString":                    "the operator name can't be empty",
+	"NoChangeInState":                    "the setting already has this value",
+	"NoStateChange":                      "the setting already has this value",
+	"NotEnoughRewardToDistribute":        "the vault doesn't hold enough rewards to distribute",
+	"NotEnoughRewardToWithdraw":          "the vault doesn't hold any rewards to withdraw",
+	"NotEnoughSDCollateral":              "the node doesn't have enough SD collateral for this many validators; deposit more SD first",
+	"OperatorAlreadyOnBoardedInProtocol": "the node is already registered",
+	"OperatorIsDeactivate":               "the node operator has been deactivated",
+	"OperatorIsNotOnboarded":             "the node isn't registered yet",
+	"OperatorNotOnBoarded":               "the node isn't registered yet",
+	"PubkeyAlreadyExist":                 "one of the validator keys is already registered",
+	"RewardAlreadyClaimed":               "the rewards for this cycle have already been claimed",
+	"UnsupportedOperationInSafeMode":     "the contract is in safe mode; wait for it to be lifted",
+	"ZeroAddress":                        "an address argument is the zero address",
+	"maxKeyLimitReached":                 "the node has reached the maximum number of validators",
+}
+
+// Descriptions of the Solidity panic codes
+var panicReasons = map[uint64]string{
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array",
+	0x31: "pop on an empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to an uninitialized function",
+}
+
+// A transaction or call that reverted, decoded into the reason it reverted
+type RevertError struct {
+	// The custom error name, or Error / Panic for the built-in ones
+	Name string
+
+	// The custom error's arguments, in order
+	Args []interface{}
+
+	// The require() message, panic description or custom error hint
+	Reason string
+
+	// The raw revert data
+	Data []byte
+
+	// The original error from the execution client
+	Err error
+}
+
+func (e *RevertError) Error() string {
+	switch e.Name {
+	case "Error":
+		return fmt.Sprintf("execution reverted: %s", e.Reason)
+	case "Panic":
+		return fmt.Sprintf("execution reverted with a panic: %s", e.Reason)
+	}
+	args := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		args[i] = fmt.Sprint(arg)
+	}
+	message := fmt.Sprintf("execution reverted: %s(%s)", e.Name, strings.Join(args, ", "))
+	if e.Reason != "" {
+		message += fmt.Sprintf(" - %s", e.Reason)
+	}
+	return message
+}
+
+func (e *RevertError) Unwrap() error {
+	return e.Err
+}
+
+// Decode the revert reason in an execution client error, using the given ABIs and then the Stader contract ABIs
+// to find custom errors. Returns the original error if it doesn't carry revert data that can be decoded.
+func DecodeRevert(err error, contractAbis ...*abi.ABI) error {
+	if err == nil {
+		return nil
+	}
+	var revertErr *RevertError
+	if errors.As(err, &revertErr) {
+		return err
+	}
+
+	data := getRevertData(err)
+	if data == nil {
+		return err
+	}
+	decoded := DecodeRevertData(data, contractAbis...)
+	if decoded == nil {
+		return err
+	}
+	decoded.Err = err
+	return decoded
+}
+
+// Decode revert data into a RevertError, or nil if it doesn't match a known error
+func DecodeRevertData(data []byte, contractAbis ...*abi.ABI) *RevertError {
+	if len(data) < 4 {
+		return nil
+	}
+
+	// Built-in errors
+	if bytes.Equal(data[:4], errorStringSelector) {
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			return nil
+		}
+		return &RevertError{Name: "Error", Reason: reason, Data: data}
+	}
+	if bytes.Equal(data[:4], panicSelector) && len(data) == 36 {
+		code := new(big.Int).SetBytes(data[4:])
+		reason, exists := panicReasons[code.Uint64()]
+		if !exists || !code.IsUint64() {
+			reason = fmt.Sprintf("code 0x%x", code)
+		}
+		return &RevertError{Name: "Panic", Reason: reason, Data: data}
+	}
+
+	// Custom errors
+	var selector [4]byte
+	copy(selector[:], data[:4])
+	for _, contractAbi := range contractAbis {
+		if contractAbi == nil {
+			continue
+		}
+		for _, abiError := range contractAbi.Errors {
+			if bytes.Equal(abiError.ID[:4], selector[:]) {
+				return unpackCustomError(abiError, data)
+			}
+		}
+	}
+	loadStaderErrors.Do(func() {
+		staderErrors = map[[4]byte]abi.Error{}
+		for _, contractAbi := range staderContractAbis {
+			parsed, err := abi.JSON(strings.NewReader(contractAbi))
+			if err != nil {
+				continue
+			}
+			for _, abiError := range parsed.Errors {
+				var errorSelector [4]byte
+				copy(errorSelector[:], abiError.ID[:4])
+				staderErrors[errorSelector] = abiError
+			}
+		}
+	})
+	if abiError, exists := staderErrors[selector]; exists {
+		return unpackCustomError(abiError, data)
+	}
+	return nil
+}
+
+// Unpack a custom error's arguments
+func unpackCustomError(abiError abi.Error, data []byte) *RevertError {
+	revertErr := &RevertError{
+		Name:   abiError.Name,
+		Reason: revertHints[abiError.Name],
+		Data:   data,
+	}
+	args, err := abiError.Unpack(data)
+	if err != nil {
+		return revertErr
+	}
+	if unpacked, ok := args.([]interface{}); ok {
+		revertErr.Args = unpacked
+	}
+	return revertErr
+}
+
+// Get the revert data from an execution client error
+func getRevertData(err error) []byte {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if decoded, err := hexutil.Decode(data); err == nil {
+				return decoded
+			}
+		}
+	}
+	if match := revertDataPattern.FindString(err.Error()); match != "" {
+		if decoded, err := hexutil.Decode(match); err == nil {
+			return decoded
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package stader
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stader-labs/stader-node/stader-lib/contracts"
+)
+
+// An execution client error with revert data, like go-ethereum's JSON-RPC errors
+type fakeDataError struct {
+	data string
+}
+
+func (e fakeDataError) Error() string          { return "execution reverted" }
+func (e fakeDataError) ErrorData() interface{} { return e.data }
+
+// An execution client whose gas estimates always revert
+type fakeRevertClient struct {
+	ExecutionClient
+	err error
+}
+
+func (c *fakeRevertClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return 0, c.err
+}
+
+func TestDecodeRevert(t *testing.T) {
+	sdCollateralAbi, err := abi.JSON(strings.NewReader(contracts.SdCollateralMetaData.ABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A custom error from the called contract, with its arguments
+	insufficientSd := sdCollateralAbi.Errors["InsufficientSDToWithdraw"]
+	data, err := insufficientSd.Inputs.Pack(big.NewInt(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = append(insufficientSd.ID[:4], data...)
+	address := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	client := &fakeRevertClient{err: fakeDataError{data: hexutil.Encode(data)}}
+	contract := &Contract{Address: &address, ABI: &sdCollateralAbi, Client: client}
+
+	_, err = contract.GetTransactionGasInfo(&bind.TransactOpts{}, "withdraw", big.NewInt(10))
+	var revertErr *RevertError
+	if !errors.As(err, &revertErr) {
+		t.Fatalf("expected a revert error, got %v", err)
+	}
+	if revertErr.Name != "InsufficientSDToWithdraw" || len(revertErr.Args) != 1 || revertErr.Args[0].(*big.Int).Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("unexpected revert error: %+v", revertErr)
+	}
+	if !strings.Contains(err.Error(), "InsufficientSDToWithdraw(5) - the amount is more than the SD collateral that can be withdrawn") {
+		t.Errorf("unexpected error message: %s", err.Error())
+	}
+
+	// A custom error from another Stader contract, with the data in the message
+	registryAbi, err := abi.JSON(strings.NewReader(contracts.PermissionlessNodeRegistryMetaData.ABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	notEnoughSd := registryAbi.Errors["NotEnoughSDCollateral"]
+	err = DecodeRevert(errors.New("Reverted "+hexutil.Encode(notEnoughSd.ID[:4])), &sdCollateralAbi)
+	if !errors.As(err, &revertErr) || revertErr.Name != "NotEnoughSDCollateral" {
+		t.Errorf("expected NotEnoughSDCollateral, got %v", err)
+	}
+
+	// require() messages and panics
+	message, _ := abi.NewType("string", "", nil)
+	data, _ = abi.Arguments{{Type: message}}.Pack("Pausable: paused")
+	err = DecodeRevert(fakeDataError{data: hexutil.Encode(append(errorStringSelector, data...))})
+	if err == nil || err.Error() != "execution reverted: Pausable: paused" {
+		t.Errorf("unexpected require() error: %v", err)
+	}
+	code := common.LeftPadBytes([]byte{0x11}, 32)
+	err = DecodeRevert(fakeDataError{data: hexutil.Encode(append(panicSelector, code...))})
+	if err == nil || err.Error() != "execution reverted with a panic: arithmetic overflow or underflow" {
+		t.Errorf("unexpected panic error: %v", err)
+	}
+
+	// Errors without revert data are left alone
+	original := errors.New("insufficient funds for gas * price + value")
+	if DecodeRevert(original) != original {
+		t.Errorf("expected an error without revert data to be returned as-is")
+	}
+}
@@ -21,3 +21,17 @@ func GetEthBalance(client stader.ExecutionClient, address common.Address, opts *
 
 	return ethBalance, nil
 }
+
+func GetEthBalances(mc *stader.MultiCaller, addresses []common.Address, opts *bind.CallOpts) ([]*big.Int, error) {
+	balances := make([]*big.Int, len(addresses))
+	for i, address := range addresses {
+		if err := mc.AddEthBalance(&balances[i], address); err != nil {
+			return nil, err
+		}
+	}
+	if err := mc.Execute(opts); err != nil {
+		return nil, err
+	}
+
+	return balances, nil
+}
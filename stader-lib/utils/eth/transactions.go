@@ -50,7 +50,7 @@ func EstimateSendTransactionGas(client stader.ExecutionClient, toAddress common.
 		Value:    value,
 	})
 	if err != nil {
-		return stader.GasInfo{}, err
+		return stader.GasInfo{}, stader.DecodeRevert(err)
 	}
 	response.EstGasLimit = gasLimit
 	response.SafeGasLimit = gasLimit
@@ -89,7 +89,7 @@ func SendTransaction(client stader.ExecutionClient, toAddress common.Address, ch
 			Value:    value,
 		})
 		if err != nil {
-			return common.Hash{}, err
+			return common.Hash{}, stader.DecodeRevert(err)
 		}
 	}
 
@@ -373,6 +373,50 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "sign-typed-data",
+				Usage:     "Signs an EIP-712 typed data document with the node's private key.",
+				UsageText: "stader-cli api node sign-typed-data typed-data-json",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					typedData := c.Args().Get(0)
+
+					// Run
+					api.PrintResponse(signTypedData(c, typedData))
+					return nil
+
+				},
+			},
+			{
+				Name:      "verify-signature",
+				Usage:     "Recovers the signer of a personal message or EIP-712 typed data document and checks it against an address.",
+				UsageText: "stader-cli api node verify-signature type message signature expected-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 4); err != nil {
+						return err
+					}
+
+					signatureType := c.Args().Get(0)
+					message := c.Args().Get(1)
+					signature := c.Args().Get(2)
+					expectedAddress, err := cliutils.ValidateAddress("expected address", c.Args().Get(3))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(verifySignature(c, signatureType, message, signature, expectedAddress))
+					return nil
+
+				},
+			},
 			{
 				Name:      "can-update-socialize-el",
 				Usage:     "Can opt in or opt out of socializing pool",
@@ -710,6 +754,23 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "report",
+				Usage:     "Get snapshots of the node's collateral, validators and rewards over a period of time",
+				UsageText: "stader-cli api node report from to interval",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getReport(c, c.Args().Get(0), c.Args().Get(1), c.Args().Get(2)))
+					return nil
+
+				},
+			},
 		},
 	})
 }
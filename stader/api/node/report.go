@@ -0,0 +1,230 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/services/state"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/shared/utils/stdr"
+	"github.com/stader-labs/stader-node/stader-lib/stader"
+	"github.com/stader-labs/stader-node/stader-lib/utils/eth"
+	"github.com/urfave/cli"
+)
+
+// The most snapshots a single report can contain
+const maxReportSnapshots = 1000
+
+// The number of earlier slots to try if the slot for a snapshot is missing a block
+const maxReportMissedSlots uint64 = 32
+
+func getReport(c *cli.Context, from string, to string, interval string) (*api.NodeReportResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeReportResponse{}
+
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	m, err := state.NewMetricsCache(c, cfg, ec, bc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Work out when each snapshot should be taken
+	fromTime, err := parseReportTime(ec, from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid report start '%s': %w", from, err)
+	}
+	toTime, err := parseReportTime(ec, to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid report end '%s': %w", to, err)
+	}
+	if toTime.Before(fromTime) {
+		return nil, fmt.Errorf("the report end (%s) is before its start (%s)", toTime.Format(time.RFC3339), fromTime.Format(time.RFC3339))
+	}
+	snapshotTimes, err := getReportTimes(fromTime, toTime, interval)
+	if err != nil {
+		return nil, err
+	}
+	headSlot, err := m.GetHeadSlot()
+	if err != nil {
+		return nil, err
+	}
+
+	response.Snapshots = []api.OperatorSnapshot{}
+	for _, snapshotTime := range snapshotTimes {
+		slot, err := getSlotAtTime(m.BeaconConfig, snapshotTime)
+		if err != nil {
+			return nil, err
+		}
+		if slot > headSlot {
+			break
+		}
+		slot, err = getLatestSlotWithBlock(bc, slot)
+		if err != nil {
+			return nil, err
+		}
+
+		metrics, err := m.GetStateForSlot(nodeAccount.Address, slot)
+		if err != nil {
+			if cfg.StaderNode.ArchiveECUrl.Value.(string) == "" {
+				return nil, fmt.Errorf("error getting the node's state at slot %d: %w\nReports for older dates need an archive-mode Execution client. You can set one with `stader-cli service config`.", slot, err)
+			}
+			return nil, fmt.Errorf("error getting the node's state at slot %d: %w", slot, err)
+		}
+		response.Snapshots = append(response.Snapshots, getOperatorSnapshot(snapshotTime, metrics))
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Parse a report boundary, which can be a block number, a date, an RFC3339 timestamp or "now"
+func parseReportTime(ec stader.ExecutionClient, value string) (time.Time, error) {
+	if value == "" || value == "now" {
+		return time.Now(), nil
+	}
+	if blockNumber, err := strconv.ParseUint(value, 10, 64); err == nil {
+		header, err := ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(blockNumber))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("error getting block %d: %w", blockNumber, err)
+		}
+		return time.Unix(int64(header.Time), 0), nil
+	}
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// Get the times to take snapshots at, from the start of the report to its end inclusive
+func getReportTimes(from time.Time, to time.Time, interval string) ([]time.Time, error) {
+	times := []time.Time{}
+	for snapshotTime := from; !snapshotTime.After(to); {
+		if len(times) == maxReportSnapshots {
+			return nil, fmt.Errorf("the report would have more than %d snapshots; use a shorter range or a longer interval", maxReportSnapshots)
+		}
+		times = append(times, snapshotTime)
+		switch interval {
+		case "hour":
+			snapshotTime = snapshotTime.Add(time.Hour)
+		case "day":
+			snapshotTime = snapshotTime.AddDate(0, 0, 1)
+		case "week":
+			snapshotTime = snapshotTime.AddDate(0, 0, 7)
+		case "month":
+			snapshotTime = snapshotTime.AddDate(0, 1, 0)
+		default:
+			return nil, fmt.Errorf("invalid interval '%s'; must be 'hour', 'day', 'week' or 'month'", interval)
+		}
+	}
+	return times, nil
+}
+
+// Get the Beacon chain slot at a point in time
+func getSlotAtTime(config beacon.Eth2Config, snapshotTime time.Time) (uint64, error) {
+	genesisTime := time.Unix(int64(config.GenesisTime), 0)
+	if snapshotTime.Before(genesisTime) {
+		return 0, fmt.Errorf("%s is before the Beacon chain's genesis", snapshotTime.Format(time.RFC3339))
+	}
+	return uint64(snapshotTime.Sub(genesisTime).Seconds()) / config.SecondsPerSlot, nil
+}
+
+// Get the latest slot at or before the given one that has a block
+func getLatestSlotWithBlock(bc beacon.Client, slot uint64) (uint64, error) {
+	for i := uint64(0); i < maxReportMissedSlots && i <= slot; i++ {
+		_, exists, err := bc.GetBeaconBlock(strconv.FormatUint(slot-i, 10))
+		if err != nil {
+			return 0, err
+		}
+		if exists {
+			return slot - i, nil
+		}
+	}
+	return 0, fmt.Errorf("no blocks found in the %d slots up to slot %d", maxReportMissedSlots, slot)
+}
+
+// Convert the metrics cache for a slot into a report snapshot
+func getOperatorSnapshot(snapshotTime time.Time, metrics *state.MetricsCache) api.OperatorSnapshot {
+	details := metrics.StaderNetworkDetails
+	snapshot := api.OperatorSnapshot{
+		Time:                                 snapshotTime,
+		Slot:                                 metrics.BeaconSlotNumber,
+		BlockNumber:                          metrics.ElBlockNumber,
+		SdCollateral:                         details.OperatorStakedSd,
+		SdCollateralInEth:                    details.OperatorStakedSdInEth,
+		EthCollateral:                        details.OperatorEthCollateral,
+		ActiveValidators:                     details.ActiveValidators.Int64(),
+		StaderQueuedValidators:               details.StaderQueuedValidators.Int64(),
+		BeaconChainQueuedValidators:          details.BeaconChainQueuedValidators.Int64(),
+		ExitingValidators:                    details.ExitingValidators.Int64(),
+		WithdrawnValidators:                  details.WithdrawnValidators.Int64(),
+		SlashedValidators:                    details.SlashedValidators.Int64(),
+		FundsSettledValidators:               details.FundsSettledValidators.Int64(),
+		CumulativePenalty:                    details.CumulativePenalty,
+		UnclaimedClRewards:                   details.UnclaimedClRewards,
+		UnclaimedNonSocializingPoolElRewards: details.UnclaimedNonSocializingPoolElRewards,
+		UnclaimedSocializingPoolElRewards:    details.UnclaimedSocializingPoolElRewards,
+		UnclaimedSocializingPoolSdRewards:    details.UnclaimedSocializingPoolSDRewards,
+		Validators:                           []api.ValidatorSnapshot{},
+	}
+
+	for pubkey, validatorContractInfo := range details.ValidatorInfoMap {
+		beaconStatus := metrics.ValidatorDetails[pubkey]
+		status, _ := stdr.GetValidatorRunningStatus(beaconStatus, validatorContractInfo)
+		validatorSnapshot := api.ValidatorSnapshot{
+			Pubkey:               pubkey,
+			Status:               status,
+			BeaconBalance:        float64(beaconStatus.Balance) / eth.WeiPerGwei,
+			WithdrawVaultAddress: validatorContractInfo.WithdrawVaultAddress,
+		}
+		if balance, exists := metrics.WithdrawVaultBalances[pubkey]; exists {
+			validatorSnapshot.WithdrawVaultBalance = eth.WeiToEth(balance)
+		}
+		if penalty, exists := metrics.ValidatorPenalties[pubkey]; exists {
+			validatorSnapshot.Penalty = eth.WeiToEth(penalty)
+		}
+		snapshot.Validators = append(snapshot.Validators, validatorSnapshot)
+	}
+	sortValidatorSnapshots(snapshot.Validators)
+
+	return snapshot
+}
+
+// Sort validator snapshots by pubkey so reports are stable between runs
+func sortValidatorSnapshots(validators []api.ValidatorSnapshot) {
+	sort.Slice(validators, func(i, j int) bool {
+		return bytes.Compare(validators[i].Pubkey.Bytes(), validators[j].Pubkey.Bytes()) < 0
+	})
+}
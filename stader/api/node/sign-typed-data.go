@@ -0,0 +1,37 @@
+package node
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	hexutils "github.com/stader-labs/stader-node/shared/utils/hex"
+	"github.com/stader-labs/stader-node/shared/utils/signature"
+)
+
+func signTypedData(c *cli.Context, typedDataJson string) (*api.NodeSignResponse, error) {
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeSignResponse{}
+	typedData, err := signature.ParseTypedData([]byte(typedDataJson))
+	if err != nil {
+		return nil, err
+	}
+	signedBytes, err := w.SignTypedData(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("Error signing typed data [%s]: %w", typedData.PrimaryType, err)
+	}
+	response.SignedData = hexutils.AddPrefix(hex.EncodeToString(signedBytes))
+
+	// Return response
+	return &response, nil
+
+}
@@ -3,13 +3,15 @@ package node
 import (
 	stader_backend "github.com/stader-labs/stader-node/shared/types/stader-backend"
 	"github.com/stader-labs/stader-node/shared/utils/eth1"
+	"github.com/stader-labs/stader-node/shared/utils/eth2"
 	pool_utils "github.com/stader-labs/stader-node/stader-lib/pool-utils"
 	socializing_pool "github.com/stader-labs/stader-node/stader-lib/socializing-pool"
 	stader_config "github.com/stader-labs/stader-node/stader-lib/stader-config"
-	"github.com/stader-labs/stader-node/stader-lib/types"
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/stader-labs/stader-node/shared/services"
 	"github.com/stader-labs/stader-node/shared/types/api"
 	"github.com/stader-labs/stader-node/shared/utils/stdr"
@@ -109,6 +111,10 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	mc, err := services.GetMultiCaller(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := api.NodeStatusResponse{}
@@ -237,42 +243,59 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 		totalValidatorClRewards := big.NewInt(0)
 		validatorInfoArray := make([]stdr.ValidatorInfo, totalValidatorKeys.Int64())
 
-		validatorInfoMap, _, err := stdr.GetAllValidatorsRegisteredWithOperator(pnr, operatorId, nodeAccount.Address, nil)
+		validatorInfoMap, validatorPubKeys, err := stdr.GetAllValidatorsRegisteredWithOperator(pnr, mc, operatorId, nodeAccount.Address, nil)
 		if err != nil {
 			return nil, err
 		}
 
-		i := 0
-		for _, validatorContractInfo := range validatorInfoMap {
-			withdrawVaultBalance, err := tokens.GetEthBalance(pnr.Client, validatorContractInfo.WithdrawVaultAddress, nil)
-			if err != nil {
-				return nil, err
-			}
-			withdrawVaultRewardShares, err := pool_utils.CalculateRewardShare(putils, 1, withdrawVaultBalance, nil)
-			if err != nil {
-				return nil, err
-			}
-			rewardsThreshold, err := stader_config.GetRewardsThreshold(sdcfg, nil)
-			if err != nil {
-				return nil, err
-			}
+		// Read every validator's withdraw vault in batches rather than one call per validator
+		withdrawVaultAddresses := make([]common.Address, len(validatorPubKeys))
+		for i, pubKey := range validatorPubKeys {
+			withdrawVaultAddresses[i] = validatorInfoMap[pubKey].WithdrawVaultAddress
+		}
+		withdrawVaultBalances, err := tokens.GetEthBalances(mc, withdrawVaultAddresses, nil)
+		if err != nil {
+			return nil, err
+		}
+		withdrawVaultRewardShares, err := pool_utils.CalculateRewardShares(putils, mc, 1, withdrawVaultBalances, nil)
+		if err != nil {
+			return nil, err
+		}
+		withdrawVaultWithdrawShares, err := node.CalculateValidatorWithdrawVaultWithdrawShares(pnr.Client, mc, withdrawVaultAddresses, nil)
+		if err != nil {
+			return nil, err
+		}
+		rewardsThreshold, err := stader_config.GetRewardsThreshold(sdcfg, nil)
+		if err != nil {
+			return nil, err
+		}
+		validatorBeaconStatuses, err := bc.GetValidatorStatuses(validatorPubKeys, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// The activation queues are only loaded if a validator is waiting to be activated
+		var staderQueue *stdr.StaderQueue
+		var beaconQueue *eth2.ActivationQueue
+
+		// Likewise for the exit queue and withdrawal sweep, if a validator is waiting for its funds
+		var exitQueue *eth2.ExitQueue
+		var withdrawalSweep *eth2.WithdrawalSweep
+
+		for i, pubKey := range validatorPubKeys {
+			validatorContractInfo := validatorInfoMap[pubKey]
+			withdrawVaultBalance := withdrawVaultBalances[i]
+			withdrawVaultRewardShare := withdrawVaultRewardShares[i]
 			crossedRewardThreshold := false
 			if withdrawVaultBalance.Cmp(rewardsThreshold) > 0 {
 				crossedRewardThreshold = true
 			} else {
-				totalValidatorClRewards.Add(totalValidatorClRewards, withdrawVaultRewardShares.OperatorShare)
+				totalValidatorClRewards.Add(totalValidatorClRewards, withdrawVaultRewardShare.OperatorShare)
 			}
 
-			withdrawVaultWithdrawShares, err := node.CalculateValidatorWithdrawVaultWithdrawShare(pnr.Client, validatorContractInfo.WithdrawVaultAddress, nil)
-			if err != nil {
-				return nil, err
-			}
-			validatorWithdrawVaultWithdrawShares := withdrawVaultWithdrawShares.OperatorShare
+			validatorWithdrawVaultWithdrawShares := withdrawVaultWithdrawShares[i].OperatorShare
 
-			validatorBeaconStatus, err := bc.GetValidatorStatus(types.BytesToValidatorPubkey(validatorContractInfo.Pubkey), nil)
-			if err != nil {
-				return nil, err
-			}
+			validatorBeaconStatus := validatorBeaconStatuses[pubKey]
 
 			validatorDisplayStatus, err := stdr.GetValidatorRunningStatus(validatorBeaconStatus, validatorContractInfo)
 			if err != nil {
@@ -288,6 +311,44 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 				return nil, err
 			}
 
+			var activationEstimate *stdr.ActivationEstimate
+			if stdr.IsAwaitingActivation(validatorContractInfo, validatorBeaconStatus) {
+				if staderQueue == nil {
+					stakePoolManagerAddress, err := services.GetStakePoolManagerAddress(c)
+					if err != nil {
+						return nil, err
+					}
+					staderQueue, err = stdr.GetStaderQueue(pnr, putils, mc, stakePoolManagerAddress, nil)
+					if err != nil {
+						return nil, err
+					}
+					beaconQueue, err = eth2.GetActivationQueue(bc)
+					if err != nil {
+						return nil, err
+					}
+				}
+				validatorId, err := node.GetValidatorIdByPubKey(pnr, validatorContractInfo.Pubkey, nil)
+				if err != nil {
+					return nil, err
+				}
+				estimate := stdr.EstimateActivation(validatorId.Uint64(), validatorContractInfo, validatorBeaconStatus, staderQueue, beaconQueue)
+				activationEstimate = &estimate
+			}
+
+			var withdrawalEstimate *stdr.WithdrawalEstimate
+			if stdr.IsAwaitingWithdrawal(validatorBeaconStatus) {
+				if exitQueue == nil {
+					exitQueue, err = eth2.GetExitQueue(bc)
+					if err != nil {
+						return nil, err
+					}
+					// The sweep can't always be measured (e.g. on a freshly synced node), so it's only used if available
+					withdrawalSweep, _ = eth2.GetWithdrawalSweep(bc, exitQueue.ValidatorCount)
+				}
+				estimate := stdr.EstimateWithdrawal(validatorBeaconStatus, exitQueue, withdrawalSweep)
+				withdrawalEstimate = &estimate
+			}
+
 			validatorInfo := stdr.ValidatorInfo{
 				Status:                           validatorContractInfo.Status,
 				StatusToDisplay:                  validatorDisplayStatus,
@@ -295,7 +356,7 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 				PreDepositSignature:              validatorContractInfo.PreDepositSignature,
 				DepositSignature:                 validatorContractInfo.DepositSignature,
 				WithdrawVaultAddress:             validatorContractInfo.WithdrawVaultAddress,
-				WithdrawVaultRewardBalance:       withdrawVaultRewardShares.OperatorShare,
+				WithdrawVaultRewardBalance:       withdrawVaultRewardShare.OperatorShare,
 				CrossedRewardsThreshold:          crossedRewardThreshold,
 				WithdrawVaultWithdrawableBalance: validatorWithdrawVaultWithdrawShares,
 				OperatorId:                       validatorContractInfo.OperatorId,
@@ -303,11 +364,11 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 				DepositTime:                      depositTime,
 				WithdrawnBlock:                   validatorContractInfo.WithdrawnBlock,
 				WithdrawnTime:                    withdrawTime,
+				ActivationEstimate:               activationEstimate,
+				WithdrawalEstimate:               withdrawalEstimate,
 			}
 
 			validatorInfoArray[i] = validatorInfo
-
-			i += 1
 		}
 
 		response.ValidatorInfos = validatorInfoArray
@@ -0,0 +1,48 @@
+package node
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/types/api"
+	hexutils "github.com/stader-labs/stader-node/shared/utils/hex"
+	"github.com/stader-labs/stader-node/shared/utils/signature"
+)
+
+// Recover the signer of a personal message or EIP-712 typed data document and compare it to the expected address
+func verifySignature(c *cli.Context, signatureType string, message string, signedData string, expectedAddress common.Address) (*api.VerifySignatureResponse, error) {
+
+	// Response
+	response := api.VerifySignatureResponse{
+		ExpectedAddress: expectedAddress,
+	}
+
+	// Recover the signer
+	signatureBytes, err := hex.DecodeString(hexutils.RemovePrefix(signedData))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid signature '%s': %w", signedData, err)
+	}
+	switch signatureType {
+	case signature.TypePersonal:
+		response.Signer, err = signature.RecoverPersonalSigner([]byte(message), signatureBytes)
+	case signature.TypeTypedData:
+		typedData, parseErr := signature.ParseTypedData([]byte(message))
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		response.Signer, err = signature.RecoverTypedDataSigner(typedData, signatureBytes)
+	default:
+		return nil, fmt.Errorf("Unknown signature type '%s'; must be '%s' or '%s'", signatureType, signature.TypePersonal, signature.TypeTypedData)
+	}
+	if err != nil {
+		return nil, err
+	}
+	response.Matches = response.Signer == expectedAddress
+
+	// Return response
+	return &response, nil
+
+}
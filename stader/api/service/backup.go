@@ -0,0 +1,260 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/backup"
+	"github.com/stader-labs/stader-node/shared/services/config"
+	"github.com/stader-labs/stader-node/shared/services/wallet"
+	lhkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/lighthouse"
+	lodestarkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/lodestar"
+	nmkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/nimbus"
+	prkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/prysm"
+	tkkeystore "github.com/stader-labs/stader-node/shared/services/wallet/keystore/teku"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	cfgtypes "github.com/stader-labs/stader-node/shared/types/config"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
+)
+
+// Archive paths
+const (
+	backupConfigDir          = "config"
+	backupSettingsFile       = "config/user-settings.yml"
+	backupValidatorsDir      = "validators"
+	backupSlashingProtection = "slashing-protection.json"
+)
+
+// Writes an encrypted backup of the wallet, validator keys, slashing protection history, settings and reward data to the backups folder
+func serviceBackup(c *cli.Context, passphrase string) (*api.ServiceBackupResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ServiceBackupResponse{
+		Warnings: []string{},
+	}
+
+	// Get the node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.NodeAddress = nodeAccount.Address
+	network := string(cfg.StaderNode.Network.Value.(cfgtypes.Network))
+	archive := backup.NewArchive(network, nodeAccount.Address.Hex())
+
+	// Wallet and node data
+	dataDir := filepath.Dir(cfg.StaderNode.GetWalletPath())
+	for _, name := range []string{
+		filepath.Base(cfg.StaderNode.GetWalletPath()),
+		filepath.Base(cfg.StaderNode.GetPasswordPath()),
+		wallet.ImportedValidatorKeysFolder,
+		filepath.Base(cfg.StaderNode.GetCustomKeyPath()),
+		filepath.Base(cfg.StaderNode.GetCustomKeyPasswordFilePath()),
+		config.GuardianFolder,
+		config.SpRewardsMerkleProofsFolder,
+	} {
+		if err := addPath(archive, name, filepath.Join(dataDir, name)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validator keystores of the active client, and the files shared by all clients such as the fee recipient
+	validatorsDir := cfg.StaderNode.GetValidatorKeychainPath()
+	entries, err := ioutil.ReadDir(validatorsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading the validator keys: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), "slashing-protection") {
+			continue
+		}
+		if _, err := archive.AddFile(backupValidatorsDir+"/"+entry.Name(), filepath.Join(validatorsDir, entry.Name())); err != nil {
+			return nil, err
+		}
+	}
+	cc, _ := cfg.GetSelectedConsensusClient()
+	keystoreDir, err := getKeystoreDir(cc)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := archive.AddDir(backupValidatorsDir+"/"+keystoreDir, filepath.Join(validatorsDir, keystoreDir), "*.lock"); err != nil {
+		return nil, err
+	}
+
+	// Slashing protection history; a backup without it is still useful, so only warn
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+	slashingProtection, err := validator.ExportSlashingProtection(cfg, d)
+	if err != nil {
+		response.Warnings = append(response.Warnings, fmt.Sprintf("The slashing protection history was not backed up: %s", err.Error()))
+	} else if err := archive.AddBytes(backupSlashingProtection, slashingProtection, 0600); err != nil {
+		return nil, err
+	}
+
+	// Settings
+	if _, err := archive.AddFile(backupSettingsFile, os.ExpandEnv(c.GlobalString("settings"))); err != nil {
+		return nil, err
+	}
+
+	// Encrypt the archive into the backups folder, removing the partial file if that fails
+	backupDir := getBackupDir(c)
+	if err := os.MkdirAll(backupDir, backup.ArchiveDirMode); err != nil {
+		return nil, fmt.Errorf("error creating the backups folder: %w", err)
+	}
+	response.File = fmt.Sprintf("stader-backup-%s-%s.age", network, archive.Manifest.CreatedAt.Format("20060102-150405"))
+	backupPath := filepath.Join(backupDir, response.File)
+	backupFile, err := os.OpenFile(backupPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error saving the backup: %w", err)
+	}
+	err = archive.Seal(backupFile, passphrase)
+	if closeErr := backupFile.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("error saving the backup: %w", closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(backupPath)
+		return nil, err
+	}
+	response.FileCount = len(archive.Manifest.Files)
+
+	// Return response
+	return &response, nil
+
+}
+
+// Verifies an encrypted backup in the backups folder and restores its contents
+func serviceRestore(c *cli.Context, file string, passphrase string, overwrite bool) (*api.ServiceRestoreResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ServiceRestoreResponse{}
+
+	// Decrypt and verify the archive
+	if file != filepath.Base(file) {
+		return nil, fmt.Errorf("Invalid backup file name %s", file)
+	}
+	backupFile, err := os.Open(filepath.Join(getBackupDir(c), file))
+	if err != nil {
+		return nil, fmt.Errorf("error reading the backup: %w", err)
+	}
+	archive, err := backup.OpenArchive(backupFile, passphrase)
+	backupFile.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Network = archive.Manifest.Network
+	response.NodeAddress = common.HexToAddress(archive.Manifest.NodeAddress)
+	response.CreatedAt = archive.Manifest.CreatedAt
+	response.FileCount = len(archive.Manifest.Files)
+
+	// Check it can be restored on this node
+	network := string(cfg.StaderNode.Network.Value.(cfgtypes.Network))
+	if archive.Manifest.Network != network {
+		return nil, fmt.Errorf("The backup is for the %s network but this node is configured for %s", archive.Manifest.Network, network)
+	}
+	walletPath := cfg.StaderNode.GetWalletPath()
+	if !archive.HasFile(filepath.Base(walletPath)) {
+		return nil, errors.New("The backup does not contain a wallet")
+	}
+	if _, err := os.Stat(walletPath); err == nil && !overwrite {
+		return nil, errors.New("This node already has a wallet; restoring the backup would replace it")
+	}
+
+	// Restore the node data and validator keys
+	dataDir := filepath.Dir(walletPath)
+	for _, file := range archive.Manifest.Files {
+		if file.Path == backupSlashingProtection || strings.HasPrefix(file.Path, backupConfigDir+"/") {
+			continue
+		}
+		if err := archive.ExtractFile(file.Path, filepath.Join(dataDir, filepath.FromSlash(file.Path))); err != nil {
+			return nil, err
+		}
+	}
+
+	// Leave the slashing protection history for the validator client to import before it starts
+	if archive.HasFile(backupSlashingProtection) {
+		target := filepath.Join(cfg.StaderNode.GetValidatorKeychainPath(), validator.SlashingProtectionImportFile)
+		if err := archive.ExtractFile(backupSlashingProtection, target); err != nil {
+			return nil, err
+		}
+		response.SlashingProtectionRestored = true
+	}
+
+	// Restore the settings in place so the file keeps its owner
+	if settings, exists := archive.GetFile(backupSettingsFile); exists {
+		if err := ioutil.WriteFile(os.ExpandEnv(c.GlobalString("settings")), settings, 0644); err != nil {
+			return nil, fmt.Errorf("error restoring the settings: %w", err)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Add a file or folder to a backup if it exists
+func addPath(archive *backup.Archive, archivePath string, sourcePath string) error {
+	info, err := os.Stat(sourcePath)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error reading %s: %w", sourcePath, err)
+	}
+	if info.IsDir() {
+		_, err = archive.AddDir(archivePath, sourcePath, "*.lock")
+	} else {
+		_, err = archive.AddFile(archivePath, sourcePath)
+	}
+	return err
+}
+
+// Get the folder under the validator keychain path that a client's keystores are saved in
+func getKeystoreDir(cc cfgtypes.ConsensusClient) (string, error) {
+	switch cc {
+	case cfgtypes.ConsensusClient_Lighthouse:
+		return lhkeystore.KeystoreDir, nil
+	case cfgtypes.ConsensusClient_Lodestar:
+		return lodestarkeystore.KeystoreDir, nil
+	case cfgtypes.ConsensusClient_Nimbus:
+		return nmkeystore.KeystoreDir, nil
+	case cfgtypes.ConsensusClient_Prysm:
+		return prkeystore.KeystoreDir, nil
+	case cfgtypes.ConsensusClient_Teku:
+		return tkkeystore.KeystoreDir, nil
+	default:
+		return "", fmt.Errorf("Unknown consensus client '%s'", cc)
+	}
+}
+
+// Get the folder backups are exchanged with the CLI through, next to the settings file
+func getBackupDir(c *cli.Context) string {
+	return filepath.Join(filepath.Dir(os.ExpandEnv(c.GlobalString("settings"))), backup.BackupFolder)
+}
@@ -70,6 +70,68 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "get-fee-estimate",
+				Aliases:   []string{"f"},
+				Usage:     "Estimate transaction fees from the Execution client's recent blocks",
+				UsageText: "stader-cli api service get-fee-estimate",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getFeeEstimate(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "backup",
+				Usage:     "Write an encrypted backup of the node's wallet, validator keys, slashing protection history, settings and reward data to the backups folder",
+				UsageText: "stader-cli api service backup passphrase",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					passphrase := c.Args().Get(0)
+
+					// Run
+					api.PrintResponse(serviceBackup(c, passphrase))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "restore",
+				Usage:     "Verify an encrypted backup in the backups folder and restore its contents",
+				UsageText: "stader-cli api service restore file passphrase overwrite",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					file := c.Args().Get(0)
+					passphrase := c.Args().Get(1)
+					overwrite, err := cliutils.ValidateBool("overwrite", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(serviceRestore(c, file, passphrase, overwrite))
+					return nil
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/gas/feehistory"
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+// Estimates fees from the recent blocks of the configured Execution clients
+func getFeeEstimate(c *cli.Context) (*api.FeeEstimateResponse, error) {
+
+	// Get services
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.FeeEstimateResponse{}
+
+	suggestion, err := feehistory.GetGasPrices(ec)
+	if err != nil {
+		return nil, err
+	}
+	response.BaseFee = suggestion.BaseFeeWei
+	response.Slow = getFeeTier(suggestion.Slow)
+	response.Standard = getFeeTier(suggestion.Standard)
+	response.Fast = getFeeTier(suggestion.Fast)
+
+	// Return response
+	return &response, nil
+
+}
+
+func getFeeTier(tier feehistory.FeeTier) api.FeeTier {
+	return api.FeeTier{
+		MaxFee:         tier.MaxFeeWei,
+		MaxPriorityFee: tier.MaxPriorityFeeWei,
+		Time:           tier.Time,
+	}
+}
@@ -0,0 +1,60 @@
+package tx
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+func broadcastTransaction(c *cli.Context, rawTx string) (*api.BroadcastTxResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.BroadcastTxResponse{}
+
+	// Check the signed transaction is for this network
+	txBytes, err := hexutil.Decode(rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding signed transaction: %w", err)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(txBytes); err != nil {
+		return nil, fmt.Errorf("Error parsing signed transaction: %w", err)
+	}
+	chainID := new(big.Int).SetUint64(uint64(cfg.StaderNode.GetChainID()))
+	if tx.ChainId().Cmp(chainID) != 0 {
+		return nil, fmt.Errorf("the transaction is for chain ID %s, but the node is on chain ID %s", tx.ChainId(), chainID)
+	}
+	if _, err := types.LatestSignerForChainID(chainID).Sender(tx); err != nil {
+		return nil, fmt.Errorf("the transaction isn't signed correctly: %w", err)
+	}
+
+	// Send it; the client manager records it in the journal
+	if err := ec.SendTransaction(context.Background(), tx); err != nil {
+		return nil, fmt.Errorf("error sending transaction: %w", err)
+	}
+	response.TxHash = tx.Hash()
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,166 @@
+package tx
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
+	"github.com/stader-labs/stader-node/shared/utils/api"
+	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
+)
+
+// Register subcommands
+func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
+	command.Subcommands = append(command.Subcommands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Manage the transactions sent by the node",
+		Subcommands: []cli.Command{
+
+			{
+				Name:      "list",
+				Aliases:   []string{"l"},
+				Usage:     "List the transactions in the node's journal",
+				UsageText: "stader-cli api tx list",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(listTransactions(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "status",
+				Aliases:   []string{"s"},
+				Usage:     "Get the status of a transaction and its replacements",
+				UsageText: "stader-cli api tx status tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getTransactionStatus(c, hash))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-speed-up",
+				Usage:     "Check whether a pending transaction can be sped up",
+				UsageText: "stader-cli api tx can-speed-up tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canReplaceTransaction(c, hash, txmanager.TxKind_SpeedUp))
+					return nil
+
+				},
+			},
+			{
+				Name:      "speed-up",
+				Usage:     "Resend a pending transaction with higher fees",
+				UsageText: "stader-cli api tx speed-up tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(replaceTransaction(c, hash, txmanager.TxKind_SpeedUp))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-cancel",
+				Usage:     "Check whether a pending transaction can be cancelled",
+				UsageText: "stader-cli api tx can-cancel tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canReplaceTransaction(c, hash, txmanager.TxKind_Cancel))
+					return nil
+
+				},
+			},
+			{
+				Name:      "cancel",
+				Usage:     "Cancel a pending transaction by replacing it with an empty transfer to the node",
+				UsageText: "stader-cli api tx cancel tx-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hash, err := cliutils.ValidateTxHash("tx-hash", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(replaceTransaction(c, hash, txmanager.TxKind_Cancel))
+					return nil
+
+				},
+			},
+			{
+				Name:      "broadcast",
+				Aliases:   []string{"b"},
+				Usage:     "Send a transaction that was signed offline",
+				UsageText: "stader-cli api tx broadcast signed-tx",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(broadcastTransaction(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,161 @@
+package tx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+func canReplaceTransaction(c *cli.Context, hash common.Hash, kind txmanager.TxKind) (*api.CanReplaceTxResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	journal, err := getUpdatedJournal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanReplaceTxResponse{}
+
+	entry, err := getJournalEntry(journal, hash)
+	if err != nil {
+		return nil, err
+	}
+	response.Transaction = entry
+	base, err := getReplacementBase(journal, entry)
+	if err != nil {
+		return nil, err
+	}
+	if base == nil {
+		response.NotPending = true
+		return &response, nil
+	}
+
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	if opts.From != entry.From {
+		return nil, fmt.Errorf("transaction %s was sent by %s, not the node wallet", hash.Hex(), entry.From.Hex())
+	}
+	response.MaxFee, response.MaxPriorityFee, err = txmanager.GetReplacementFees(ec, *base, opts.GasFeeCap, opts.GasTipCap)
+	if err != nil {
+		return nil, err
+	}
+	response.GasLimit = txmanager.CreateReplacement(entry, kind, w.GetChainID(), response.MaxFee, response.MaxPriorityFee).Gas()
+
+	// Update & return response
+	response.CanReplace = !response.NotPending
+	return &response, nil
+
+}
+
+func replaceTransaction(c *cli.Context, hash common.Hash, kind txmanager.TxKind) (*api.ReplaceTxResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	journal, err := getUpdatedJournal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ReplaceTxResponse{}
+
+	entry, err := getJournalEntry(journal, hash)
+	if err != nil {
+		return nil, err
+	}
+	base, err := getReplacementBase(journal, entry)
+	if err != nil {
+		return nil, err
+	}
+	if base == nil {
+		return nil, fmt.Errorf("transaction %s is no longer pending", hash.Hex())
+	}
+
+	// Get the fees and sign the replacement
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	if opts.From != entry.From {
+		return nil, fmt.Errorf("transaction %s was sent by %s, not the node wallet", hash.Hex(), entry.From.Hex())
+	}
+	response.MaxFee, response.MaxPriorityFee, err = txmanager.GetReplacementFees(ec, *base, opts.GasFeeCap, opts.GasTipCap)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := opts.Signer(opts.From, txmanager.CreateReplacement(entry, kind, w.GetChainID(), response.MaxFee, response.MaxPriorityFee))
+	if err != nil {
+		return nil, fmt.Errorf("error signing replacement transaction: %w", err)
+	}
+
+	// Send it; the client manager records it in the journal
+	if err := ec.SendTransaction(context.Background(), tx); err != nil {
+		return nil, fmt.Errorf("error sending replacement transaction: %w", err)
+	}
+	if err := journal.LinkReplacement(hash, tx.Hash(), kind); err != nil {
+		return nil, err
+	}
+	response.TxHash = tx.Hash()
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the transaction a replacement should be based on, with the highest fees of the pending transactions that
+// share its nonce since the replacement has to outbid all of them. Returns nil if none of them are pending.
+func getReplacementBase(journal *txmanager.Journal, entry txmanager.JournalEntry) (*txmanager.JournalEntry, error) {
+	group, err := journal.GetGroup(entry)
+	if err != nil {
+		return nil, err
+	}
+	var base *txmanager.JournalEntry
+	for _, other := range group {
+		if other.Status != txmanager.TxStatus_Pending {
+			continue
+		}
+		if base == nil {
+			base = &entry
+			base.MaxFee = other.MaxFee
+			base.MaxPriorityFee = other.MaxPriorityFee
+			continue
+		}
+		if other.MaxFee != nil && (base.MaxFee == nil || other.MaxFee.Cmp(base.MaxFee) > 0) {
+			base.MaxFee = other.MaxFee
+		}
+		if other.MaxPriorityFee != nil && (base.MaxPriorityFee == nil || other.MaxPriorityFee.Cmp(base.MaxPriorityFee) > 0) {
+			base.MaxPriorityFee = other.MaxPriorityFee
+		}
+	}
+	return base, nil
+}
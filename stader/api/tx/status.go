@@ -0,0 +1,80 @@
+package tx
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+func listTransactions(c *cli.Context) (*api.TxListResponse, error) {
+
+	// Get services
+	journal, err := getUpdatedJournal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TxListResponse{}
+	response.Transactions, err = journal.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func getTransactionStatus(c *cli.Context, hash common.Hash) (*api.TxStatusResponse, error) {
+
+	// Get services
+	journal, err := getUpdatedJournal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TxStatusResponse{}
+	response.Transaction, err = getJournalEntry(journal, hash)
+	if err != nil {
+		return nil, err
+	}
+	response.Group, err = journal.GetGroup(response.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the transaction journal, with the status of every pending transaction refreshed
+func getUpdatedJournal(c *cli.Context) (*txmanager.Journal, error) {
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	journal := ec.GetJournal()
+	if err := journal.Update(ec); err != nil {
+		return nil, fmt.Errorf("error updating the transaction journal: %w", err)
+	}
+	return journal, nil
+}
+
+func getJournalEntry(journal *txmanager.Journal, hash common.Hash) (txmanager.JournalEntry, error) {
+	entry, exists, err := journal.Get(hash)
+	if err != nil {
+		return txmanager.JournalEntry{}, err
+	}
+	if !exists {
+		return txmanager.JournalEntry{}, fmt.Errorf("transaction %s was not sent by this node, or is no longer in its journal", hash.Hex())
+	}
+	return entry, nil
+}
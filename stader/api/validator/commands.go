@@ -20,8 +20,13 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package validator
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/urfave/cli"
 
+	apitypes "github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/shared/types/eth2"
 	"github.com/stader-labs/stader-node/shared/utils/api"
 	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
 )
@@ -37,12 +42,12 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 			{
 				Name:      "can-deposit",
 				Usage:     "Check whether the node can make a deposit to create a validator",
-				UsageText: "stader-cli api validator can-deposit amount salt num-validators reload-keys",
+				UsageText: "stader-cli api validator can-deposit amount num-validators reload-keys imported-pubkeys",
 				Action: func(c *cli.Context) error {
 
 					//// Validate args
 					// Validate args
-					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+					if err := cliutils.ValidateArgCount(c, 4); err != nil {
 						return err
 					}
 					amountWei, err := cliutils.ValidateWeiAmount("deposit amount", c.Args().Get(0))
@@ -60,7 +65,12 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						return err
 					}
 
-					api.PrintResponse(canNodeDeposit(c, amountWei, numValidators, reloadKeys))
+					importedPubkeys, err := cliutils.ValidatePubkeys("imported-pubkeys", c.Args().Get(3))
+					if err != nil {
+						return err
+					}
+
+					api.PrintResponse(canNodeDeposit(c, amountWei, numValidators, reloadKeys, importedPubkeys))
 
 					return nil
 
@@ -70,11 +80,11 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				Name:      "deposit",
 				Aliases:   []string{"d"},
 				Usage:     "Make a deposit and create a validator",
-				UsageText: "stader-cli api validator deposit amount num-validators reload-keys",
+				UsageText: "stader-cli api validator deposit amount num-validators reload-keys imported-pubkeys",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
-					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+					if err := cliutils.ValidateArgCount(c, 4); err != nil {
 						return err
 					}
 					amountWei, err := cliutils.ValidateWeiAmount("deposit amount", c.Args().Get(0))
@@ -92,14 +102,83 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						return err
 					}
 
+					importedPubkeys, err := cliutils.ValidatePubkeys("imported-pubkeys", c.Args().Get(3))
+					if err != nil {
+						return err
+					}
+
 					// Run
-					response, err := nodeDeposit(c, amountWei, numValidators, reloadKeys)
+					response, err := nodeDeposit(c, amountWei, numValidators, reloadKeys, importedPubkeys)
 					api.PrintResponse(response, err)
 
 					return nil
 
 				},
 			},
+			{
+				Name:      "import-keystores",
+				Usage:     "Import EIP-2335 validator keystores generated outside of the node wallet",
+				UsageText: "stader-cli api validator import-keystores keystores-json reload-keys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					var keystores []apitypes.KeystoreImport
+					if err := json.Unmarshal([]byte(c.Args().Get(0)), &keystores); err != nil {
+						return fmt.Errorf("invalid keystores-json: %w", err)
+					}
+
+					reloadKeys, err := cliutils.ValidateBool("reload-keys", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(importKeystores(c, keystores, reloadKeys))
+					return nil
+
+				},
+			},
+			{
+				Name:      "export-deposit-data",
+				Usage:     "Get the deposit data of the node's validators in the staking-deposit-cli format",
+				UsageText: "stader-cli api validator export-deposit-data",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(exportDepositData(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "verify-deposit-data",
+				Usage:     "Verify deposit data in the staking-deposit-cli format against the network and the node's withdraw vaults",
+				UsageText: "stader-cli api validator verify-deposit-data deposit-data-json",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					var deposits []eth2.LaunchpadDepositData
+					if err := json.Unmarshal([]byte(c.Args().Get(0)), &deposits); err != nil {
+						return fmt.Errorf("invalid deposit-data-json: %w", err)
+					}
+
+					// Run
+					api.PrintResponse(verifyDepositData(c, deposits))
+					return nil
+
+				},
+			},
 			{
 				Name:      "can-exit-validator",
 				Usage:     "Can validator exit",
@@ -142,6 +221,52 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "can-remove-keys",
+				Usage:     "Check whether the keys of exited validators can be removed",
+				UsageText: "stader-cli api validator can-remove-keys validator-pub-keys all-withdrawn",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					validatorPubKeys, err := cliutils.ValidatePubkeys("validator-pub-keys", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					allWithdrawn, err := cliutils.ValidateBool("all-withdrawn", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canRemoveValidatorKeys(c, validatorPubKeys, allWithdrawn))
+					return nil
+
+				},
+			},
+			{
+				Name:      "remove-keys",
+				Usage:     "Export the slashing protection history, then remove the keys of exited validators from the wallet and validator client",
+				UsageText: "stader-cli api validator remove-keys validator-pub-keys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					validatorPubKeys, err := cliutils.ValidatePubkeys("validator-pub-keys", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(removeValidatorKeys(c, validatorPubKeys))
+					return nil
+
+				},
+			},
 			{
 				Name:      "can-send-cl-rewards",
 				Usage:     "Can send cl rewards of a validator to the operator claim vault",
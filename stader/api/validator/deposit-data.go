@@ -0,0 +1,234 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	cfgtypes "github.com/stader-labs/stader-node/shared/types/config"
+	"github.com/stader-labs/stader-node/shared/types/eth2"
+	"github.com/stader-labs/stader-node/shared/utils/stdr"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
+	"github.com/stader-labs/stader-node/stader-lib/node"
+	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+// Gets the 1 ETH pre-deposit and 31 ETH deposit data of the operator's validators in the staking-deposit-cli format
+func exportDepositData(c *cli.Context) (*api.ExportDepositDataResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	pnr, err := services.GetPermissionlessNodeRegistry(c)
+	if err != nil {
+		return nil, err
+	}
+	vfc, err := services.GetVaultFactory(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get eth2 config
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ExportDepositDataResponse{
+		PreDepositData: []eth2.LaunchpadDepositData{},
+		DepositData:    []eth2.LaunchpadDepositData{},
+		MissingKeys:    []stadertypes.ValidatorPubkey{},
+	}
+	networkName, exists := validator.GetDepositNetworkName(eth2Config.GenesisForkVersion)
+	if !exists {
+		networkName = string(cfg.StaderNode.Network.Value.(cfgtypes.Network))
+	}
+	response.NetworkName = networkName
+
+	// Get the operator's validators
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	operatorId, err := node.GetOperatorId(pnr, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if operatorId.Cmp(big.NewInt(0)) == 0 {
+		return nil, errors.New("The node is not registered as a Stader operator")
+	}
+	validators, pubkeys, err := stdr.GetAllValidatorsRegisteredWithOperator(pnr, nil, operatorId, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pubkey := range pubkeys {
+
+		// Get a signer for the validator key
+		signer, err := services.GetValidatorSigner(c, pubkey)
+		if err != nil {
+			response.MissingKeys = append(response.MissingKeys, pubkey)
+			continue
+		}
+
+		// Get the withdrawal credentials of its vault
+		withdrawCredentials, err := node.GetValidatorWithdrawalCredential(vfc, validators[pubkey].WithdrawVaultAddress, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// Sign the pre-deposit and the deposit
+		preDepositData, err := getLaunchpadDepositData(signer, withdrawCredentials, eth2Config, networkName, 1000000000)
+		if err != nil {
+			return nil, fmt.Errorf("Could not get the pre-deposit data of validator %s: %w", pubkey.Hex(), err)
+		}
+		response.PreDepositData = append(response.PreDepositData, preDepositData)
+		depositData, err := getLaunchpadDepositData(signer, withdrawCredentials, eth2Config, networkName, 31000000000)
+		if err != nil {
+			return nil, fmt.Errorf("Could not get the deposit data of validator %s: %w", pubkey.Hex(), err)
+		}
+		response.DepositData = append(response.DepositData, depositData)
+
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Checks deposit data in the staking-deposit-cli format against the network and the operator's withdraw vaults.
+// Keys that aren't registered yet are checked against the vaults they would get if they were deposited next, in order.
+func verifyDepositData(c *cli.Context, deposits []eth2.LaunchpadDepositData) (*api.VerifyDepositDataResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	pnr, err := services.GetPermissionlessNodeRegistry(c)
+	if err != nil {
+		return nil, err
+	}
+	vfc, err := services.GetVaultFactory(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get eth2 config
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.VerifyDepositDataResponse{
+		Results: make([]api.DepositDataVerification, 0, len(deposits)),
+	}
+
+	// Get the operator's validators
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	operatorId, err := node.GetOperatorId(pnr, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if operatorId.Cmp(big.NewInt(0)) == 0 {
+		return nil, errors.New("The node is not registered as a Stader operator")
+	}
+	validators, _, err := stdr.GetAllValidatorsRegisteredWithOperator(pnr, nil, operatorId, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	nextKeyIndex, err := node.GetTotalValidatorKeys(pnr, operatorId, nil)
+	if err != nil {
+		return nil, err
+	}
+	unregisteredVaults := map[stadertypes.ValidatorPubkey]common.Address{}
+
+	for _, deposit := range deposits {
+		result := api.DepositDataVerification{
+			Pubkey: strings.ToLower(strings.TrimPrefix(deposit.PublicKey, "0x")),
+			Amount: deposit.Amount,
+		}
+
+		// Check the deposit itself
+		depositData, err := validator.VerifyLaunchpadDepositData(deposit, eth2Config.GenesisForkVersion)
+		if err != nil {
+			result.Error = err.Error()
+			response.Results = append(response.Results, result)
+			continue
+		}
+
+		// Get the vault the validator withdraws to
+		pubkey := stadertypes.BytesToValidatorPubkey(depositData.PublicKey)
+		if validatorInfo, exists := validators[pubkey]; exists {
+			result.Registered = true
+			result.WithdrawVaultAddress = validatorInfo.WithdrawVaultAddress
+		} else if vault, exists := unregisteredVaults[pubkey]; exists {
+			result.WithdrawVaultAddress = vault
+		} else {
+			result.WithdrawVaultAddress, err = node.ComputeWithdrawVaultAddress(vfc, 1, operatorId, nextKeyIndex, nil)
+			if err != nil {
+				return nil, err
+			}
+			unregisteredVaults[pubkey] = result.WithdrawVaultAddress
+			nextKeyIndex = big.NewInt(0).Add(nextKeyIndex, big.NewInt(1))
+		}
+
+		// Check the withdrawal credentials
+		withdrawCredentials, err := node.GetValidatorWithdrawalCredential(vfc, result.WithdrawVaultAddress, nil)
+		if err != nil {
+			return nil, err
+		}
+		if common.BytesToHash(depositData.WithdrawalCredentials) != withdrawCredentials {
+			result.Error = fmt.Sprintf("the withdrawal credentials %s are not those of the withdraw vault %s, which are %s", deposit.WithdrawalCredentials, result.WithdrawVaultAddress.Hex(), withdrawCredentials.Hex())
+		} else {
+			result.Valid = true
+		}
+		response.Results = append(response.Results, result)
+
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Sign a deposit and convert it to the staking-deposit-cli format
+func getLaunchpadDepositData(signer validator.Signer, withdrawCredentials common.Hash, eth2Config beacon.Eth2Config, networkName string, amount uint64) (eth2.LaunchpadDepositData, error) {
+	depositData, _, err := validator.GetDepositData(signer, withdrawCredentials, eth2Config, amount)
+	if err != nil {
+		return eth2.LaunchpadDepositData{}, err
+	}
+	return validator.GetLaunchpadDepositData(depositData, eth2Config.GenesisForkVersion, networkName)
+}
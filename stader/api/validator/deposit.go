@@ -7,16 +7,22 @@ import (
 	"github.com/stader-labs/stader-node/stader-lib/tokens"
 	stadertypes "github.com/stader-labs/stader-node/stader-lib/types"
 	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	_ "golang.org/x/sync/errgroup"
 	"math/big"
 
 	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/beacon"
+	"github.com/stader-labs/stader-node/shared/services/wallet"
 	"github.com/stader-labs/stader-node/shared/types/api"
 	"github.com/stader-labs/stader-node/shared/utils/eth1"
 	"github.com/stader-labs/stader-node/shared/utils/validator"
 )
 
-func canNodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, reloadKeys bool) (*api.CanNodeDepositResponse, error) {
+func canNodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, reloadKeys bool, importedPubkeys []stadertypes.ValidatorPubkey) (*api.CanNodeDepositResponse, error) {
+	if len(importedPubkeys) > 0 && int64(len(importedPubkeys)) != numValidators.Int64() {
+		return nil, fmt.Errorf("%d imported keys were given to create %s validators", len(importedPubkeys), numValidators.String())
+	}
 	if err := services.RequireNodeWallet(c); err != nil {
 		return nil, err
 	}
@@ -148,12 +154,17 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int,
 	}
 
 	for i := int64(0); i < numValidators.Int64(); i++ {
-		// Create and save a new validator key
-		validatorKey, err := w.GetValidatorKeyAt(walletIndex)
+		// Get the imported key, or the next validator key of the wallet
+		var validatorKey *eth2types.BLSPrivateKey
+		if len(importedPubkeys) > 0 {
+			validatorKey, err = getImportedDepositKey(w, bc, importedPubkeys[i])
+		} else {
+			validatorKey, err = w.GetValidatorKeyAt(walletIndex)
+			walletIndex++
+		}
 		if err != nil {
 			return nil, err
 		}
-		walletIndex++
 
 		rewardWithdrawVault, err := node.ComputeWithdrawVaultAddress(vfc, 1, operatorId, newValidatorKey, nil)
 		if err != nil {
@@ -165,14 +176,15 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int,
 			return nil, err
 		}
 
-		// Get validator deposit data for 1 eth
-		preDepositData, _, err := validator.GetDepositData(validatorKey, withdrawCredentials, eth2Config, 1000000000)
+		// Get validator deposit data for 1 eth; the key may not have been stored yet, so it's signed locally
+		signer := validator.NewLocalSigner(validatorKey)
+		preDepositData, _, err := validator.GetDepositData(signer, withdrawCredentials, eth2Config, 1000000000)
 		if err != nil {
 			return nil, err
 		}
 		preDepositSignature := stadertypes.BytesToValidatorSignature(preDepositData.Signature)
 
-		depositData, _, err := validator.GetDepositData(validatorKey, withdrawCredentials, eth2Config, 31000000000)
+		depositData, _, err := validator.GetDepositData(signer, withdrawCredentials, eth2Config, 31000000000)
 		if err != nil {
 			return nil, err
 		}
@@ -204,7 +216,10 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int,
 	return &canNodeDepositResponse, nil
 }
 
-func nodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, reloadKeys bool) (*api.NodeDepositResponse, error) {
+func nodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, reloadKeys bool, importedPubkeys []stadertypes.ValidatorPubkey) (*api.NodeDepositResponse, error) {
+	if len(importedPubkeys) > 0 && int64(len(importedPubkeys)) != numValidators.Int64() {
+		return nil, fmt.Errorf("%d imported keys were given to create %s validators", len(importedPubkeys), numValidators.String())
+	}
 
 	cfg, err := services.GetConfig(c)
 	if err != nil {
@@ -274,8 +289,13 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, rel
 	newValidatorKey := validatorKeyCount
 
 	for i := int64(0); i < numValidators.Int64(); i++ {
-		// Create and save a new validator key
-		validatorKey, err := w.CreateValidatorKey()
+		// Use the imported key, or create and save a new validator key
+		var validatorKey *eth2types.BLSPrivateKey
+		if len(importedPubkeys) > 0 {
+			validatorKey, err = getImportedDepositKey(w, bc, importedPubkeys[i])
+		} else {
+			validatorKey, err = w.CreateValidatorKey()
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -291,7 +311,11 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, rel
 		}
 
 		// Get validator deposit data for 1 eth
-		preDepositData, _, err := validator.GetDepositData(validatorKey, withdrawCredentials, eth2Config, 1000000000)
+		signer, err := services.GetValidatorSigner(c, stadertypes.BytesToValidatorPubkey(validatorKey.PublicKey().Marshal()))
+		if err != nil {
+			return nil, err
+		}
+		preDepositData, _, err := validator.GetDepositData(signer, withdrawCredentials, eth2Config, 1000000000)
 		if err != nil {
 			return nil, err
 		}
@@ -299,7 +323,7 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, rel
 
 		pubKey := stadertypes.BytesToValidatorPubkey(preDepositData.PublicKey)
 
-		depositData, _, err := validator.GetDepositData(validatorKey, withdrawCredentials, eth2Config, 31000000000)
+		depositData, _, err := validator.GetDepositData(signer, withdrawCredentials, eth2Config, 31000000000)
 		if err != nil {
 			return nil, err
 		}
@@ -326,7 +350,7 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, rel
 		newValidatorKey = validatorKeyCount.Add(validatorKeyCount, big.NewInt(1))
 	}
 
-	if reloadKeys {
+	if reloadKeys && !w.LoadsKeysLive() {
 		d, err := services.GetDocker(c)
 		if err != nil {
 			return nil, err
@@ -361,3 +385,19 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, numValidators *big.Int, rel
 	return &response, nil
 
 }
+
+// Get an imported validator key to deposit with, making sure it isn't in use on the Beacon chain
+func getImportedDepositKey(w *wallet.Wallet, bc beacon.Client, pubkey stadertypes.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+	validatorKey, err := w.GetImportedValidatorKey(pubkey)
+	if err != nil {
+		return nil, fmt.Errorf("%w; import its keystore with `stader-cli validator import-keystores` first", err)
+	}
+	status, err := bc.GetValidatorStatus(pubkey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for existing validator status: %w\nYour funds have not been deposited for your own safety.", err)
+	}
+	if status.Exists {
+		return nil, fmt.Errorf("The imported key %s is already in use by validator %d on the Beacon chain and can't be deposited again.", pubkey.Hex(), status.Index)
+	}
+	return validatorKey, nil
+}
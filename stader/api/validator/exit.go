@@ -2,12 +2,13 @@ package validator
 
 import (
 	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/beacon"
 	"github.com/stader-labs/stader-node/shared/types/api"
 	"github.com/stader-labs/stader-node/shared/utils/eth2"
+	"github.com/stader-labs/stader-node/shared/utils/stdr"
 	"github.com/stader-labs/stader-node/shared/utils/validator"
 	"github.com/stader-labs/stader-node/stader-lib/types"
 	"github.com/urfave/cli"
-	eth2types "github.com/wealdtech/go-eth2-types/v2"
 )
 
 func canExitValidator(c *cli.Context, validatorPubKey types.ValidatorPubkey) (*api.CanExitValidatorResponse, error) {
@@ -23,57 +24,17 @@ func canExitValidator(c *cli.Context, validatorPubKey types.ValidatorPubkey) (*a
 	if err != nil {
 		return nil, err
 	}
-	w, err := services.GetWallet(c)
-	if err != nil {
-		return nil, err
-	}
-	// Response
-	response := api.CanExitValidatorResponse{}
-
 	// check if the validator is key is available to sign the exit message
-	_, err = w.GetValidatorKeyByPubkey(validatorPubKey)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := bc.GetValidatorStatus(validatorPubKey, nil)
+	_, err = services.GetValidatorSigner(c, validatorPubKey)
 	if err != nil {
 		return nil, err
 	}
-	if !res.Exists {
-		response.ValidatorNotRegistered = true
-		return &response, nil
-	}
-	if !eth2.IsValidatorActive(res) {
-		response.ValidatorNotActive = true
-		return &response, nil
-	}
 
-	if eth2.IsValidatorExiting(res) {
-		response.ValidatorExiting = true
-		return &response, nil
-	}
-
-	beaconHead, err := bc.GetBeaconHead()
-	if err != nil {
-		return nil, err
-	}
-	currentEpoch := beaconHead.Epoch
-
-	if res.ActivationEpoch+256 > currentEpoch {
-		response.ValidatorTooYoung = true
-		return &response, nil
-	}
-
-	return &response, nil
+	return validator.GetExitEligibility(bc, validatorPubKey)
 }
 
 func exitValidator(c *cli.Context, validatorPubKey types.ValidatorPubkey) (*api.ExitValidatorResponse, error) {
 
-	w, err := services.GetWallet(c)
-	if err != nil {
-		return nil, err
-	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -86,43 +47,37 @@ func exitValidator(c *cli.Context, validatorPubKey types.ValidatorPubkey) (*api.
 	// Response
 	response := api.ExitValidatorResponse{}
 
-	// Get beacon head
-	head, err := bc.GetBeaconHead()
+	signer, err := services.GetValidatorSigner(c, validatorPubKey)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get voluntary exit signature domain
-	signatureDomain, err := bc.GetExitDomainData(eth2types.DomainVoluntaryExit[:])
-	if err != nil {
-		return nil, err
-	}
-
-	// Get validator index
-	validatorIndex, err := bc.GetValidatorIndex(validatorPubKey)
-	if err != nil {
-		return nil, err
-	}
-
-	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubKey)
-	if err != nil {
-		return nil, err
-	}
-
-	// Get signed voluntary exit message
-	signature, _, err := validator.GetSignedExitMessage(validatorKey, validatorIndex, head.Epoch, signatureDomain)
-	if err != nil {
-		return nil, err
-	}
-
-	// Broadcast voluntary exit message
-	if err := bc.ExitValidator(validatorIndex, head.Epoch, signature); err != nil {
+	// Sign and broadcast the voluntary exit message
+	if err := validator.ExitValidator(bc, signer, validatorPubKey); err != nil {
 		return nil, err
 	}
 
 	response.BeaconChainUrl = cfg.StaderNode.GetBeaconChainUrl()
 
+	// The exit has been broadcast, so failing to estimate when the funds arrive isn't an error
+	response.WithdrawalEstimate = estimateWithdrawal(bc, validatorPubKey)
+
 	// Return response
 	return &response, nil
 
 }
+
+// Estimate when an exiting validator's funds will reach its withdraw vault, or nil if it can't be estimated
+func estimateWithdrawal(bc beacon.Client, validatorPubKey types.ValidatorPubkey) *stdr.WithdrawalEstimate {
+	validatorStatus, err := bc.GetValidatorStatus(validatorPubKey, nil)
+	if err != nil {
+		return nil
+	}
+	exitQueue, err := eth2.GetExitQueue(bc)
+	if err != nil {
+		return nil
+	}
+	withdrawalSweep, _ := eth2.GetWithdrawalSweep(bc, exitQueue.ValidatorCount)
+	estimate := stdr.EstimateWithdrawal(validatorStatus, exitQueue, withdrawalSweep)
+	return &estimate
+}
@@ -0,0 +1,75 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
+)
+
+func importKeystores(c *cli.Context, keystores []api.KeystoreImport, reloadKeys bool) (*api.ImportKeystoresResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ImportKeystoresResponse{
+		ImportedKeys: make([]api.ImportedKeystore, 0, len(keystores)),
+	}
+
+	for i, keystore := range keystores {
+
+		// Import the key into the wallet and the validator keystores
+		validatorKey, err := w.ImportValidatorKey(keystore.Keystore, keystore.Password)
+		if err != nil {
+			return nil, fmt.Errorf("Could not import keystore %d: %w", i+1, err)
+		}
+		importedKey := api.ImportedKeystore{
+			ValidatorPubKey: validatorKey.PublicKey,
+		}
+
+		// Check whether it has already been deposited
+		status, err := bc.GetValidatorStatus(validatorKey.PublicKey, nil)
+		if err != nil {
+			return nil, fmt.Errorf("Error checking validator %s status: %w", validatorKey.PublicKey.Hex(), err)
+		}
+		importedKey.ValidatorExists = status.Exists
+
+		response.ImportedKeys = append(response.ImportedKeys, importedKey)
+
+	}
+
+	if reloadKeys && len(keystores) > 0 && !w.LoadsKeysLive() {
+		cfg, err := services.GetConfig(c)
+		if err != nil {
+			return nil, err
+		}
+		d, err := services.GetDocker(c)
+		if err != nil {
+			return nil, err
+		}
+
+		// Restart the validator container so it loads the new keys
+		err = validator.RestartValidator(cfg, bc, nil, d)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,192 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/wallet"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
+	"github.com/stader-labs/stader-node/stader-lib/types"
+)
+
+func canRemoveValidatorKeys(c *cli.Context, validatorPubkeys []types.ValidatorPubkey, allWithdrawn bool) (*api.CanRemoveValidatorKeysResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the keys to check
+	if allWithdrawn {
+		validatorPubkeys, err = getWalletValidatorPubkeys(w)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := checkWalletValidatorKeys(w, validatorPubkeys); err != nil {
+		return nil, err
+	}
+
+	// Check them
+	response, err := validator.GetKeyRemovalEligibility(bc, validatorPubkeys)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only list the keys that can be removed when looking for all of them
+	if allWithdrawn {
+		removableKeys := []api.ValidatorKeyRemoval{}
+		for _, key := range response.Keys {
+			if key.Removable {
+				removableKeys = append(removableKeys, key)
+			}
+		}
+		response.Keys = removableKeys
+	}
+
+	// Return response
+	return response, nil
+
+}
+
+func removeValidatorKeys(c *cli.Context, validatorPubkeys []types.ValidatorPubkey) (*api.RemoveValidatorKeysResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RemoveValidatorKeysResponse{
+		RemovedKeys: []types.ValidatorPubkey{},
+	}
+
+	// Check the keys can be removed
+	if len(validatorPubkeys) == 0 {
+		return nil, errors.New("No validator keys were given to remove")
+	}
+	if err := checkWalletValidatorKeys(w, validatorPubkeys); err != nil {
+		return nil, err
+	}
+	eligibility, err := validator.GetKeyRemovalEligibility(bc, validatorPubkeys)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range eligibility.Keys {
+		if !key.Removable {
+			return nil, fmt.Errorf("The key of validator %s can't be removed until the validator has exited and reached its withdrawable epoch", key.Pubkey.Hex())
+		}
+	}
+
+	// Export the slashing protection history first; the keys aren't removed without it.
+	// A live keystore exports the history as it deletes the keys from the Validator client, so whatever it returns is
+	// saved and sent back before any error is, since some keys may already be gone.
+	liveKeys := w.LoadsKeysLive()
+	var slashingProtection []byte
+	var exportErr error
+	if liveKeys {
+		slashingProtection, exportErr = w.DeleteLiveValidatorKeys(validatorPubkeys)
+	} else {
+		slashingProtection, exportErr = validator.ExportSlashingProtection(cfg, d)
+	}
+	if exportErr == nil || len(slashingProtection) > 0 {
+		response.SlashingProtection = string(slashingProtection)
+		response.SlashingProtectionFile = validator.GetRemovedSlashingProtectionFile("", time.Now())
+		if err := ioutil.WriteFile(filepath.Join(cfg.StaderNode.GetValidatorKeychainPath(), response.SlashingProtectionFile), slashingProtection, wallet.FileMode); err != nil {
+			if liveKeys {
+				return &response, fmt.Errorf("The keys may have been deleted from the Validator client, but their slashing protection history could not be saved on the node; it is included in this response: %w", err)
+			}
+			return nil, fmt.Errorf("Could not save the slashing protection history, so no keys were removed: %w", err)
+		}
+		response.SlashingProtectionSaved = true
+	}
+	if exportErr != nil {
+		if liveKeys {
+			return &response, fmt.Errorf("Some keys may have been deleted from the Validator client but none were removed from the node wallet; any slashing protection history it returned is included in this response: %w", exportErr)
+		}
+		return nil, fmt.Errorf("Could not export the slashing protection history, so no keys were removed: %w", exportErr)
+	}
+
+	// Remove the keys
+	for _, pubkey := range validatorPubkeys {
+		if err := w.RemoveValidatorKey(pubkey); err != nil {
+			return nil, err
+		}
+		response.RemovedKeys = append(response.RemovedKeys, pubkey)
+	}
+
+	// Restart the validator client so it stops loading the removed keys
+	if !w.LoadsKeysLive() {
+		if err := validator.RestartValidator(cfg, bc, nil, d); err != nil {
+			return nil, fmt.Errorf("The validator keys were removed, but the validator client could not be restarted: %w", err)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the public keys of the validator keys the wallet holds
+func getWalletValidatorPubkeys(w *wallet.Wallet) ([]types.ValidatorPubkey, error) {
+	keyCount, err := w.GetValidatorKeyCount()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := w.GetValidatorKeys(0, keyCount)
+	if err != nil {
+		return nil, err
+	}
+	importedKeys, err := w.GetImportedValidatorKeys()
+	if err != nil {
+		return nil, err
+	}
+	pubkeys := []types.ValidatorPubkey{}
+	for _, key := range append(keys, importedKeys...) {
+		pubkeys = append(pubkeys, key.PublicKey)
+	}
+	return pubkeys, nil
+}
+
+// Check that the wallet holds validator keys and they haven't been removed yet
+func checkWalletValidatorKeys(w *wallet.Wallet, validatorPubkeys []types.ValidatorPubkey) error {
+	for _, pubkey := range validatorPubkeys {
+		if w.IsRemovedValidatorKey(pubkey) {
+			return fmt.Errorf("The key of validator %s was already removed", pubkey.Hex())
+		}
+		if _, err := w.GetValidatorKeyByPubkey(pubkey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
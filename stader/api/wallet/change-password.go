@@ -0,0 +1,52 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
+)
+
+func changePassword(c *cli.Context, currentPassword string, newPassword string) (*api.ChangePasswordResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ChangePasswordResponse{}
+
+	// Re-encrypt the wallet and every validator keystore
+	if err := w.ChangePassword(currentPassword, newPassword); err != nil {
+		return nil, err
+	}
+
+	// Restart the validator client once so it loads the re-encrypted keystores
+	if err := validator.RestartValidator(cfg, bc, nil, d); err != nil {
+		return nil, fmt.Errorf("The password was changed, but the validator client could not be restarted: %w", err)
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -26,6 +26,22 @@ import (
 	cliutils "github.com/stader-labs/stader-node/shared/utils/cli"
 )
 
+// Validator key recovery flags
+var (
+	keyStartIndexFlag = cli.UintFlag{
+		Name:  "key-start-index",
+		Usage: "The first index to search for validator keys at",
+	}
+	keyEndIndexFlag = cli.UintFlag{
+		Name:  "key-end-index",
+		Usage: "The index to stop searching for validator keys at (defaults to 2000 past the start index)",
+	}
+	validatorKeyPathFlag = cli.StringFlag{
+		Name:  "validator-key-path",
+		Usage: "The derivation path to search for validator keys first, with %d in place of the index (defaults to \"m/12381/3600/%d/0/0\")",
+	}
+)
+
 // Register subcommands
 func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 	command.Subcommands = append(command.Subcommands, cli.Command{
@@ -76,6 +92,29 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "change-password",
+				Usage:     "Change the node wallet password and re-encrypt the wallet and validator keystores",
+				UsageText: "stader-cli api wallet change-password current-password new-password",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					currentPassword := c.Args().Get(0)
+					newPassword, err := cliutils.ValidateNodePassword("new wallet password", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(changePassword(c, currentPassword, newPassword))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "recover",
 				Aliases:   []string{"r"},
@@ -95,6 +134,9 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						Usage: "Specify the index to use with the derivation path when recovering your wallet",
 						Value: 0,
 					},
+					keyStartIndexFlag,
+					keyEndIndexFlag,
+					validatorKeyPathFlag,
 				},
 				Action: func(c *cli.Context) error {
 
@@ -107,6 +149,13 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						return err
 					}
 
+					// Validate flags
+					if c.String("validator-key-path") != "" {
+						if _, err := cliutils.ValidateValidatorKeyPath("validator-key-path", c.String("validator-key-path")); err != nil {
+							return err
+						}
+					}
+
 					// Run
 					api.PrintResponse(recoverWallet(c, mnemonic))
 					return nil
@@ -114,6 +163,40 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "recover-validator-keys",
+				Usage:     "Search the node wallet for the validator keys registered with the operator and recover the ones that are found",
+				UsageText: "stader-cli api wallet recover-validator-keys",
+				Flags: []cli.Flag{
+					keyStartIndexFlag,
+					keyEndIndexFlag,
+					validatorKeyPathFlag,
+					cli.BoolFlag{
+						Name:  "test-only, t",
+						Usage: "Only report which keys would be recovered, without saving them",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("validator-key-path") != "" {
+						if _, err := cliutils.ValidateValidatorKeyPath("validator-key-path", c.String("validator-key-path")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					api.PrintResponse(recoverValidatorKeys(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "rebuild-validator-keys",
 				Aliases:   []string{"rvk"},
@@ -158,6 +241,28 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "watch",
+				Usage:     "Initialize a watch-only node wallet that only knows the operator address",
+				UsageText: "stader-cli api wallet watch address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					address, err := cliutils.ValidateAddress("address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(watchWallet(c, address))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "export",
 				Aliases:   []string{"e"},
@@ -177,6 +282,46 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "verify-mnemonic",
+				Usage:     "Check whether a mnemonic phrase is the one the node wallet was created from",
+				UsageText: "stader-cli api wallet verify-mnemonic mnemonic",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					mnemonic, err := cliutils.ValidateWalletMnemonic("mnemonic", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(verifyMnemonic(c, mnemonic))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "sign-tx",
+				Usage:     "Sign a transaction that was exported for offline signing",
+				UsageText: "stader-cli api wallet sign-tx unsigned-tx-json",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(signTransaction(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "purge",
 				Usage:     "Deletes your node wallet, your validator keys, and restarts your Validator Client while preserving your chain data. WARNING: Only use this if you want to stop validating with this machine!",
@@ -29,6 +29,7 @@ import (
 	"github.com/stader-labs/stader-node/shared/services"
 	"github.com/stader-labs/stader-node/shared/services/wallet"
 	"github.com/stader-labs/stader-node/shared/types/api"
+	"github.com/stader-labs/stader-node/shared/utils/validator"
 	walletutils "github.com/stader-labs/stader-node/shared/utils/wallet"
 )
 
@@ -109,10 +110,13 @@ func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse,
 
 		response.OperatorExists = operatorExists
 		if operatorExists {
-			response.ValidatorKeys, err = walletutils.RecoverStaderKeys(pnr, nodeAccount.Address, w, false)
+			response.RecoveryReport, err = walletutils.RecoverStaderKeys(pnr, nodeAccount.Address, w, getRecoveryOptions(c))
 			if err != nil {
 				return nil, err
 			}
+			for _, key := range response.RecoveryReport.RecoveredKeys {
+				response.ValidatorKeys = append(response.ValidatorKeys, key.Pubkey)
+			}
 		}
 	}
 
@@ -126,6 +130,90 @@ func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse,
 
 }
 
+func recoverValidatorKeys(c *cli.Context) (*api.RecoverValidatorKeysResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	pnr, err := services.GetPermissionlessNodeRegistry(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RecoverValidatorKeysResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the operator is registered
+	operatorExists, err := pnr.PermissionlessNodeRegistry.IsExistingOperator(nil, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	response.OperatorExists = operatorExists
+	if !operatorExists {
+		return &response, nil
+	}
+
+	// Recover the keys
+	response.RecoveryReport, err = walletutils.RecoverStaderKeys(pnr, nodeAccount.Address, w, getRecoveryOptions(c))
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Bool("test-only") || len(response.RecoveryReport.RecoveredKeys) == 0 {
+		return &response, nil
+	}
+
+	// Save wallet
+	if err := w.Save(); err != nil {
+		return nil, err
+	}
+
+	// Restart the validator client so it loads the recovered keys, unless they were loaded live
+	if w.LoadsKeysLive() {
+		return &response, nil
+	}
+	if err := validator.RestartValidator(cfg, bc, nil, d); err != nil {
+		return nil, fmt.Errorf("The validator keys were recovered, but the validator client could not be restarted: %w", err)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the validator key search range and path from the command flags
+func getRecoveryOptions(c *cli.Context) walletutils.RecoveryOptions {
+	return walletutils.RecoveryOptions{
+		StartIndex:     c.Uint("key-start-index"),
+		EndIndex:       c.Uint("key-end-index"),
+		DerivationPath: c.String("validator-key-path"),
+		TestOnly:       c.Bool("test-only"),
+	}
+}
+
 func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Address) (*api.SearchAndRecoverWalletResponse, error) {
 
 	// Get services
@@ -0,0 +1,71 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/services/txmanager"
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+func signTransaction(c *cli.Context, unsignedTxJson string) (*api.SignTxResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SignTxResponse{}
+
+	// Check the transaction is for this wallet
+	var unsignedTx txmanager.UnsignedTx
+	if err := json.Unmarshal([]byte(unsignedTxJson), &unsignedTx); err != nil {
+		return nil, fmt.Errorf("Error parsing unsigned transaction: %w", err)
+	}
+	tx, err := unsignedTx.Transaction()
+	if err != nil {
+		return nil, err
+	}
+	if tx.ChainId().Cmp(w.GetChainID()) != 0 {
+		return nil, fmt.Errorf("the transaction is for chain ID %s, but the node wallet is configured for chain ID %s", tx.ChainId(), w.GetChainID())
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if unsignedTx.From != nodeAccount.Address {
+		return nil, fmt.Errorf("the transaction is from %s, but the node wallet's account is %s", unsignedTx.From.Hex(), nodeAccount.Address.Hex())
+	}
+
+	// Sign it
+	txBytes, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("Error serializing transaction: %w", err)
+	}
+	signedBytes, err := w.Sign(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(signedBytes); err != nil {
+		return nil, fmt.Errorf("Error parsing signed transaction: %w", err)
+	}
+	response.SignedTx = txmanager.SignedTx{
+		UnsignedTx: unsignedTx,
+		Hash:       signedTx.Hash(),
+		RawTx:      signedBytes,
+	}
+
+	// Return response
+	return &response, nil
+
+}
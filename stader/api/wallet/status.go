@@ -51,9 +51,10 @@ func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
 	// Get wallet status
 	response.PasswordSet = pm.IsPasswordSet()
 	response.WalletInitialized = w.IsInitialized()
+	response.WatchOnly = w.IsWatchOnly()
 
 	// Get accounts if initialized
-	if response.WalletInitialized {
+	if response.WalletInitialized || response.WatchOnly {
 
 		// Get node account
 		nodeAccount, err := w.GetNodeAccount()
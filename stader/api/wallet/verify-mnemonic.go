@@ -0,0 +1,34 @@
+package wallet
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+func verifyMnemonic(c *cli.Context, mnemonic string) (*api.VerifyMnemonicResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.VerifyMnemonicResponse{}
+
+	// Compare the mnemonic with the wallet
+	matches, err := w.MatchesMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	response.Matches = matches
+
+	// Return response
+	return &response, nil
+
+}
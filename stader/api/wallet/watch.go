@@ -0,0 +1,38 @@
+package wallet
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/stader-labs/stader-node/shared/services"
+	"github.com/stader-labs/stader-node/shared/types/api"
+)
+
+func watchWallet(c *cli.Context, address common.Address) (*api.WatchWalletResponse, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.WatchWalletResponse{}
+
+	// Check if wallet is already initialized
+	if w.IsInitialized() || w.IsWatchOnly() {
+		return nil, errors.New("The wallet is already initialized")
+	}
+
+	// Save the operator address
+	if err := w.InitializeWatchOnly(address); err != nil {
+		return nil, err
+	}
+	response.AccountAddress = address
+
+	// Return response
+	return &response, nil
+
+}
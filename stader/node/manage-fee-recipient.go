@@ -27,7 +27,6 @@ import (
 	"github.com/stader-labs/stader-node/stader-lib/stader"
 
 	"github.com/docker/docker/client"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 
 	"github.com/stader-labs/stader-node/shared/services"
@@ -154,20 +153,7 @@ func (m *manageFeeRecipient) run() error {
 
 	updatable := currentBlock > nextUpdatableBlock
 	// Get the correct fee recipient address
-	var correctFeeRecipient common.Address
-	if feeRecipientInfo.IsInSocializingPool {
-		if updatable {
-			correctFeeRecipient = feeRecipientInfo.SocializingPoolAddress
-		} else {
-			correctFeeRecipient = feeRecipientInfo.FeeDistributorAddress
-		}
-	} else {
-		if updatable {
-			correctFeeRecipient = feeRecipientInfo.FeeDistributorAddress
-		} else {
-			correctFeeRecipient = feeRecipientInfo.SocializingPoolAddress
-		}
-	}
+	correctFeeRecipient := staderUtils.GetCorrectFeeRecipient(feeRecipientInfo, updatable)
 
 	// Check if the VC is using the correct fee recipient
 	fileExists, correctAddress, err := staderService.CheckFeeRecipientFile(correctFeeRecipient, m.cfg)
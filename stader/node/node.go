@@ -26,18 +26,13 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
-	"strconv"
 	"sync"
 	"time"
 
 	stader_backend "github.com/stader-labs/stader-node/shared/types/stader-backend"
-	"github.com/stader-labs/stader-node/shared/utils/crypto"
-	"github.com/stader-labs/stader-node/shared/utils/eth2"
 	"github.com/stader-labs/stader-node/shared/utils/stader"
 	"github.com/stader-labs/stader-node/shared/utils/stdr"
-	"github.com/stader-labs/stader-node/shared/utils/validator"
 	"github.com/stader-labs/stader-node/stader-lib/node"
-	eth2types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/fatih/color"
 	"github.com/urfave/cli"
@@ -162,7 +157,7 @@ func run(c *cli.Context) error {
 			// make a map of all validators actually registered with stader
 			// user might just move the validator keys to the directory. we don't wanna send the presigned msg of them
 			infoLog.Println("Building a map of user validators registered with stader")
-			registeredValidators, validatorPubKeys, err := stdr.GetAllValidatorsRegisteredWithOperator(pnr, operatorId, nodeAccount.Address, nil)
+			registeredValidators, validatorPubKeys, err := stdr.GetAllValidatorsRegisteredWithOperator(pnr, nil, operatorId, nodeAccount.Address, nil)
 			if err != nil {
 				errorLog.Printf("Could not get all validators registered with operator %s with error %s\n", operatorId, err.Error())
 				continue
@@ -183,6 +178,13 @@ func run(c *cli.Context) error {
 				continue
 			}
 
+			// A watch-only wallet has no validator keys to presign exit messages with
+			if w.IsWatchOnly() {
+				infoLog.Println("The node wallet is watch-only, so no presigned exit messages can be sent")
+				time.Sleep(preSignedCooldown)
+				continue
+			}
+
 			preSignRegisteredMap, err := stader.BulkIsPresignedKeyRegistered(c, validatorPubKeys)
 			if err != nil {
 				errorLog.Printf("Could not bulk check presigned keys with error %s\n", err.Error())
@@ -209,13 +211,6 @@ func run(c *cli.Context) error {
 
 				for _, validatorPubKey := range validatorKeyBatch {
 					infoLog.Printf("Checking validator pubkey %s\n", validatorPubKey.String())
-					validatorKeyPair, err := w.GetValidatorKeyByPubkey(validatorPubKey)
-					// log the errors and continue. dont need to sleep post an error
-					if err != nil {
-						errorLog.Printf("Could not find validator private key for %s with err: %s\n", validatorPubKey, err.Error())
-						continue
-					}
-
 					validatorInfo, ok := registeredValidators[validatorPubKey]
 					if !ok {
 						errorLog.Printf("Validator pub key: %s not found in stader contracts\n", validatorPubKey)
@@ -238,58 +233,25 @@ func run(c *cli.Context) error {
 						infoLog.Printf("Validator pub key: %s pre signed key not registered. Creating presigned message\n", validatorPubKey)
 					}
 
-					// check if validator has not yet been registered on beacon chain
-					validatorStatus, err := bc.GetValidatorStatus(validatorPubKey, nil)
-					if err != nil {
-						errorLog.Printf("Error finding validator status for validator: %s with err: %s\n", validatorPubKey, err.Error())
-						continue
-					}
-					if !validatorStatus.Exists {
-						errorLog.Printf("Validator pub key: %s not found on beacon chain\n", validatorPubKey)
-						continue
-					}
-
-					// check if validator is already in an exiting phase, then no point sending a pre-signed message
-					if eth2.IsValidatorExiting(validatorStatus) {
-						errorLog.Printf("Validator pub key: %s already exiting or exited with status %s", validatorPubKey, validatorStatus.Status)
-						continue
-					}
-
-					exitEpoch := currentHead.Epoch
-
-					signatureDomain, err := bc.GetExitDomainData(eth2types.DomainVoluntaryExit[:])
+					// log the errors and continue. dont need to sleep post an error
+					signer, err := services.GetValidatorSigner(c, validatorPubKey)
 					if err != nil {
-						errorLog.Printf("Failed to get the signature domain from beacon chain with err: %s\n", err.Error())
+						errorLog.Printf("Could not get a signer for validator: %s with err: %s\n", validatorPubKey, err.Error())
 						continue
 					}
 
-					// get the presigned msg
-					exitSignature, _, err := validator.GetSignedExitMessage(validatorKeyPair, validatorStatus.Index, exitEpoch, signatureDomain)
+					preSignedMessage, ok, err := stader.BuildPresignedMessage(bc, signer, validatorPubKey, currentHead.Epoch, publicKey)
 					if err != nil {
-						errorLog.Printf("Failed to generate the SignedExitMessage for validator with beacon chain index: %d with err: %s\n", validatorStatus.Index, err.Error())
+						errorLog.Printf("Could not create presigned message for validator: %s with err: %s\n", validatorPubKey, err.Error())
 						continue
 					}
-
-					// encrypt the signature and srHash
-					exitSignatureEncrypted, err := crypto.EncryptUsingPublicKey([]byte(exitSignature.String()), publicKey)
-					if err != nil {
-						errorLog.Printf("Failed to encrypt exit signature for validator: %s with err: %s\n", validatorPubKey, err.Error())
+					if !ok {
+						errorLog.Printf("Validator pub key: %s not found on beacon chain or already exiting\n", validatorPubKey)
 						continue
 					}
-					exitSignatureEncryptedString := crypto.EncodeBase64(exitSignatureEncrypted)
 
 					// send it to the presigned api
-					preSignSendMessages = append(preSignSendMessages, stader_backend.PreSignSendApiRequestType{
-						Message: struct {
-							Epoch          string `json:"epoch"`
-							ValidatorIndex string `json:"validator_index"`
-						}{
-							Epoch:          strconv.FormatUint(exitEpoch, 10),
-							ValidatorIndex: strconv.FormatUint(validatorStatus.Index, 10),
-						},
-						Signature:          exitSignatureEncryptedString,
-						ValidatorPublicKey: validatorPubKey.String(),
-					})
+					preSignSendMessages = append(preSignSendMessages, preSignedMessage)
 				}
 
 				//fmt.Printf("Sending %d presigned messages to stader backend\n", len(preSignSendMessages))
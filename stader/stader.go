@@ -799,6 +799,14 @@ Public License instead of this License. But first, please read
 			Name:  "force-fallbacks",
 			Usage: "Set this to true if you know the primary EC or CC is offline and want to bypass its health checks, and just use the fallback EC and CC instead",
 		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Simulate the first transaction a command sends against the latest block and respond with the simulation instead of signing and sending it",
+		},
+		cli.BoolFlag{
+			Name:  "export-unsigned-tx",
+			Usage: "Respond with the first transaction a command sends, unsigned, so it can be signed offline instead of signing and sending it",
+		},
 	}
 
 	// Register commands